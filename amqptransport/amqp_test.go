@@ -0,0 +1,270 @@
+package amqptransport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeChannel is an in-memory Channel. Messages are pushed to queues with deliver,
+// settlements and publishes are recorded
+type fakeChannel struct {
+	mu        sync.Mutex
+	failQueue string // Consume of this queue fails
+	consumers map[string]chan amqp.Delivery
+	queues    map[string]string // Consumer tag to queue
+	cancelled []string
+	published []fakePublish
+	settled   []string
+	tags      uint64
+}
+
+type fakePublish struct {
+	exchange, key string
+	msg           amqp.Publishing
+}
+
+func newFakeChannel() *fakeChannel {
+	return &fakeChannel{consumers: map[string]chan amqp.Delivery{}, queues: map[string]string{}}
+}
+
+func (f *fakeChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (f *fakeChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, nil
+}
+
+func (f *fakeChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (f *fakeChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	return nil
+}
+
+func (f *fakeChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if queue == f.failQueue {
+		return nil, errors.New("access refused")
+	}
+	if _, ok := f.consumers[consumer]; ok || consumer == "" {
+		return nil, fmt.Errorf("invalid consumer tag %q", consumer)
+	}
+	ch := make(chan amqp.Delivery, 10)
+	f.consumers[consumer] = ch
+	f.queues[consumer] = queue
+	return ch, nil
+}
+
+func (f *fakeChannel) Cancel(consumer string, noWait bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch, ok := f.consumers[consumer]
+	if !ok {
+		return fmt.Errorf("unknown consumer %s", consumer)
+	}
+	delete(f.consumers, consumer)
+	f.cancelled = append(f.cancelled, f.queues[consumer])
+	close(ch)
+	return nil
+}
+
+func (f *fakeChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published = append(f.published, fakePublish{exchange: exchange, key: key, msg: msg})
+	return nil
+}
+
+func (f *fakeChannel) Close() error {
+	return nil
+}
+
+// deliver pushes a message to the consumer of a queue
+func (f *fakeChannel) deliver(queue string, body string, headers amqp.Table) {
+	f.mu.Lock()
+	f.tags++
+	d := amqp.Delivery{Acknowledger: f, DeliveryTag: f.tags, Body: []byte(body), Headers: headers}
+	var ch chan amqp.Delivery
+	for tag, q := range f.queues {
+		if q == queue && f.consumers[tag] != nil {
+			ch = f.consumers[tag]
+		}
+	}
+	f.mu.Unlock()
+	ch <- d
+}
+
+func (f *fakeChannel) Ack(tag uint64, multiple bool) error {
+	return f.settle(fmt.Sprintf("ack %d", tag))
+}
+
+func (f *fakeChannel) Nack(tag uint64, multiple, requeue bool) error {
+	return f.settle(fmt.Sprintf("nack %d requeue %v", tag, requeue))
+}
+
+func (f *fakeChannel) Reject(tag uint64, requeue bool) error {
+	return f.settle(fmt.Sprintf("reject %d requeue %v", tag, requeue))
+}
+
+func (f *fakeChannel) settle(s string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.settled = append(f.settled, s)
+	return nil
+}
+
+// state returns copies of the recorded calls
+func (f *fakeChannel) state() (cancelled, settled []string, published []fakePublish) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.cancelled...), append([]string(nil), f.settled...), append([]fakePublish(nil), f.published...)
+}
+
+// waitFor polls cond until it holds or a second elapsed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxRedeliveries int
+		headers         amqp.Table
+		requeue         bool
+		settled         string
+		republished     int32 // Redelivery count of the requeued copy, 0 when not requeued
+	}{
+		{"requeue", 0, nil, true, "ack 1", 1},
+		{"requeue again", 0, amqp.Table{RedeliveriesHeader: int32(2)}, true, "ack 1", 3},
+		{"redeliveries exhausted", 0, amqp.Table{RedeliveriesHeader: int32(3)}, true, "reject 1 requeue false", 0},
+		{"dead letter", 0, nil, false, "reject 1 requeue false", 0},
+		{"no redeliveries", -1, nil, true, "reject 1 requeue false", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ch := newFakeChannel()
+			tr := New(ch, Config{Group: "g", MaxRedeliveries: tt.maxRedeliveries})
+			msgs, err := tr.Subscribe(ctx, "mail", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ch.deliver("mail.g", `{}`, tt.headers)
+			d := <-msgs
+			if d.Redelivered != (tt.headers != nil) {
+				t.Errorf("Redelivered = %v", d.Redelivered)
+			}
+			if err = d.Reject(tt.requeue); err != nil {
+				t.Fatal(err)
+			}
+			if err = d.Ack(); err == nil {
+				t.Error("Ack after Reject succeeded")
+			}
+
+			_, settled, published := ch.state()
+			if len(settled) != 1 || settled[0] != tt.settled {
+				t.Errorf("settled = %v, want %s", settled, tt.settled)
+			}
+			if tt.republished == 0 {
+				if len(published) != 0 {
+					t.Errorf("published %v, want nothing", published)
+				}
+				return
+			}
+			if len(published) != 1 || published[0].key != "mail.g" || published[0].msg.Headers[RedeliveriesHeader] != tt.republished {
+				t.Errorf("published %+v, want a copy to mail.g with %d redeliveries", published, tt.republished)
+			}
+		})
+	}
+}
+
+func TestSubscribeCancelsConsumer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := newFakeChannel()
+	tr := New(ch, Config{Group: "g"})
+	msgs, err := tr.Subscribe(ctx, "engineEvents", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The message is not read, it is requeued when the consumer stops
+	ch.deliver("engineEvents.g", `[]`, nil)
+	cancel()
+	waitFor(t, "consumer cancel", func() bool {
+		cancelled, settled, _ := ch.state()
+		return len(cancelled) == 1 && len(settled) == 1
+	})
+	_, settled, _ := ch.state()
+	if settled[0] != "nack 1 requeue true" {
+		t.Errorf("settled = %v, want the undelivered message requeued", settled)
+	}
+	if _, ok := <-msgs; ok {
+		t.Error("delivery channel open after cancel")
+	}
+}
+
+func TestReceiveCancelsSubscriptionsOnError(t *testing.T) {
+	ch := newFakeChannel()
+	ch.failQueue = "sms.g"
+	tr := New(ch, Config{Group: "g"})
+
+	_, err := tr.Connector().Receive(context.Background(), []string{"mail", "push", "sms"})
+	if err == nil {
+		t.Fatal("Receive succeeded, want the consume error")
+	}
+	waitFor(t, "consumer cancels", func() bool {
+		cancelled, _, _ := ch.state()
+		return len(cancelled) == 2
+	})
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if len(ch.consumers) != 0 {
+		t.Errorf("%d consumers left", len(ch.consumers))
+	}
+}
+
+func TestReceive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := newFakeChannel()
+	tr := New(ch, Config{Group: "g"})
+	deliveries, err := tr.Connector().Receive(ctx, []string{"mail", "sms"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch.deliver("sms.g", `{"id":"r1"}`, nil)
+	d := <-deliveries
+	if string(d.Body) != `{"id":"r1"}` {
+		t.Errorf("body = %s", d.Body)
+	}
+	if err = d.Ack(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	for range deliveries {
+	}
+	waitFor(t, "consumer cancels", func() bool {
+		cancelled, _, _ := ch.state()
+		return len(cancelled) == 2
+	})
+}
@@ -0,0 +1,88 @@
+package amqptransport
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lihongchen/go-activiti-rest/connector"
+)
+
+// connectorTransport adapts Transport to connector.Transport
+type connectorTransport struct {
+	t *Transport
+}
+
+// Connector returns the transport of a connector worker
+func (t *Transport) Connector() connector.Transport {
+	return connectorTransport{t: t}
+}
+
+// Events delivers the runtime bundle events, each message holds a JSON array of events
+func (t *Transport) Events(ctx context.Context) (<-chan Delivery, error) {
+	return t.Subscribe(ctx, t.cfg.EventsExchange, "")
+}
+
+// Receive implements connector.Transport, with a group queue per connector type.
+// When a subscription fails the consumers already started are cancelled
+func (c connectorTransport) Receive(ctx context.Context, connectorTypes []string) (<-chan connector.Delivery, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var subs []<-chan Delivery
+	for _, ct := range connectorTypes {
+		in, err := c.t.Subscribe(ctx, c.t.cfg.RequestExchange(ct), "")
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		subs = append(subs, in)
+	}
+
+	out := make(chan connector.Delivery)
+	done := make(chan struct{})
+	for _, in := range subs {
+		go func(in <-chan Delivery) {
+			defer func() { done <- struct{}{} }()
+			for d := range in {
+				select {
+				case out <- connector.Delivery{Body: d.Body, Redelivered: d.Redelivered, Ack: d.Ack, Reject: d.Reject}:
+				case <-ctx.Done():
+					// Unsettled messages return to the queue when the channel closes
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		for range subs {
+			<-done
+		}
+		cancel()
+		close(out)
+	}()
+	return out, nil
+}
+
+// PublishResult implements connector.Transport
+func (c connectorTransport) PublishResult(ctx context.Context, res *connector.IntegrationResult) error {
+	body, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return c.t.Publish(ctx, c.t.cfg.ResultExchange(serviceFullName(&res.IntegrationRequest)), body, nil)
+}
+
+// PublishError implements connector.Transport
+func (c connectorTransport) PublishError(ctx context.Context, ie *connector.IntegrationError) error {
+	body, err := json.Marshal(ie)
+	if err != nil {
+		return err
+	}
+	return c.t.Publish(ctx, c.t.cfg.ErrorExchange(serviceFullName(&ie.IntegrationRequest)), body, nil)
+}
+
+// serviceFullName is the runtime bundle which sent the request
+func serviceFullName(req *connector.IntegrationRequest) string {
+	if req.ServiceFullName != "" {
+		return req.ServiceFullName
+	}
+	return req.ServiceName
+}
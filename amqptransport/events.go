@@ -0,0 +1,38 @@
+package amqptransport
+
+import (
+	"context"
+
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+// eventSource adapts Transport to events.Source
+type eventSource struct {
+	t *Transport
+}
+
+// EventSource returns the runtime events of the group queue of the events destination
+func (t *Transport) EventSource() events.Source {
+	return eventSource{t: t}
+}
+
+// Messages implements events.Source
+func (s eventSource) Messages(ctx context.Context) (<-chan events.Message, error) {
+	in, err := s.t.Events(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan events.Message)
+	go func() {
+		defer close(out)
+		for d := range in {
+			select {
+			case out <- events.Message{Body: d.Body, Ack: d.Ack, Reject: d.Reject}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
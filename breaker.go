@@ -0,0 +1,289 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the circuit breaker of a service
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests are sent
+	CircuitOpen                         // Requests fail fast with a CircuitOpenError
+	CircuitHalfOpen                     // A limited number of probe requests are sent
+)
+
+// ErrCircuitOpen matches every CircuitOpenError with errors.Is
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type (
+	// CircuitBreakerSettings configures the circuit breaker of a client
+	CircuitBreakerSettings struct {
+		FailureThreshold int           // Consecutive failures which open the circuit, 5 when 0
+		OpenTimeout      time.Duration // Time the circuit stays open before probing, 30s when 0
+		HalfOpenRequests int           // Concurrent probes while half-open, 1 when 0
+
+		// IsFailure decides whether a request counts as a failure. By default transport
+		// errors, except cancellation by the caller, and 5xx responses are failures
+		IsFailure func(resp *http.Response, err error) bool
+
+		// OnStateChange is called on every state change, for example for alerting.
+		// service is the scheme, host and first path segment, such as 'http://gateway/rb'
+		OnStateChange func(service string, from, to CircuitState)
+	}
+
+	// CircuitOpenError is returned without sending the request while the circuit of a service is open
+	CircuitOpenError struct {
+		Service string
+		State   CircuitState
+	}
+
+	// breakers holds one circuit per service, it is shared by the copies made with
+	// WithHeader and WithContext, also when they are made before SetCircuitBreaker
+	breakers struct {
+		mu       sync.Mutex
+		enabled  bool
+		settings CircuitBreakerSettings
+		circuits map[string]*circuit
+		now      func() time.Time
+	}
+
+	circuit struct {
+		state      CircuitState
+		generation uint64 // Incremented on every state change
+		failures   int
+		openedAt   time.Time
+		probes     int
+	}
+
+	// stateChange is a transition reported to OnStateChange after the lock is released
+	stateChange struct {
+		service  string
+		from, to CircuitState
+	}
+)
+
+// SetCircuitBreaker enables a circuit breaker per service base url.
+// After FailureThreshold consecutive failures requests to the service fail fast
+// with a CircuitOpenError, until a probe after OpenTimeout succeeds.
+// Calling it again replaces the settings and closes all circuits
+func (c *ActClient) SetCircuitBreaker(s CircuitBreakerSettings) {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = 5
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = 30 * time.Second
+	}
+	if s.HalfOpenRequests <= 0 {
+		s.HalfOpenRequests = 1
+	}
+	if s.IsFailure == nil {
+		s.IsFailure = isFailure
+	}
+	b := c.circuitBreakers()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.enabled = true
+	b.settings = s
+	b.reset()
+}
+
+// DisableCircuitBreaker stops the circuit breaker, requests are sent whatever the state of their service
+func (c *ActClient) DisableCircuitBreaker() {
+	b := c.circuitBreakers()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.enabled = false
+	b.reset()
+}
+
+// CircuitState returns the state of the circuit of a service, such as 'http://gateway/rb'
+func (c *ActClient) CircuitState(service string) CircuitState {
+	b := c.circuitBreakers()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb, ok := b.circuits[service]; ok {
+		return cb.state
+	}
+	return CircuitClosed
+}
+
+// circuitBreakers returns the circuit breakers of the client, see limits
+func (c *ActClient) circuitBreakers() *breakers {
+	if b := c.breakers; b != nil {
+		return b
+	}
+	lazyInit.Lock()
+	defer lazyInit.Unlock()
+	if c.breakers == nil {
+		c.breakers = newBreakers()
+	}
+	return c.breakers
+}
+
+// newBreakers returns disabled circuit breakers
+func newBreakers() *breakers {
+	return &breakers{circuits: map[string]*circuit{}, now: time.Now}
+}
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// Error implements error
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v: %s is %s", ErrCircuitOpen, e.Service, e.State)
+}
+
+// Unwrap makes errors.Is(err, ErrCircuitOpen) true
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// serviceKey returns the scheme, host and first path segment of a url
+func serviceKey(u *url.URL) string {
+	segment := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+	return u.Scheme + "://" + u.Host + "/" + segment
+}
+
+// isFailure is the default CircuitBreakerSettings.IsFailure
+func isFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= 500
+}
+
+// allow reports whether a request to the service may be sent and returns the
+// generation of the circuit which admitted it, tracked is false while disabled.
+// A tracked request must be followed by a call to done or abort with that generation
+func (b *breakers) allow(service string) (generation uint64, tracked bool, err error) {
+	b.mu.Lock()
+	var changes []stateChange
+	onStateChange := b.settings.OnStateChange
+	defer func() {
+		b.mu.Unlock()
+		notify(onStateChange, changes)
+	}()
+
+	if !b.enabled {
+		return 0, false, nil
+	}
+
+	cb, ok := b.circuits[service]
+	if !ok {
+		cb = &circuit{}
+		b.circuits[service] = cb
+	}
+
+	if cb.state == CircuitOpen {
+		if b.now().Sub(cb.openedAt) < b.settings.OpenTimeout {
+			return 0, true, &CircuitOpenError{Service: service, State: CircuitOpen}
+		}
+		changes = append(changes, cb.set(service, CircuitHalfOpen))
+		cb.probes = 0
+	}
+	if cb.state == CircuitHalfOpen {
+		if cb.probes >= b.settings.HalfOpenRequests {
+			return 0, true, &CircuitOpenError{Service: service, State: CircuitHalfOpen}
+		}
+		cb.probes++
+	}
+	return cb.generation, true, nil
+}
+
+// done records the outcome of an allowed request. Requests admitted before the
+// last state change finish in a later generation, their outcome changes nothing
+func (b *breakers) done(service string, generation uint64, resp *http.Response, err error) {
+	b.mu.Lock()
+	isFailure := b.settings.IsFailure
+	b.mu.Unlock()
+	failed := isFailure(resp, err)
+
+	b.mu.Lock()
+	var changes []stateChange
+	onStateChange := b.settings.OnStateChange
+	defer func() {
+		b.mu.Unlock()
+		notify(onStateChange, changes)
+	}()
+
+	cb := b.circuits[service]
+	if cb.generation != generation {
+		return
+	}
+	switch cb.state {
+	case CircuitClosed:
+		if !failed {
+			cb.failures = 0
+			return
+		}
+		cb.failures++
+		if cb.failures >= b.settings.FailureThreshold {
+			changes = append(changes, cb.set(service, CircuitOpen))
+			cb.openedAt = b.now()
+		}
+	case CircuitHalfOpen:
+		if failed {
+			changes = append(changes, cb.set(service, CircuitOpen))
+			cb.openedAt = b.now()
+			return
+		}
+		changes = append(changes, cb.set(service, CircuitClosed))
+		cb.failures = 0
+	}
+}
+
+// abort frees the probe slot of an allowed request which was not sent or was
+// cancelled by the caller, its outcome says nothing about the service
+func (b *breakers) abort(service string, generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb := b.circuits[service]; cb.generation == generation && cb.state == CircuitHalfOpen {
+		cb.probes--
+	}
+}
+
+// set changes the state, starting a new generation, and returns the transition
+func (cb *circuit) set(service string, to CircuitState) stateChange {
+	from := cb.state
+	cb.state = to
+	cb.generation++
+	return stateChange{service: service, from: from, to: to}
+}
+
+// reset closes all circuits in a new generation, so requests in flight change nothing
+func (b *breakers) reset() {
+	for _, cb := range b.circuits {
+		cb.state = CircuitClosed
+		cb.generation++
+		cb.failures = 0
+		cb.probes = 0
+	}
+}
+
+// notify calls onStateChange for each transition
+func notify(onStateChange func(service string, from, to CircuitState), changes []stateChange) {
+	if onStateChange == nil {
+		return
+	}
+	for _, ch := range changes {
+		onStateChange(ch.service, ch.from, ch.to)
+	}
+}
@@ -0,0 +1,200 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreakerStateMachine(t *testing.T) {
+	const service = "http://gw/rb"
+	ok := &http.Response{StatusCode: 200}
+	fail := &http.Response{StatusCode: 503}
+
+	// A step admits request n, or finishes it with resp, or aborts it
+	type step struct {
+		admit, finish, abort int
+		resp                 *http.Response
+		advance              time.Duration
+		err                  bool // admit fails with a CircuitOpenError
+		state                CircuitState
+	}
+	// open fails two requests with FailureThreshold 2
+	open := []step{
+		{admit: 1, state: CircuitClosed},
+		{finish: 1, resp: fail, state: CircuitClosed},
+		{admit: 2, state: CircuitClosed},
+		{finish: 2, resp: fail, state: CircuitOpen},
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"failures open the circuit", append(open,
+			step{admit: 3, err: true, state: CircuitOpen},
+		)},
+		{"success resets failures", []step{
+			{admit: 1, state: CircuitClosed},
+			{finish: 1, resp: fail, state: CircuitClosed},
+			{admit: 2, state: CircuitClosed},
+			{finish: 2, resp: ok, state: CircuitClosed},
+			{admit: 3, state: CircuitClosed},
+			{finish: 3, resp: fail, state: CircuitClosed},
+		}},
+		{"probe closes the circuit", append(open,
+			step{advance: time.Minute, admit: 3, state: CircuitHalfOpen},
+			step{admit: 4, err: true, state: CircuitHalfOpen},
+			step{finish: 3, resp: ok, state: CircuitClosed},
+		)},
+		{"failed probe reopens the circuit", append(open,
+			step{advance: time.Minute, admit: 3, state: CircuitHalfOpen},
+			step{finish: 3, resp: fail, state: CircuitOpen},
+			step{admit: 4, err: true, state: CircuitOpen},
+		)},
+		{"aborted probe frees its slot", append(open,
+			step{advance: time.Minute, admit: 3, state: CircuitHalfOpen},
+			step{abort: 3, state: CircuitHalfOpen},
+			step{admit: 4, state: CircuitHalfOpen},
+		)},
+		{"stale success does not close the circuit", []step{
+			{admit: 1, state: CircuitClosed},
+			{admit: 2, state: CircuitClosed},
+			{admit: 3, state: CircuitClosed},
+			{finish: 1, resp: fail, state: CircuitClosed},
+			{finish: 2, resp: fail, state: CircuitOpen},
+			{advance: time.Minute, admit: 4, state: CircuitHalfOpen},
+			{finish: 3, resp: ok, state: CircuitHalfOpen},
+			{admit: 5, err: true, state: CircuitHalfOpen},
+		}},
+		{"stale abort does not free a probe slot", []step{
+			{admit: 1, state: CircuitClosed},
+			{admit: 2, state: CircuitClosed},
+			{admit: 3, state: CircuitClosed},
+			{finish: 1, resp: fail, state: CircuitClosed},
+			{finish: 2, resp: fail, state: CircuitOpen},
+			{advance: time.Minute, admit: 4, state: CircuitHalfOpen},
+			{abort: 3, state: CircuitHalfOpen},
+			{admit: 5, err: true, state: CircuitHalfOpen},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+			c := &ActClient{}
+			c.SetCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: 30 * time.Second})
+			c.breakers.now = func() time.Time { return now }
+
+			admitted := map[int]uint64{}
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				switch {
+				case s.admit > 0:
+					gen, _, err := c.breakers.allow(service)
+					if s.err != errors.Is(err, ErrCircuitOpen) {
+						t.Fatalf("step %d: allow = %v, want open error %v", i, err, s.err)
+					}
+					admitted[s.admit] = gen
+				case s.abort > 0:
+					c.breakers.abort(service, admitted[s.abort])
+				default:
+					c.breakers.done(service, admitted[s.finish], s.resp, nil)
+				}
+				if got := c.CircuitState(service); got != s.state {
+					t.Fatalf("step %d: state = %s, want %s", i, got, s.state)
+				}
+			}
+		})
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	tests := []struct {
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{&http.Response{StatusCode: 200}, nil, false},
+		{&http.Response{StatusCode: 404}, nil, false},
+		{&http.Response{StatusCode: 502}, nil, true},
+		{nil, errors.New("connection refused"), true},
+		{nil, context.Canceled, false},
+	}
+	for _, tt := range tests {
+		if got := isFailure(tt.resp, tt.err); got != tt.want {
+			t.Errorf("isFailure(%v, %v) = %v, want %v", tt.resp, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBreakerSharedAndDisabled(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		newClient func() *ActClient
+	}{
+		// The copies are made before the breaker is set
+		{"NewClient", func() *ActClient {
+			c, _ := NewClient("token", srv.URL)
+			return c
+		}},
+		// Struct literals get the breakers on first use, only later copies share them
+		{"struct literal", func() *ActClient {
+			c := &ActClient{Client: &http.Client{}, Token: "token", BaseURL: srv.URL}
+			c.CircuitState("")
+			return c
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			c := tt.newClient()
+			header := c.WithHeader("Accept-Language", "en")
+			ctx := c.WithContext(context.Background())
+			c.SetCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Hour})
+
+			if _, err := c.GetTask("t1"); errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("first request = %v, want it sent", err)
+			}
+			for _, cc := range []*ActClient{c, header, ctx} {
+				if _, err := cc.GetTask("t1"); !errors.Is(err, ErrCircuitOpen) {
+					t.Errorf("GetTask = %v, want %v", err, ErrCircuitOpen)
+				}
+			}
+			if n := atomic.LoadInt32(&calls); n != 1 {
+				t.Errorf("%d requests sent, want 1", n)
+			}
+
+			header.DisableCircuitBreaker()
+			u, _ := url.Parse(srv.URL + "/tasks/t1")
+			if got := c.CircuitState(serviceKey(u)); got != CircuitClosed {
+				t.Errorf("state after disable = %s, want %s", got, CircuitClosed)
+			}
+			for i := 0; i < 3; i++ {
+				if _, err := ctx.GetTask("t1"); errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("GetTask after disable = %v", err)
+				}
+			}
+			if n := atomic.LoadInt32(&calls); n != 4 {
+				t.Errorf("%d requests sent, want 4", n)
+			}
+
+			// Enabling again starts with closed circuits
+			c.SetCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Hour})
+			if _, err := header.GetTask("t1"); errors.Is(err, ErrCircuitOpen) {
+				t.Errorf("GetTask after enable = %v, want it sent", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package activiti
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBulkWorkers is the number of concurrent requests when BulkOptions.Workers is 0
+const DefaultBulkWorkers = 4
+
+type (
+	// BulkOptions configures a batch operation
+	BulkOptions struct {
+		Workers int     // Concurrent requests, DefaultBulkWorkers when 0
+		Rate    float64 // Maximum requests per second over all workers, unlimited when 0 or above 1e9
+	}
+
+	// BulkItemResult is the outcome of a batch operation for one id
+	BulkItemResult struct {
+		ID  string
+		Err error
+	}
+
+	// BulkResult reports a batch operation, Items are in the order of the ids
+	BulkResult struct {
+		Items     []BulkItemResult
+		Succeeded int
+		Failed    int
+	}
+)
+
+// Err returns nil when every item succeeded, otherwise an error summarising the failures
+func (r *BulkResult) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	for _, item := range r.Items {
+		if item.Err != nil {
+			return fmt.Errorf("%d of %d items failed, first %s: %w", r.Failed, len(r.Items), item.ID, item.Err)
+		}
+	}
+	return nil
+}
+
+// Failures returns the failed items
+func (r *BulkResult) Failures() []BulkItemResult {
+	var failed []BulkItemResult
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// CancelProcessInstances cancels process instances, for example all ids returned by
+// c.QueryProcessInstanceIDs(url.Values{"status": {"SUSPENDED"}})
+func (c *ActClient) CancelProcessInstances(ctx context.Context, ids []string, opts BulkOptions) *BulkResult {
+	return runBulk(ctx, ids, opts, c.WithContext(ctx).Cancel)
+}
+
+// CompleteTasks completes tasks, each with the same variables
+func (c *ActClient) CompleteTasks(ctx context.Context, ids []string, variables map[string]interface{}, opts BulkOptions) *BulkResult {
+	cc := c.WithContext(ctx)
+	return runBulk(ctx, ids, opts, func(id string) error {
+		return cc.CompleteTask(id, variables)
+	})
+}
+
+// AssignTasks assigns tasks to assignee
+func (c *ActClient) AssignTasks(ctx context.Context, ids []string, assignee string, opts BulkOptions) *BulkResult {
+	cc := c.WithContext(ctx)
+	return runBulk(ctx, ids, opts, func(id string) error {
+		return cc.TaskActionAssign(id, assignee)
+	})
+}
+
+// SetVariablesBulk sets the same variables on process instances
+func (c *ActClient) SetVariablesBulk(ctx context.Context, ids []string, variables map[string]interface{}, opts BulkOptions) *BulkResult {
+	cc := c.WithContext(ctx)
+	return runBulk(ctx, ids, opts, func(id string) error {
+		return cc.SetProcessVariables(id, variables)
+	})
+}
+
+// runBulk calls fn for every id with a pool of workers.
+// Once ctx is done the remaining ids fail with the context error, the
+// requests made by fn are cancelled with ctx
+func runBulk(ctx context.Context, ids []string, opts BulkOptions, fn func(id string) error) *BulkResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+
+	var tick <-chan time.Time
+	// Rates above one request per nanosecond are not limited
+	if interval := time.Duration(float64(time.Second) / opts.Rate); opts.Rate > 0 && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(ids))}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result.Items[i] = BulkItemResult{ID: ids[i], Err: runBulkItem(ctx, tick, ids[i], fn)}
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, item := range result.Items {
+		if item.Err != nil {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+	return result
+}
+
+// runBulkItem waits for the rate limit and calls fn
+func runBulkItem(ctx context.Context, tick <-chan time.Time, id string, fn func(id string) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tick != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick:
+		}
+	}
+	return fn(id)
+}
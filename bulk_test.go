@@ -0,0 +1,76 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulk(t *testing.T) {
+	errFail := errors.New("fail")
+	tests := []struct {
+		name      string
+		ids       []string
+		opts      BulkOptions
+		succeeded int
+		failed    int
+	}{
+		{"empty", nil, BulkOptions{}, 0, 0},
+		{"unlimited", []string{"a", "b", "fail"}, BulkOptions{}, 2, 1},
+		{"rate", []string{"a", "b", "c"}, BulkOptions{Workers: 2, Rate: 1000}, 3, 0},
+		{"rate above 1e9", []string{"a", "fail"}, BulkOptions{Rate: 2e9}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runBulk(context.Background(), tt.ids, tt.opts, func(id string) error {
+				if id == "fail" {
+					return errFail
+				}
+				return nil
+			})
+			if res.Succeeded != tt.succeeded || res.Failed != tt.failed {
+				t.Errorf("succeeded/failed = %d/%d, want %d/%d", res.Succeeded, res.Failed, tt.succeeded, tt.failed)
+			}
+			for i, item := range res.Items {
+				if item.ID != tt.ids[i] {
+					t.Errorf("item %d = %s, want %s", i, item.ID, tt.ids[i])
+				}
+			}
+			if tt.failed > 0 && !errors.Is(res.Err(), errFail) {
+				t.Errorf("Err() = %v, want %v", res.Err(), errFail)
+			}
+		})
+	}
+}
+
+func TestBulkCancelsInFlightRequests(t *testing.T) {
+	var started int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&started, 1)
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c, _ := NewClient("token", srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res := c.AssignTasks(ctx, []string{"t1", "t2", "t3"}, "bob", BulkOptions{Workers: 2})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("AssignTasks took %v, in flight requests were not cancelled", elapsed)
+	}
+	if res.Failed != 3 {
+		t.Errorf("Failed = %d, want 3", res.Failed)
+	}
+	if n := atomic.LoadInt32(&started); n != 2 {
+		t.Errorf("%d requests sent, want 2", n)
+	}
+}
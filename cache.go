@@ -0,0 +1,74 @@
+package activiti
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type (
+	// definitionCache keeps process definition responses by url for a TTL.
+	// Responses are stored as JSON so callers never share decoded values
+	definitionCache struct {
+		mu      sync.Mutex
+		ttl     time.Duration
+		entries map[string]cacheEntry
+		now     func() time.Time
+	}
+
+	cacheEntry struct {
+		data    []byte
+		expires time.Time
+	}
+)
+
+// EnableDefinitionCache caches process definitions, the definition list and their /meta
+// for ttl. Definitions are immutable per id, the ttl bounds how long a new deployment
+// takes to show up in GetProcessDefinitions and GetLatestProcessDefinition.
+// A ttl of 0 disables the cache
+func (c *ActClient) EnableDefinitionCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.definitionCache = nil
+		return
+	}
+	c.definitionCache = &definitionCache{ttl: ttl, entries: map[string]cacheEntry{}, now: time.Now}
+}
+
+// InvalidateDefinitionCache drops all cached definitions, for example after a deployment
+func (c *ActClient) InvalidateDefinitionCache() {
+	if dc := c.definitionCache; dc != nil {
+		dc.mu.Lock()
+		dc.entries = map[string]cacheEntry{}
+		dc.mu.Unlock()
+	}
+}
+
+// get decodes the cached response of url into v and reports whether there was one
+func (dc *definitionCache) get(url string, v interface{}) bool {
+	if dc == nil {
+		return false
+	}
+	dc.mu.Lock()
+	e, ok := dc.entries[url]
+	if ok && !dc.now().Before(e.expires) {
+		delete(dc.entries, url)
+		ok = false
+	}
+	dc.mu.Unlock()
+
+	return ok && json.Unmarshal(e.data, v) == nil
+}
+
+// put stores the response of url
+func (dc *definitionCache) put(url string, v interface{}) {
+	if dc == nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	dc.mu.Lock()
+	dc.entries[url] = cacheEntry{data: data, expires: dc.now().Add(dc.ttl)}
+	dc.mu.Unlock()
+}
@@ -2,13 +2,14 @@ package activiti
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
+	"time"
 )
 
 // NewClient returns new Client struct
@@ -22,6 +23,11 @@ func NewClient(token string, baseURL string) (*ActClient, error) {
 		Client:  &http.Client{},
 		Token:   token,
 		BaseURL: baseURL,
+		Header:  http.Header{"Accept-Language": {"zh-CN,en_US"}},
+
+		limiters:   &limiters{},
+		breakers:   newBreakers(),
+		validators: newValidators(),
 	}, nil
 }
 
@@ -31,9 +37,62 @@ func (c *ActClient) SetHTTPClient(client *http.Client) {
 }
 
 // SetLog will set/change the output destination.
-// If log file is set all requests and responses will be logged to this Writer
+// If log file is set all requests and responses, including bodies, will be logged to this Writer
 func (c *ActClient) SetLog(log io.Writer) {
-	c.Log = log
+	handler := slog.NewTextHandler(log, &slog.HandlerOptions{Level: slog.LevelDebug})
+	c.SetLogger(slog.New(handler), LogOptions{LogHeaders: true, LogBodies: true})
+}
+
+// SetLogger sets the structured logger used for requests and responses.
+// The Authorization header and password fields are always redacted
+func (c *ActClient) SetLogger(logger *slog.Logger, opts LogOptions) {
+	c.Logger = logger
+	c.LogOptions = opts
+}
+
+// SetHeader sets a default header sent with every request, for example Accept-Language
+func (c *ActClient) SetHeader(key, value string) {
+	if c.Header == nil {
+		c.Header = http.Header{}
+	}
+	c.Header.Set(key, value)
+}
+
+// WithHeader returns a copy of the client which sends the header with its requests,
+// for per call headers: c.WithHeader("Accept-Language", "en").GetTasks()
+func (c *ActClient) WithHeader(key, value string) *ActClient {
+	cc := *c
+	cc.Header = c.Header.Clone()
+	cc.SetHeader(key, value)
+	cc.middleware = append([]Middleware(nil), c.middleware...)
+	return &cc
+}
+
+// WithContext returns a copy of the client whose requests are made with ctx, they are
+// cancelled with it and its trace span is the parent of their spans:
+// c.WithContext(ctx).GetTask(id)
+func (c *ActClient) WithContext(ctx context.Context) *ActClient {
+	cc := *c
+	cc.ctx = ctx
+	cc.middleware = append([]Middleware(nil), c.middleware...)
+	return &cc
+}
+
+// context returns the context of the client requests
+func (c *ActClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// setDefaultHeaders adds the client default headers which the request has not set
+func (c *ActClient) setDefaultHeaders(req *http.Request) {
+	for k, v := range c.Header {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = append([]string(nil), v...)
+		}
+	}
 }
 
 // Send makes a request to the API, the response body will be
@@ -47,14 +106,15 @@ func (c *ActClient) Send(req *http.Request, v interface{}) error {
 	)
 
 	// Set default headers
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Accept-Language", "zh-CN,en_US")
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	c.setDefaultHeaders(req)
 	if req.Header.Get("Content-type") == "" {
 		req.Header.Set("Content-type", "application/json")
 	}
 
-	resp, err = c.Client.Do(req)
-	c.log(req, resp)
+	resp, err = c.do(req)
 
 	if err != nil {
 		return err
@@ -68,7 +128,6 @@ func (c *ActClient) Send(req *http.Request, v interface{}) error {
 		if err == nil && len(data) > 0 {
 			json.Unmarshal(data, errResp)
 		}
-		fmt.Println(string(data))
 		return errResp
 	}
 
@@ -91,6 +150,8 @@ func (c *ActClient) Send(req *http.Request, v interface{}) error {
 	}
 	return json.Unmarshal(bodyBytes, v)
 }
+
+// GetImg makes a request to the API and returns the raw response body, used for diagrams
 func (c *ActClient) GetImg(req *http.Request, v interface{}) ([]byte, error) {
 	var (
 		err  error
@@ -99,10 +160,9 @@ func (c *ActClient) GetImg(req *http.Request, v interface{}) ([]byte, error) {
 	)
 
 	// Set default headers
-	req.Header.Set("Accept-Language", "zh-CN,en_US")
+	c.setDefaultHeaders(req)
 
-	resp, err = c.Client.Do(req)
-	c.log(req, resp)
+	resp, err = c.do(req)
 
 	if err != nil {
 		return nil, err
@@ -116,7 +176,6 @@ func (c *ActClient) GetImg(req *http.Request, v interface{}) ([]byte, error) {
 		if err == nil && len(data) > 0 {
 			json.Unmarshal(data, errResp)
 		}
-		fmt.Println(string(data))
 		return nil, errResp
 	}
 
@@ -127,6 +186,42 @@ func (c *ActClient) GetImg(req *http.Request, v interface{}) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// roundTrip checks the circuit breaker, waits for the client limits,
+// sends the request with the http client and logs it
+func (c *ActClient) roundTrip(req *http.Request) (*http.Response, error) {
+	service, breakers := serviceKey(req.URL), c.circuitBreakers()
+	generation, tracked, err := breakers.allow(service)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.acquire(req)
+	if err != nil {
+		if tracked {
+			breakers.abort(service, generation)
+		}
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.Client.Do(req)
+	c.log(req, resp, err, time.Since(start))
+
+	if tracked {
+		if err != nil && req.Context().Err() != nil {
+			breakers.abort(service, generation)
+		} else {
+			breakers.done(service, generation, resp, err)
+		}
+	}
+	if err != nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
 // SendWithBasicAuth makes a request to the API using username:password basic auth
 func (c *ActClient) SendWithBasicAuth(req *http.Request, v interface{}) error {
 	req.Header.Add("Authorization", "Bearer "+c.Token)
@@ -142,6 +237,12 @@ func (c *ActClient) GetImgWithBasicAuth(req *http.Request, v interface{}) ([]byt
 // NewRequest constructs a request
 // Convert payload to a JSON
 func (c *ActClient) NewRequest(method, url string, payload interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, url, payload)
+}
+
+// NewRequestWithContext constructs a request which is cancelled with ctx
+// Convert payload to a JSON
+func (c *ActClient) NewRequestWithContext(ctx context.Context, method, url string, payload interface{}) (*http.Request, error) {
 	var buf io.Reader
 	if payload != nil {
 		var b []byte
@@ -151,24 +252,25 @@ func (c *ActClient) NewRequest(method, url string, payload interface{}) (*http.R
 		}
 		buf = bytes.NewBuffer(b)
 	}
-	return http.NewRequest(method, url, buf)
+	return http.NewRequestWithContext(ctx, method, url, buf)
 }
 
-// log will dump request and response to the log file
-func (c *ActClient) log(r *http.Request, resp *http.Response) {
-	if c.Log != nil {
-		var (
-			reqDump  string
-			respDump []byte
-		)
+// operationKey is the request context key of the API operation name
+type operationKey struct{}
 
-		if r != nil {
-			reqDump = fmt.Sprintf("%s %s. Data: %s", r.Method, r.URL.String(), r.Form.Encode())
-		}
-		if resp != nil {
-			respDump, _ = httputil.DumpResponse(resp, true)
-		}
+// newRequest constructs a request for the named API operation, with the client context
+func (c *ActClient) newRequest(op, method, url string, payload interface{}) (*http.Request, error) {
+	return c.newRequestContext(c.context(), op, method, url, payload)
+}
 
-		c.Log.Write([]byte(fmt.Sprintf("Request: %s\nResponse: %s\n", reqDump, string(respDump))))
-	}
+// newRequestContext constructs a request for the named API operation which is cancelled with ctx
+func (c *ActClient) newRequestContext(ctx context.Context, op, method, url string, payload interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.WithValue(ctx, operationKey{}, op), method, url, payload)
+}
+
+// Operation returns the name of the API operation which made the request, for example
+// "GetTask" or "StartProcessInstance". It is empty for requests built with NewRequest
+func Operation(req *http.Request) string {
+	op, _ := req.Context().Value(operationKey{}).(string)
+	return op
 }
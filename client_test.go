@@ -0,0 +1,61 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func TestWithContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entry":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		value interface{}
+		err   error
+	}{
+		{"background", nil, nil, nil},
+		{"value", context.WithValue(context.Background(), ctxKey{}, "v"), "v", nil},
+		{"cancelled", cancelled, nil, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewClient("token", srv.URL)
+			var got interface{}
+			var op string
+			c.Use(BeforeRequest(func(req *http.Request) error {
+				got, op = req.Context().Value(ctxKey{}), Operation(req)
+				return nil
+			}))
+
+			cc := c
+			if tt.ctx != nil {
+				cc = c.WithContext(tt.ctx)
+			}
+			_, err := cc.GetTask("t1")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetTask error = %v, want %v", err, tt.err)
+			}
+			if got != tt.value || op != "GetTask" {
+				t.Errorf("middleware saw %v/%q, want %v/GetTask", got, op, tt.value)
+			}
+
+			// The original client keeps its context
+			if _, err = c.GetTask("t1"); err != nil {
+				t.Errorf("GetTask on the original client: %v", err)
+			}
+		})
+	}
+}
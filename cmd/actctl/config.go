@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Config is the actctl configuration file
+	Config struct {
+		Current  string              `yaml:"current,omitempty"`
+		Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+
+		path string
+	}
+
+	// Profile holds the endpoints and credentials of one Activiti Cloud environment
+	Profile struct {
+		URL          string    `yaml:"url"`                    // Runtime bundle url, for example http://gateway/rb/v1
+		QueryURL     string    `yaml:"queryUrl,omitempty"`     // Query service url, derived from url when empty
+		KeycloakURL  string    `yaml:"keycloakUrl,omitempty"`  // For example http://gateway/auth
+		Realm        string    `yaml:"realm,omitempty"`        // Keycloak realm
+		ClientID     string    `yaml:"clientId,omitempty"`     // Keycloak public client
+		Username     string    `yaml:"username,omitempty"`     // Last user logged in
+		Token        string    `yaml:"token,omitempty"`        // Access token
+		RefreshToken string    `yaml:"refreshToken,omitempty"` // Refresh token
+		Expiry       time.Time `yaml:"expiry,omitempty"`       // Access token expiry
+	}
+)
+
+// defaultConfigPath returns $ACTCTL_CONFIG or ~/.config/actctl/config.yaml
+func defaultConfigPath() string {
+	if p := os.Getenv("ACTCTL_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "actctl.yaml"
+	}
+	return filepath.Join(dir, "actctl", "config.yaml")
+}
+
+// loadConfig reads the configuration file, a missing file is an empty configuration
+func loadConfig(path string) (*Config, error) {
+	cfg := &Config{Profiles: map[string]*Profile{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*Profile{}
+	}
+	return cfg, nil
+}
+
+// save writes the configuration file, it holds tokens so it is only readable by the user
+func (c *Config) save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0600)
+}
+
+// profile returns the named profile, or the current one when name is empty
+func (c *Config) profile(name string) (string, *Profile, error) {
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		return "", nil, errors.New("no profile selected, create one with 'actctl config set-profile'")
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return "", nil, fmt.Errorf("profile %q does not exist", name)
+	}
+	return name, p, nil
+}
+
+// profileNames returns the sorted profile names
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runConfig implements 'actctl config'
+func runConfig(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: actctl config list|use|set-profile|delete-profile")
+	}
+
+	switch args[0] {
+	case "list":
+		type row struct {
+			Name    string `json:"name" yaml:"name"`
+			Current bool   `json:"current" yaml:"current"`
+			URL     string `json:"url" yaml:"url"`
+			User    string `json:"username,omitempty" yaml:"username,omitempty"`
+		}
+		var rows []row
+		for _, name := range app.cfg.profileNames() {
+			p := app.cfg.Profiles[name]
+			rows = append(rows, row{Name: name, Current: name == app.cfg.Current, URL: p.URL, User: p.Username})
+		}
+		return app.print(rows, []string{"NAME", "CURRENT", "URL", "USERNAME"}, func(add func(...interface{})) {
+			for _, r := range rows {
+				current := ""
+				if r.Current {
+					current = "*"
+				}
+				add(r.Name, current, r.URL, r.User)
+			}
+		})
+
+	case "use":
+		if len(args) != 2 {
+			return errors.New("usage: actctl config use <profile>")
+		}
+		if _, ok := app.cfg.Profiles[args[1]]; !ok {
+			return fmt.Errorf("profile %q does not exist", args[1])
+		}
+		app.cfg.Current = args[1]
+		return app.cfg.save()
+
+	case "set-profile":
+		fs := newFlagSet("config set-profile <profile>")
+		url := fs.String("url", "", "runtime bundle url, for example http://gateway/rb/v1")
+		queryURL := fs.String("query-url", "", "query service url, derived from --url when empty")
+		keycloakURL := fs.String("keycloak-url", "", "keycloak url, for example http://gateway/auth")
+		realm := fs.String("realm", "", "keycloak realm")
+		clientID := fs.String("client-id", "", "keycloak client id")
+		names, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(names) != 1 {
+			return errors.New("usage: actctl config set-profile <profile> [flags]")
+		}
+
+		name := names[0]
+		p, ok := app.cfg.Profiles[name]
+		if !ok {
+			p = &Profile{}
+			app.cfg.Profiles[name] = p
+		}
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "url":
+				p.URL = *url
+			case "query-url":
+				p.QueryURL = *queryURL
+			case "keycloak-url":
+				p.KeycloakURL = *keycloakURL
+			case "realm":
+				p.Realm = *realm
+			case "client-id":
+				p.ClientID = *clientID
+			}
+		})
+		if app.cfg.Current == "" {
+			app.cfg.Current = name
+		}
+		return app.cfg.save()
+
+	case "delete-profile":
+		if len(args) != 2 {
+			return errors.New("usage: actctl config delete-profile <profile>")
+		}
+		delete(app.cfg.Profiles, args[1])
+		if app.cfg.Current == args[1] {
+			app.cfg.Current = ""
+		}
+		return app.cfg.save()
+	}
+
+	return fmt.Errorf("unknown config command %q", args[0])
+}
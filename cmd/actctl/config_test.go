@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string // Written when not empty
+		current  string
+		profiles []string
+		wantErr  bool
+	}{
+		{"missing file", "", "", []string{}, false},
+		{"no profiles", "current: dev\n", "dev", []string{}, false},
+		{"profiles", "current: dev\nprofiles:\n  dev:\n    url: http://dev\n  prod:\n    url: http://prod\n", "dev", []string{"dev", "prod"}, false},
+		{"invalid yaml", "profiles: [", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.data != "" {
+				if err := os.WriteFile(path, []byte(tt.data), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg, err := loadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Current != tt.current || !reflect.DeepEqual(cfg.profileNames(), tt.profiles) {
+				t.Errorf("current %q profiles %q, want %q %q", cfg.Current, cfg.profileNames(), tt.current, tt.profiles)
+			}
+		})
+	}
+}
+
+func TestConfigSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actctl", "config.yaml")
+	cfg, _ := loadConfig(path)
+	cfg.Current = "dev"
+	cfg.Profiles["dev"] = &Profile{URL: "http://dev", Token: "secret"}
+	if err := cfg.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", fi.Mode().Perm())
+	}
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Current != "dev" || got.Profiles["dev"].URL != "http://dev" || got.Profiles["dev"].Token != "secret" {
+		t.Errorf("got %+v, profile %+v", got, got.Profiles["dev"])
+	}
+}
+
+func TestConfigProfile(t *testing.T) {
+	cfg := &Config{Current: "dev", Profiles: map[string]*Profile{"dev": {URL: "http://dev"}, "prod": {URL: "http://prod"}}}
+	tests := []struct {
+		name     string
+		current  string
+		arg      string
+		wantName string
+		wantErr  bool
+	}{
+		{"current", "dev", "", "dev", false},
+		{"named", "dev", "prod", "prod", false},
+		{"unknown", "dev", "test", "", true},
+		{"none selected", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Current = tt.current
+			name, p, err := cfg.profile(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (name != tt.wantName || p != cfg.Profiles[tt.wantName]) {
+				t.Errorf("got %q %+v, want %q", name, p, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	steps := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{[]string{"set-profile", "dev", "--url", "http://dev", "--realm", "r"}, false},
+		{[]string{"set-profile", "--url", "http://prod", "prod"}, false},
+		// Only the given flags change
+		{[]string{"set-profile", "dev", "--client-id", "app"}, false},
+		{[]string{"set-profile"}, true},
+		{[]string{"use", "missing"}, true},
+		{[]string{"use", "prod"}, false},
+		{[]string{"delete-profile", "prod"}, false},
+		{[]string{"nope"}, true},
+	}
+	for _, s := range steps {
+		err := run(append([]string{"-config", path, "config"}, s.args...), &bytes.Buffer{})
+		if (err != nil) != s.wantErr {
+			t.Fatalf("%q: err = %v, wantErr %v", s.args, err, s.wantErr)
+		}
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Current != "" {
+		t.Errorf("current = %q, want none after deleting it", cfg.Current)
+	}
+	want := map[string]*Profile{"dev": {URL: "http://dev", Realm: "r", ClientID: "app"}}
+	if !reflect.DeepEqual(cfg.Profiles, want) {
+		t.Errorf("profiles = %+v, want %+v", cfg.Profiles["dev"], want["dev"])
+	}
+
+	out := &bytes.Buffer{}
+	if err := run([]string{"-config", path, "-o", "json", "config", "list"}, out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"url": "http://dev"`) {
+		t.Errorf("list = %s", out)
+	}
+}
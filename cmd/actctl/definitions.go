@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// runDefinitions implements 'actctl definitions'
+func runDefinitions(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: actctl definitions list|get|meta")
+	}
+	c, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		pds, err := c.GetProcessDefinitions()
+		if err != nil {
+			return err
+		}
+		return app.print(pds.List.ProcessDefinitions, []string{"ID", "KEY", "NAME", "VERSION", "APP"}, func(add func(...interface{})) {
+			for _, pd := range pds.List.ProcessDefinitions {
+				d := pd.ProcessDefinition
+				add(d.ID, d.Key, d.Name, d.Version, d.AppName)
+			}
+		})
+
+	case "get":
+		id, err := argID(args, "actctl definitions get <processDefinitionId>")
+		if err != nil {
+			return err
+		}
+		pd, err := c.GetProcessDefinition(id)
+		if err != nil {
+			return err
+		}
+		return app.print(pd.ProcessDefinition, []string{"ID", "KEY", "NAME", "VERSION", "APP"}, func(add func(...interface{})) {
+			d := pd.ProcessDefinition
+			add(d.ID, d.Key, d.Name, d.Version, d.AppName)
+		})
+
+	case "meta":
+		id, err := argID(args, "actctl definitions meta <processDefinitionId>")
+		if err != nil {
+			return err
+		}
+		meta, err := c.GetProcessDefinitionMeta(id)
+		if err != nil {
+			return err
+		}
+		return printMeta(app, meta)
+	}
+
+	return fmt.Errorf("unknown definitions command %q", args[0])
+}
+
+// printMeta prints the metadata of a process definition
+func printMeta(app *app, meta *activiti.ActProcessDefinitionMeta) error {
+	return app.print(meta.Entry, []string{"ID", "NAME", "VERSION", "GROUPS"}, func(add func(...interface{})) {
+		m := meta.Entry
+		add(m.ID, m.Name, m.Version, strings.Join(m.Groups, ","))
+	})
+}
+
+// argID returns the single positional argument of a subcommand
+func argID(args []string, usage string) (string, error) {
+	if len(args) != 2 || args[1] == "" {
+		return "", errors.New("usage: " + usage)
+	}
+	return args[1], nil
+}
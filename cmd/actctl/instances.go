@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// instanceHeaders are the table columns of process instances
+var instanceHeaders = []string{"ID", "DEFINITION", "VERSION", "STATUS", "INITIATOR", "STARTED"}
+
+// instanceRow returns the table cells of a process instance
+func instanceRow(pi activiti.ProcessInstance) []interface{} {
+	return []interface{}{pi.ID, pi.ProcessDefinitionKey, pi.ProcessDefinitionVersion, pi.Status, pi.Initiator, pi.StartDate}
+}
+
+// runInstances implements 'actctl instances'
+func runInstances(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: actctl instances list|get|start|cancel|suspend|resume|set-vars|diagram")
+	}
+	c, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := newFlagSet("instances list")
+		status := fs.String("status", "", "filter by status, for example RUNNING, queries the query service")
+		key := fs.String("key", "", "filter by process definition key, queries the query service")
+		max := fs.Int("max", 100, "maximum number of instances with --status or --key")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var pis *activiti.ActListProcessInstances
+		if *status != "" || *key != "" {
+			params := url.Values{"maxItems": {strconv.Itoa(*max)}}
+			if *status != "" {
+				params.Set("status", *status)
+			}
+			if *key != "" {
+				params.Set("processDefinitionKey", *key)
+			}
+			pis, err = c.QueryProcessInstances(params)
+		} else {
+			pis, err = c.GetProcessInstances()
+		}
+		if err != nil {
+			return err
+		}
+		return app.print(pis.List.ProcessInstances, instanceHeaders, func(add func(...interface{})) {
+			for _, pi := range pis.List.ProcessInstances {
+				add(instanceRow(pi.ProcessInstance)...)
+			}
+		})
+
+	case "get":
+		id, err := argID(args, "actctl instances get <processInstanceId>")
+		if err != nil {
+			return err
+		}
+		pi, err := c.GetProcessInstance(id)
+		if err != nil {
+			return err
+		}
+		return printInstance(app, pi)
+
+	case "start":
+		fs := newFlagSet("instances start")
+		key := fs.String("key", "", "process definition key")
+		id := fs.String("id", "", "process definition id, instead of --key")
+		businessKey := fs.String("business-key", "", "business key")
+		vars := varsFlag{}
+		fs.Var(vars, "var", "variable name=value, repeatable")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var pi *activiti.ActProcessInstance
+		switch {
+		case *id != "":
+			if *businessKey != "" || len(vars) > 0 {
+				return errors.New("--business-key and --var require --key")
+			}
+			pi, err = c.StartProcessInstanceById(*id)
+		case *key != "":
+			pi, err = c.StartProcessInstanceWithBusinessKeyAndVariables(*key, *businessKey, vars)
+		default:
+			return errors.New("--key or --id is required")
+		}
+		if err != nil {
+			return err
+		}
+		return printInstance(app, pi)
+
+	case "cancel":
+		id, err := argID(args, "actctl instances cancel <processInstanceId>")
+		if err != nil {
+			return err
+		}
+		return c.Cancel(id)
+
+	case "suspend":
+		id, err := argID(args, "actctl instances suspend <processInstanceId>")
+		if err != nil {
+			return err
+		}
+		pi, err := c.SuspendProcessInstance(id)
+		if err != nil {
+			return err
+		}
+		return printInstance(app, pi)
+
+	case "resume":
+		id, err := argID(args, "actctl instances resume <processInstanceId>")
+		if err != nil {
+			return err
+		}
+		pi, err := c.ResumeProcessInstance(id)
+		if err != nil {
+			return err
+		}
+		return printInstance(app, pi)
+
+	case "set-vars":
+		fs := newFlagSet("instances set-vars <processInstanceId>")
+		vars := varsFlag{}
+		fs.Var(vars, "var", "variable name=value, repeatable")
+		admin := fs.Bool("admin", false, "use the admin endpoint")
+		ids, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 || len(vars) == 0 {
+			return errors.New("usage: actctl instances set-vars <processInstanceId> --var name=value")
+		}
+		if *admin {
+			return c.AdminSetProcessVariables(ids[0], vars)
+		}
+		return c.SetProcessVariables(ids[0], vars)
+
+	case "diagram":
+		fs := newFlagSet("instances diagram <processInstanceId>")
+		file := fs.String("f", "", "output file, stdout when empty")
+		ids, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return errors.New("usage: actctl instances diagram <processInstanceId> [-f file.svg]")
+		}
+		svg, err := c.GetProcessDiagram(ids[0])
+		if err != nil {
+			return err
+		}
+		if *file == "" {
+			_, err = app.out.Write(svg)
+			return err
+		}
+		return os.WriteFile(*file, svg, 0644)
+	}
+
+	return fmt.Errorf("unknown instances command %q", args[0])
+}
+
+// printInstance prints a single process instance
+func printInstance(app *app, pi *activiti.ActProcessInstance) error {
+	return app.print(pi.ProcessInstance, instanceHeaders, func(add func(...interface{})) {
+		add(instanceRow(pi.ProcessInstance)...)
+	})
+}
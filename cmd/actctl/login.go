@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// tokenResponse is the OpenID Connect token endpoint response of Keycloak
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// runLogin implements 'actctl login', it stores the Keycloak tokens in the profile
+func runLogin(app *app, args []string) error {
+	fs := newFlagSet("login")
+	username := fs.String("username", "", "keycloak user, defaults to the last user of the profile")
+	password := fs.String("password", "", "password, read from $ACTCTL_PASSWORD or stdin when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	name, p, err := app.cfg.profile(app.profile)
+	if err != nil {
+		return err
+	}
+	if p.KeycloakURL == "" || p.Realm == "" || p.ClientID == "" {
+		return fmt.Errorf("profile %q needs --keycloak-url, --realm and --client-id to log in", name)
+	}
+	if *username == "" {
+		*username = p.Username
+	}
+	if *username == "" {
+		return errors.New("--username is required")
+	}
+	if *password == "" {
+		*password = os.Getenv("ACTCTL_PASSWORD")
+	}
+	if *password == "" {
+		if *password, err = readPassword(fmt.Sprintf("Password for %s: ", *username)); err != nil {
+			return err
+		}
+	}
+
+	if err = requestToken(p, url.Values{
+		"grant_type": {"password"},
+		"client_id":  {p.ClientID},
+		"username":   {*username},
+		"password":   {*password},
+	}); err != nil {
+		return err
+	}
+	p.Username = *username
+	if err = app.cfg.save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Logged in to %s as %s\n", name, *username)
+	return nil
+}
+
+// readPassword prompts on stderr and reads a password from stdin, without echo on a terminal
+func readPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		pw, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(pw), err
+	}
+	// Piped input, as in 'echo $PW | actctl login'
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// refreshToken renews an expired access token with the refresh token of the profile
+func refreshToken(p *Profile) error {
+	if p.RefreshToken == "" {
+		return errors.New("token expired, run 'actctl login'")
+	}
+	err := requestToken(p, url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {p.ClientID},
+		"refresh_token": {p.RefreshToken},
+	})
+	if err != nil {
+		return fmt.Errorf("token expired and refresh failed, run 'actctl login': %v", err)
+	}
+	return nil
+}
+
+// requestToken calls the Keycloak token endpoint and stores the tokens in p
+func requestToken(p *Profile, form url.Values) error {
+	endpoint := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", strings.TrimRight(p.KeycloakURL, "/"), url.PathEscape(p.Realm))
+	resp, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var tr tokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK || tr.AccessToken == "" {
+		if tr.ErrorDescription != "" {
+			return errors.New(tr.ErrorDescription)
+		}
+		return fmt.Errorf("%s: %s %s", endpoint, resp.Status, tr.Error)
+	}
+
+	p.Token = tr.AccessToken
+	p.RefreshToken = tr.RefreshToken
+	p.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantVars       map[string]interface{}
+		wantReason     string
+		wantErr        bool
+	}{
+		{"none", nil, nil, map[string]interface{}{}, "", false},
+		{"flags first", []string{"--var", "a=1", "t1"}, []string{"t1"}, map[string]interface{}{"a": 1.0}, "", false},
+		{"flags after", []string{"t1", "--var", "a=1", "-reason", "late"}, []string{"t1"}, map[string]interface{}{"a": 1.0}, "late", false},
+		{"interleaved", []string{"t1", "--var", "a=true", "t2", "--var", "b=x"}, []string{"t1", "t2"}, map[string]interface{}{"a": true, "b": "x"}, "", false},
+		{"terminator", []string{"t1", "--", "--var"}, []string{"t1", "--var"}, map[string]interface{}{}, "", false},
+		{"unknown flag", []string{"t1", "--nope"}, nil, nil, "", true},
+		{"invalid var", []string{"--var", "novalue"}, nil, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("test")
+			fs.SetOutput(&bytes.Buffer{})
+			vars := varsFlag{}
+			fs.Var(vars, "var", "variable")
+			reason := fs.String("reason", "", "reason")
+
+			got, err := parseInterspersed(fs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantPositional) {
+				t.Errorf("positional = %q, want %q", got, tt.wantPositional)
+			}
+			if !reflect.DeepEqual(map[string]interface{}(vars), tt.wantVars) {
+				t.Errorf("vars = %v, want %v", vars, tt.wantVars)
+			}
+			if *reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", *reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestVarsFlag(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    interface{}
+		wantErr bool
+	}{
+		{"n=3", 3.0, false},
+		{"n=-1.5", -1.5, false},
+		{"b=false", false, false},
+		{"s=hello", "hello", false},
+		{`s="quoted"`, "quoted", false},
+		{"s=", "", false},
+		{"s=a=b", "a=b", false},
+		{`o={"a":1}`, map[string]interface{}{"a": 1.0}, false},
+		{"l=[1,2]", []interface{}{1.0, 2.0}, false},
+		{"n=null", nil, false},
+		{"novalue", nil, true},
+		{"=1", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := varsFlag{}
+			err := v.Set(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			name, _, _ := strings.Cut(tt.in, "=")
+			if got, ok := v[name]; !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %#v, want %#v", name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{"help", []string{"help"}, "Usage: actctl", ""},
+		{"no command", nil, "Usage: actctl", ""},
+		{"unknown output", []string{"-o", "xml", "tasks", "list"}, "", `unknown output format "xml"`},
+		{"unknown command", []string{"nope"}, "", `unknown command "nope"`},
+		{"no profile", []string{"tasks", "list"}, "", "no profile selected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ACTCTL_PROFILE", "")
+			out := &bytes.Buffer{}
+			args := append([]string{"-config", t.TempDir() + "/config.yaml"}, tt.args...)
+			err := run(args, out)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output %q does not contain %q", out, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// taskHeaders are the table columns of tasks
+var taskHeaders = []string{"ID", "NAME", "KEY", "ASSIGNEE", "STATUS", "PROCESS INSTANCE", "CREATED"}
+
+// taskRow returns the table cells of a task
+func taskRow(t activiti.Task) []interface{} {
+	return []interface{}{t.ID, t.Name, t.TaskDefinitionKey, t.Assignee, t.Status, t.ProcessInstanceId, t.CreatedDate}
+}
+
+// runTasks implements 'actctl tasks'
+func runTasks(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: actctl tasks list|get|claim|assign|complete")
+	}
+	c, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := newFlagSet("tasks list")
+		status := fs.String("status", "", "filter by status, for example ASSIGNED, queries the query service")
+		assignee := fs.String("assignee", "", "filter by assignee, queries the query service")
+		max := fs.Int("max", 100, "maximum number of tasks with --status or --assignee")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var tks *activiti.ActListTasks
+		if *status != "" || *assignee != "" {
+			params := url.Values{"maxItems": {strconv.Itoa(*max)}}
+			if *status != "" {
+				params.Set("status", *status)
+			}
+			if *assignee != "" {
+				params.Set("assignee", *assignee)
+			}
+			tks, err = c.QueryTasks(params)
+		} else {
+			tks, err = c.GetTasks()
+		}
+		if err != nil {
+			return err
+		}
+		return app.print(tks.List.Tasks, taskHeaders, func(add func(...interface{})) {
+			for _, t := range tks.List.Tasks {
+				add(taskRow(t.Task)...)
+			}
+		})
+
+	case "get":
+		id, err := argID(args, "actctl tasks get <taskId>")
+		if err != nil {
+			return err
+		}
+		tk, err := c.GetTask(id)
+		if err != nil {
+			return err
+		}
+		return app.print(tk.Task, taskHeaders, func(add func(...interface{})) {
+			add(taskRow(tk.Task)...)
+		})
+
+	case "claim", "assign":
+		fs := newFlagSet("tasks " + args[0] + " <taskId>")
+		assignee := fs.String("assignee", "", "user to claim or assign the task for")
+		ids, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return fmt.Errorf("usage: actctl tasks %s <taskId> --assignee user", args[0])
+		}
+		if args[0] == "claim" {
+			if *assignee == "" {
+				*assignee = app.username()
+			}
+			return c.TaskActionClaim(ids[0], *assignee)
+		}
+		if *assignee == "" {
+			return errors.New("--assignee is required")
+		}
+		return c.TaskActionAssign(ids[0], *assignee)
+
+	case "complete":
+		fs := newFlagSet("tasks complete <taskId>")
+		vars := varsFlag{}
+		fs.Var(vars, "var", "variable name=value, repeatable")
+		ids, err := parseInterspersed(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return errors.New("usage: actctl tasks complete <taskId> [--var name=value]")
+		}
+		return c.CompleteTask(ids[0], vars)
+	}
+
+	return fmt.Errorf("unknown tasks command %q", args[0])
+}
+
+// username returns the user logged in to the selected profile
+func (a *app) username() string {
+	if _, p, err := a.cfg.profile(a.profile); err == nil {
+		return p.Username
+	}
+	return ""
+}
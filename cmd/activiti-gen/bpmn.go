@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+type (
+	// definitions is the part of a BPMN document used by the generator.
+	// Elements are matched by local name, whatever the namespace prefix
+	definitions struct {
+		Processes []bpmnProcess `xml:"process"`
+	}
+
+	bpmnProcess struct {
+		ID          string           `xml:"id,attr"`
+		Name        string           `xml:"name,attr"`
+		StartEvents []bpmnStartEvent `xml:"startEvent"`
+		flowElements
+	}
+
+	// bpmnStartEvent holds the form properties of the variables starting the process
+	bpmnStartEvent struct {
+		FormProperties []bpmnFormProperty `xml:"extensionElements>formProperty"`
+	}
+
+	// flowElements are the user tasks of a process or sub process
+	flowElements struct {
+		UserTasks    []bpmnUserTask `xml:"userTask"`
+		SubProcesses []flowElements `xml:"subProcess"`
+	}
+
+	bpmnUserTask struct {
+		ID             string             `xml:"id,attr"`
+		Name           string             `xml:"name,attr"`
+		FormKey        string             `xml:"formKey,attr"`
+		FormProperties []bpmnFormProperty `xml:"extensionElements>formProperty"`
+	}
+
+	// bpmnFormProperty is an activiti:formProperty of a start event or user task
+	bpmnFormProperty struct {
+		ID       string `xml:"id,attr"`
+		Name     string `xml:"name,attr"`
+		Type     string `xml:"type,attr"`
+		Required bool   `xml:"required,attr"`
+		Writable string `xml:"writable,attr"`
+	}
+
+	// process is the generator input of one BPMN process
+	process struct {
+		Key       string
+		Name      string
+		Variables []variable
+		Tasks     []userTask
+	}
+
+	userTask struct {
+		Key     string
+		Name    string
+		Outputs []variable
+	}
+
+	variable struct {
+		Name     string
+		Type     string // Activiti variable or form property type
+		Required bool
+	}
+)
+
+// parseBPMN returns the processes of a BPMN document
+func parseBPMN(data []byte) ([]*process, error) {
+	var defs definitions
+	if err := xml.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("invalid BPMN: %v", err)
+	}
+	if len(defs.Processes) == 0 {
+		return nil, fmt.Errorf("no process in BPMN")
+	}
+
+	var ps []*process
+	for _, bp := range defs.Processes {
+		p := &process{Key: bp.ID, Name: bp.Name}
+		for _, se := range bp.StartEvents {
+			p.Variables = append(p.Variables, formVariables(se.FormProperties)...)
+		}
+		sortVariables(p.Variables)
+		for _, t := range bp.userTasks() {
+			p.Tasks = append(p.Tasks, userTask{Key: t.ID, Name: t.Name, Outputs: formVariables(t.FormProperties)})
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// formVariables returns the variables of the writable form properties
+func formVariables(fps []bpmnFormProperty) []variable {
+	var vs []variable
+	for _, fp := range fps {
+		if fp.Writable == "false" {
+			continue
+		}
+		vs = append(vs, variable{Name: fp.ID, Type: fp.Type, Required: fp.Required})
+	}
+	return vs
+}
+
+// userTasks returns the user tasks of the process and its sub processes
+func (f flowElements) userTasks() []bpmnUserTask {
+	tasks := append([]bpmnUserTask(nil), f.UserTasks...)
+	for _, sp := range f.SubProcesses {
+		tasks = append(tasks, sp.userTasks()...)
+	}
+	return tasks
+}
+
+// applyExtensions declares the process variables of the modeler and the task outputs of its mappings.
+// Start event form properties take precedence over the modeler variables of the same name
+func (p *process) applyExtensions(ext *activiti.ProcessExtensions) {
+	declared := map[string]bool{}
+	for _, v := range p.Variables {
+		declared[v.Name] = true
+	}
+	types := map[string]variable{}
+	for _, prop := range ext.Properties {
+		v := variable{Name: prop.Name, Type: prop.Type, Required: prop.Required}
+		types[v.Name] = v
+		if !declared[v.Name] {
+			p.Variables = append(p.Variables, v)
+		}
+	}
+	sortVariables(p.Variables)
+
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		m, ok := ext.Mappings[t.Key]
+		if !ok || len(t.Outputs) > 0 {
+			continue
+		}
+		// Outputs map process variables to the task variables sent on completion
+		for processVar, mv := range m.Outputs {
+			name, ok := mv.Value.(string)
+			if mv.Type != "variable" || !ok {
+				continue
+			}
+			t.Outputs = append(t.Outputs, variable{Name: name, Type: types[processVar].Type})
+		}
+		sortVariables(t.Outputs)
+	}
+}
+
+// applyMeta declares the process variables returned by the runtime bundle
+func (p *process) applyMeta(meta *activiti.ProcessDefinitionMeta) {
+	if len(p.Variables) > 0 {
+		return
+	}
+	for _, v := range meta.Variables {
+		p.Variables = append(p.Variables, variable{Name: v.Name, Type: v.Type})
+	}
+	sortVariables(p.Variables)
+}
+
+func sortVariables(vs []variable) {
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+}
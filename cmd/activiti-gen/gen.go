@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+type (
+	// file is the template data of a generated file
+	file struct {
+		Package   string
+		Command   string
+		Processes []genProcess
+	}
+
+	genProcess struct {
+		Key       string
+		Title     string // Name, or key without one
+		Ident     string // Go name of the process
+		Variables genStruct
+		Tasks     []genTask
+	}
+
+	genTask struct {
+		Key     string
+		Title   string
+		Ident   string // Go name of the task, prefixed by the process when not unique
+		Outputs genStruct
+	}
+
+	genStruct struct {
+		Name   string
+		Doc    string // Completes "<Name> are the variables "
+		Fields []genField
+	}
+
+	// genField is a variable. Optional scalar variables are pointers, nil when unset
+	genField struct {
+		Ident    string
+		Name     string // Variable name
+		GoType   string
+		Required bool
+		Value    string // Expression of the value sent to Activiti
+	}
+)
+
+// generate returns the formatted Go source for processes
+func generate(pkg, command string, ps []*process) ([]byte, error) {
+	f := file{Package: pkg, Command: command}
+
+	taskIdents := map[string]int{}
+	for _, p := range ps {
+		for _, t := range p.Tasks {
+			taskIdents[identifier(title(t.Name, t.Key))]++
+		}
+	}
+
+	for _, p := range ps {
+		gp := genProcess{Key: p.Key, Title: title(p.Name, p.Key), Ident: identifier(title(p.Name, p.Key))}
+		gp.Variables = newStruct(gp.Ident+"Variables", "starting "+article(gp.Title)+" process instance", p.Variables)
+		for _, t := range p.Tasks {
+			gt := genTask{Key: t.Key, Title: title(t.Name, t.Key), Ident: identifier(title(t.Name, t.Key))}
+			if taskIdents[gt.Ident] > 1 {
+				gt.Ident = gp.Ident + gt.Ident
+			}
+			gt.Outputs = newStruct(gt.Ident+"Outputs", "completing "+article(gt.Title)+" task", t.Outputs)
+			gp.Tasks = append(gp.Tasks, gt)
+		}
+		f.Processes = append(f.Processes, gp)
+	}
+	if err := checkIdents(f); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := fileTemplate.Execute(buf, f); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go: %v", err)
+	}
+	return src, nil
+}
+
+// checkIdents rejects processes or tasks generating the same Go names
+func checkIdents(f file) error {
+	seen := map[string]string{}
+	add := func(ident, what string) error {
+		if prev, ok := seen[ident]; ok {
+			return fmt.Errorf("%s and %s both generate %s, rename one of them", prev, what, ident)
+		}
+		seen[ident] = what
+		return nil
+	}
+	for _, p := range f.Processes {
+		if err := add(p.Ident, "process "+p.Key); err != nil {
+			return err
+		}
+		for _, t := range p.Tasks {
+			if err := add(t.Ident+"Task", "task "+t.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newStruct returns the struct of the variables vs. Variables whose field would clash with
+// another field or the Map method get numbered fields, skipping the fields of later variables
+func newStruct(name, doc string, vs []variable) genStruct {
+	s := genStruct{Name: name, Doc: doc}
+	idents := map[string]bool{}
+	for _, v := range vs {
+		idents[identifier(v.Name)] = true
+	}
+	used := map[string]bool{"Map": true}
+	for _, v := range vs {
+		base := identifier(v.Name)
+		ident := base
+		for n := 2; used[ident]; n++ {
+			if numbered := base + strconv.Itoa(n); !idents[numbered] {
+				ident = numbered
+			}
+		}
+		used[ident] = true
+		f := genField{Ident: ident, Name: v.Name, GoType: goType(v.Type), Required: v.Required, Value: "v." + ident}
+		if !v.Required && scalar(f.GoType) {
+			f.GoType = "*" + f.GoType
+			f.Value = "*v." + ident
+		}
+		s.Fields = append(s.Fields, f)
+	}
+	return s
+}
+
+// goType returns the Go type of an Activiti variable or form property type
+func goType(t string) string {
+	switch strings.ToLower(t) {
+	case "string", "date", "datetime", "enum", "file", "folder":
+		return "string"
+	case "integer", "long", "int":
+		return "int64"
+	case "double", "float", "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]interface{}"
+	}
+	// json and undeclared types
+	return "interface{}"
+}
+
+// scalar reports whether the zero value of a Go type is a valid variable value,
+// so that an optional variable needs a pointer to tell it from an unset one
+func scalar(goType string) bool {
+	return goType != "interface{}" && !strings.HasPrefix(goType, "[]")
+}
+
+// title returns the name of an element on one line, its id when unnamed
+func title(name, id string) string {
+	if t := strings.Join(strings.Fields(name), " "); t != "" {
+		return t
+	}
+	return id
+}
+
+// article prefixes a name with a or an
+func article(name string) string {
+	if name != "" && strings.ContainsRune("AEIOUaeiou", []rune(name)[0]) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// identifier returns an exported Go name, for example "approve leave-request" becomes ApproveLeaveRequest
+func identifier(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	id := sb.String()
+	if id == "" || !unicode.IsLetter([]rune(id)[0]) {
+		id = "X" + id
+	}
+	return id
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"quote": strconv.Quote, "a": article}).Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+{{range $p := .Processes}}
+// {{$p.Ident}}Key is the process definition key of {{$p.Title}}
+const {{$p.Ident}}Key = {{quote $p.Key}}
+{{if $p.Tasks}}
+// Task definition keys of {{$p.Title}}
+const (
+{{- range $p.Tasks}}
+	{{.Ident}}Task = {{quote .Key}} // {{.Title}}
+{{- end}}
+)
+{{end}}
+{{template "struct" $p.Variables}}
+
+// Start{{$p.Ident}} starts {{a $p.Title}} process instance
+func Start{{$p.Ident}}(ctx context.Context, c activiti.ProcessInstanceService, businessKey string, v {{$p.Variables.Name}}) (*activiti.StartResult, error) {
+	return c.StartProcessInstance(ctx, activiti.StartOptions{
+		ProcessDefinitionKey: {{$p.Ident}}Key,
+		BusinessKey:          businessKey,
+		Variables:            v.Map(),
+	})
+}
+{{range $t := $p.Tasks}}{{if $t.Outputs.Fields}}
+{{template "struct" $t.Outputs}}
+
+// Complete{{$t.Ident}} completes {{a $t.Title}} task of {{$p.Title}}
+func Complete{{$t.Ident}}(c activiti.TaskService, taskID string, v {{$t.Outputs.Name}}) error {
+	return c.CompleteTask(taskID, v.Map())
+}
+{{else}}
+// Complete{{$t.Ident}} completes {{a $t.Title}} task of {{$p.Title}}
+func Complete{{$t.Ident}}(c activiti.TaskService, taskID string) error {
+	return c.CompleteTask(taskID, nil)
+}
+{{end}}{{end}}{{end}}
+{{- define "struct"}}
+// {{.Name}} are the variables {{.Doc}}. Nil optional variables are not sent
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Ident}} {{.GoType}} ` + "`" + `json:"{{.Name}}{{if not .Required}},omitempty{{end}}"` + "`" + `
+{{- end}}
+}
+
+// Map returns the variables sent to Activiti
+func (v {{.Name}}) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+{{- range .Fields}}
+{{- if .Required}}
+	m[{{quote .Name}}] = {{.Value}}
+{{- else}}
+	if v.{{.Ident}} != nil {
+		m[{{quote .Name}}] = {{.Value}}
+	}
+{{- end}}
+{{- end}}
+	return m
+}
+{{- end}}
+`))
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const leaveBPMN = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn2:definitions xmlns:bpmn2="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:activiti="http://activiti.org/bpmn">
+  <bpmn2:process id="leave" name="Leave request">
+    <bpmn2:startEvent id="start">
+      <bpmn2:extensionElements>
+        <activiti:formProperty id="days" type="long" required="true"/>
+        <activiti:formProperty id="reason" type="string"/>
+        <activiti:formProperty id="computed" type="string" writable="false"/>
+      </bpmn2:extensionElements>
+    </bpmn2:startEvent>
+    <bpmn2:userTask id="approve" name="Approve">
+      <bpmn2:extensionElements>
+        <activiti:formProperty id="approved" type="boolean"/>
+        <activiti:formProperty id="comment" type="string" required="true"/>
+      </bpmn2:extensionElements>
+    </bpmn2:userTask>
+    <bpmn2:subProcess id="sub">
+      <bpmn2:userTask id="notify" name="Notify HR"/>
+    </bpmn2:subProcess>
+  </bpmn2:process>
+</bpmn2:definitions>`
+
+func TestParseBPMN(t *testing.T) {
+	ps, err := parseBPMN([]byte(leaveBPMN))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*process{{
+		Key:  "leave",
+		Name: "Leave request",
+		Variables: []variable{
+			{Name: "days", Type: "long", Required: true},
+			{Name: "reason", Type: "string"},
+		},
+		Tasks: []userTask{
+			{Key: "approve", Name: "Approve", Outputs: []variable{{Name: "approved", Type: "boolean"}, {Name: "comment", Type: "string", Required: true}}},
+			{Key: "notify", Name: "Notify HR"},
+		},
+	}}
+	if !reflect.DeepEqual(ps, want) {
+		t.Errorf("got %+v, want %+v", ps[0], want[0])
+	}
+}
+
+func TestParseBPMNErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not xml", `<definitions`},
+		{"no process", `<definitions></definitions>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseBPMN([]byte(tt.data)); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+func TestApplyExtensions(t *testing.T) {
+	p := &process{
+		Key:       "leave",
+		Variables: []variable{{Name: "days", Type: "long", Required: true}},
+		Tasks:     []userTask{{Key: "approve"}, {Key: "review", Outputs: []variable{{Name: "ok", Type: "boolean"}}}},
+	}
+	p.applyExtensions(&activiti.ProcessExtensions{
+		Properties: map[string]activiti.ExtensionProperty{
+			"p1": {Name: "days", Type: "string"},
+			"p2": {Name: "amount", Type: "double", Required: true},
+			"p3": {Name: "approved", Type: "boolean"},
+		},
+		Mappings: map[string]activiti.ExtensionMapping{
+			"approve": {Outputs: map[string]activiti.MappingValue{"approved": {Type: "variable", Value: "decision"}, "amount": {Type: "value", Value: 1}}},
+			"review":  {Outputs: map[string]activiti.MappingValue{"approved": {Type: "variable", Value: "decision"}}},
+		},
+	})
+
+	wantVars := []variable{{Name: "amount", Type: "double", Required: true}, {Name: "approved", Type: "boolean"}, {Name: "days", Type: "long", Required: true}}
+	if !reflect.DeepEqual(p.Variables, wantVars) {
+		t.Errorf("variables = %+v, want %+v", p.Variables, wantVars)
+	}
+	if want := []variable{{Name: "decision", Type: "boolean"}}; !reflect.DeepEqual(p.Tasks[0].Outputs, want) {
+		t.Errorf("approve outputs = %+v, want %+v", p.Tasks[0].Outputs, want)
+	}
+	if want := []variable{{Name: "ok", Type: "boolean"}}; !reflect.DeepEqual(p.Tasks[1].Outputs, want) {
+		t.Errorf("review outputs = %+v, want the form properties %+v", p.Tasks[1].Outputs, want)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	ps, err := parseBPMN([]byte(leaveBPMN))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps[0].Variables = append(ps[0].Variables, variable{Name: "tags", Type: "array"}, variable{Name: "extra", Type: "json"})
+
+	src, err := generate("leave", command, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"header", "// Code generated by activiti-gen; DO NOT EDIT.\n\npackage leave\n"},
+		{"process key", `const LeaveRequestKey = "leave"`},
+		{"task key", `ApproveTask = "approve" // Approve`},
+		{"required field", "Days int64 `json:\"days\"`"},
+		{"optional string is a pointer", "Reason *string `json:\"reason,omitempty\"`"},
+		{"optional bool is a pointer", "Approved *bool `json:\"approved,omitempty\"`"},
+		{"optional array", "Tags []interface{} `json:\"tags,omitempty\"`"},
+		{"optional json", "Extra interface{} `json:\"extra,omitempty\"`"},
+		{"required value", "\tm[\"days\"] = v.Days\n"},
+		{"optional value dereferenced", "\tif v.Approved != nil {\n\t\tm[\"approved\"] = *v.Approved\n\t}\n"},
+		{"optional array value", "\tif v.Tags != nil {\n\t\tm[\"tags\"] = v.Tags\n\t}\n"},
+		{"start", "func StartLeaveRequest(ctx context.Context, c activiti.ProcessInstanceService, businessKey string, v LeaveRequestVariables) (*activiti.StartResult, error) {"},
+		{"complete with outputs", "func CompleteApprove(c activiti.TaskService, taskID string, v ApproveOutputs) error {"},
+		{"complete without outputs", "func CompleteNotifyHR(c activiti.TaskService, taskID string) error {\n\treturn c.CompleteTask(taskID, nil)"},
+	}
+	// Compare ignoring the alignment of gofmt
+	squash := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(squash(string(src)), squash(tt.want)) {
+				t.Errorf("missing %q in\n%s", tt.want, src)
+			}
+		})
+	}
+}
+
+func TestGenerateDuplicateIdents(t *testing.T) {
+	ps := []*process{{Key: "leave-request"}, {Key: "leave_request"}}
+	if _, err := generate("leave", command, ps); err == nil {
+		t.Error("got no error")
+	}
+}
+
+func TestNewStructDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{"case and punctuation", []string{"a-b", "a_b", "AB"}, []string{"AB", "AB2", "AB3"}},
+		{"numbered name of a later variable", []string{"ab", "Ab", "ab2"}, []string{"Ab", "Ab3", "Ab2"}},
+		{"numbered name of an earlier variable", []string{"ab2", "ab", "Ab"}, []string{"Ab2", "Ab", "Ab3"}},
+		{"Map method", []string{"map", "Map", "map2"}, []string{"Map3", "Map4", "Map2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vs []variable
+			for _, n := range tt.names {
+				vs = append(vs, variable{Name: n, Required: true})
+			}
+			var idents []string
+			for _, f := range newStruct("V", "", vs).Fields {
+				idents = append(idents, f.Ident)
+			}
+			if !reflect.DeepEqual(idents, tt.want) {
+				t.Errorf("got %q, want %q", idents, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateCompiles builds the generated code of clashing names in a package of the module
+func TestGenerateCompiles(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	ps, err := parseBPMN([]byte(leaveBPMN))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps[0].Variables = append(ps[0].Variables,
+		variable{Name: "map", Type: "string"}, variable{Name: "Map", Type: "long", Required: true},
+		variable{Name: "a-b", Type: "boolean"}, variable{Name: "a_b", Type: "double"}, variable{Name: "ab2", Type: "json"},
+		variable{Name: "tags", Type: "array"})
+	ps[0].Tasks[0].Outputs = append(ps[0].Tasks[0].Outputs, variable{Name: "map", Type: "boolean"})
+	src, err := generate("leave", command, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := os.MkdirTemp(".", "gentest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "leave.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command(gobin, "vet", "./"+filepath.Base(dir)).CombinedOutput(); err != nil {
+		t.Errorf("generated code does not build: %v\n%s\n%s", err, out, src)
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		typ    string
+		want   string
+		scalar bool
+	}{
+		{"string", "string", true},
+		{"Date", "string", true},
+		{"enum", "string", true},
+		{"integer", "int64", true},
+		{"long", "int64", true},
+		{"double", "float64", true},
+		{"boolean", "bool", true},
+		{"array", "[]interface{}", false},
+		{"json", "interface{}", false},
+		{"", "interface{}", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got := goType(tt.typ)
+			if got != tt.want || scalar(got) != tt.scalar {
+				t.Errorf("got %s scalar %v, want %s scalar %v", got, scalar(got), tt.want, tt.scalar)
+			}
+		})
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"approve leave-request", "ApproveLeaveRequest"},
+		{"Notify HR", "NotifyHR"},
+		{"userTask_1", "UserTask1"},
+		{"2nd review", "X2ndReview"},
+		{"", "X"},
+		{"évaluer", "Évaluer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := identifier(tt.in); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
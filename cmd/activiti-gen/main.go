@@ -0,0 +1,173 @@
+// Command activiti-gen generates typed Go wrappers for BPMN processes.
+//
+//	//go:generate go run github.com/lihongchen/go-activiti-rest/cmd/activiti-gen -out leave_gen.go bpmn/leave.bpmn20.xml
+//
+// For every process it writes the process definition key, a constant for each user
+// task definition key, a Start<Process> function taking a struct of the process
+// variables and a Complete<Task> function per user task, taking a struct of the
+// task outputs when they are known:
+//
+//	res, err := StartLeaveRequest(ctx, client, "order-1", LeaveRequestVariables{Days: 3})
+//	approved := false
+//	err = CompleteApprove(client, taskID, ApproveOutputs{Approved: &approved})
+//
+// Optional variables are pointer fields, nil ones are not sent, so false, 0 and ""
+// can be sent. Process variables and task outputs are read from the
+// '<name>-extensions.json' file saved by the modeler next to '<name>.bpmn20.xml', or
+// given with -extensions, and from the activiti:formProperty elements of the start
+// event and user tasks. BPMN files can instead be downloaded from a
+// runtime bundle, with the variables of the process definition meta data:
+//
+//	activiti-gen -url http://gateway/rb/v1 -token $ACTIVITI_TOKEN -keys leave,expenses -out processes_gen.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const command = "activiti-gen"
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, command+":", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	pkg := fs.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file, $GOPACKAGE under go generate")
+	out := fs.String("out", "", "generated file, standard output when empty")
+	extensions := fs.String("extensions", "", "extensions file of the BPMN files, '<name>-extensions.json' next to each file by default")
+	baseURL := fs.String("url", "", "runtime bundle url to download the processes of -keys from, for example http://gateway/rb/v1")
+	token := fs.String("token", os.Getenv("ACTIVITI_TOKEN"), "access token of -url")
+	keys := fs.String("keys", "", "comma separated process definition keys to download")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [-pkg name] [-out file] [-extensions file] file.bpmn20.xml...\n", command)
+		fmt.Fprintf(fs.Output(), "       %s [-pkg name] [-out file] -url url -token token -keys key,...\n", command)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *pkg == "" {
+		return errors.New("-pkg is required outside go generate")
+	}
+
+	var ps []*process
+	switch {
+	case *keys != "":
+		if *baseURL == "" {
+			return errors.New("-url is required with -keys")
+		}
+		c, err := activiti.NewClient(*token, *baseURL)
+		if err != nil {
+			return err
+		}
+		for _, key := range strings.Split(*keys, ",") {
+			p, err := download(c, strings.TrimSpace(key))
+			if err != nil {
+				return err
+			}
+			ps = append(ps, p)
+		}
+	case fs.NArg() > 0:
+		for _, path := range fs.Args() {
+			fps, err := load(path, *extensions)
+			if err != nil {
+				return err
+			}
+			ps = append(ps, fps...)
+		}
+	default:
+		fs.Usage()
+		return errors.New("no BPMN file or -keys")
+	}
+
+	src, err := generate(*pkg, command, ps)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*out, src, 0644)
+}
+
+// load reads the processes of a BPMN file and applies its extensions file when there is one
+func load(path, extensionsPath string) ([]*process, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := parseBPMN(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	if extensionsPath == "" {
+		extensionsPath = defaultExtensionsPath(path)
+		if _, err := os.Stat(extensionsPath); err != nil {
+			return ps, nil
+		}
+	}
+	ext, err := activiti.LoadProcessExtensions(extensionsPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", extensionsPath, err)
+	}
+	for _, p := range ps {
+		if e, ok := ext.Process(p.Key); ok {
+			p.applyExtensions(&e)
+		}
+	}
+	return ps, nil
+}
+
+// defaultExtensionsPath returns the extensions file saved by the modeler with a BPMN file
+func defaultExtensionsPath(path string) string {
+	base := path
+	for _, ext := range []string{".bpmn20.xml", ".bpmn", ".xml"} {
+		if strings.HasSuffix(base, ext) {
+			base = strings.TrimSuffix(base, ext)
+			break
+		}
+	}
+	return filepath.Join(filepath.Dir(path), filepath.Base(base)+"-extensions.json")
+}
+
+// download reads the latest definition of key from the runtime bundle
+func download(c *activiti.ActClient, key string) (*process, error) {
+	pd, err := c.GetLatestProcessDefinition(key)
+	if err != nil {
+		return nil, fmt.Errorf("process definition %s: %v", key, err)
+	}
+	model, err := c.GetProcessDefinitionModel(pd.ID)
+	if err != nil {
+		return nil, fmt.Errorf("model of %s: %v", pd.ID, err)
+	}
+	ps, err := parseBPMN(model)
+	if err != nil {
+		return nil, fmt.Errorf("model of %s: %v", pd.ID, err)
+	}
+
+	for _, p := range ps {
+		if p.Key != key {
+			continue
+		}
+		meta, err := c.GetProcessDefinitionMeta(pd.ID)
+		if err != nil {
+			return nil, fmt.Errorf("meta of %s: %v", pd.ID, err)
+		}
+		p.applyMeta(&meta.Entry)
+		return p, nil
+	}
+	return nil, fmt.Errorf("model of %s has no process %s", pd.ID, key)
+}
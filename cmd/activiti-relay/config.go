@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lihongchen/go-activiti-rest/relay"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Config is the relay configuration file. ${VAR} references are expanded from the environment
+	Config struct {
+		Activiti ActivitiConfig `yaml:"activiti"`
+		Source   SourceConfig   `yaml:"source"`
+		Delivery DeliveryConfig `yaml:"delivery"`
+		Rules    []relay.Rule   `yaml:"rules"`
+	}
+
+	ActivitiConfig struct {
+		URL      string `yaml:"url"`                // Runtime bundle url, for example http://gateway/rb/v1
+		QueryURL string `yaml:"queryUrl,omitempty"` // Query service url, derived from url when empty
+		Token    string `yaml:"token,omitempty"`
+		// TokenFile is read again whenever it changes, for tokens refreshed by a sidecar
+		TokenFile string `yaml:"tokenFile,omitempty"`
+	}
+
+	SourceConfig struct {
+		Type string `yaml:"type"` // amqp, graphql or poll
+
+		AMQPURL string `yaml:"amqpUrl,omitempty"`
+		Group   string `yaml:"group,omitempty"` // AMQP consumer group
+
+		GraphQLURL string `yaml:"graphqlUrl,omitempty"` // Notifications service graphql-ws url
+
+		Interval   time.Duration `yaml:"interval,omitempty"`   // Poll interval
+		Checkpoint string        `yaml:"checkpoint,omitempty"` // Poll checkpoint file
+	}
+
+	DeliveryConfig struct {
+		MaxAttempts int           `yaml:"maxAttempts,omitempty"`
+		Workers     int           `yaml:"workers,omitempty"`
+		Timeout     time.Duration `yaml:"timeout,omitempty"`    // Per attempt, 10s when 0
+		DeadLetter  string        `yaml:"deadLetter,omitempty"` // JSON lines file
+	}
+)
+
+// loadConfig reads and checks the configuration file
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", path, err)
+	}
+
+	if cfg.Activiti.URL == "" {
+		return nil, fmt.Errorf("%s: activiti.url is required", path)
+	}
+	if cfg.Activiti.Token == "" && cfg.Activiti.TokenFile == "" {
+		return nil, fmt.Errorf("%s: activiti.token or activiti.tokenFile is required", path)
+	}
+	switch cfg.Source.Type {
+	case "amqp":
+		if cfg.Source.AMQPURL == "" {
+			return nil, fmt.Errorf("%s: source.amqpUrl is required", path)
+		}
+	case "graphql":
+		if cfg.Source.GraphQLURL == "" {
+			return nil, fmt.Errorf("%s: source.graphqlUrl is required", path)
+		}
+	case "poll":
+	default:
+		return nil, fmt.Errorf("%s: unknown source.type %q, use amqp, graphql or poll", path, cfg.Source.Type)
+	}
+	if len(cfg.Rules) == 0 {
+		return nil, fmt.Errorf("%s: no rules", path)
+	}
+	if cfg.Delivery.Timeout <= 0 {
+		cfg.Delivery.Timeout = 10 * time.Second
+	}
+	return cfg, nil
+}
@@ -0,0 +1,238 @@
+// Package connector runs Activiti Cloud connectors in Go. Service tasks are executed by
+// connectors receiving integration requests over the message broker, handlers are
+// registered by the implementation name of the service task:
+//
+//	w := connector.NewWorker(transport, connector.Options{})
+//	w.HandleFunc("sendMail", func(ctx context.Context, req *connector.IntegrationRequest) (*connector.IntegrationResult, error) {
+//		...
+//		return req.Result(map[string]interface{}{"sent": true}), nil
+//	})
+//	err := w.Run(ctx)
+//
+// Handlers returning Retry(err) get the request again from the broker, Redelivered(ctx)
+// tells them it ran before. A redelivered request failing again is dead-lettered unless
+// Options.RequeueRedelivered is set.
+//
+// The broker is hidden behind Transport, MemoryTransport serves tests.
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests handled at once when Options.Concurrency is 0
+const DefaultConcurrency = 1
+
+// ErrNoHandler is reported to the runtime bundle for implementations without a handler
+var ErrNoHandler = errors.New("no handler for connector type")
+
+type (
+	// Handler executes service tasks of one implementation
+	Handler interface {
+		Handle(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error)
+	}
+
+	// HandlerFunc adapts a function to Handler
+	HandlerFunc func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error)
+
+	// Delivery is an integration request received by a transport
+	Delivery struct {
+		Body        []byte // JSON encoded IntegrationRequest
+		Redelivered bool
+		// Ack acknowledges the request once a result or error was published
+		Ack func() error
+		// Reject hands the request back, to be redelivered when requeue is true
+		// or dead-lettered when it is false
+		Reject func(requeue bool) error
+	}
+
+	// Transport connects the worker to the message broker
+	Transport interface {
+		// Receive delivers the requests of the connector types until ctx is done,
+		// then closes the channel
+		Receive(ctx context.Context, connectorTypes []string) (<-chan Delivery, error)
+		// PublishResult sends a result to the runtime bundle of its request
+		PublishResult(ctx context.Context, res *IntegrationResult) error
+		// PublishError sends an error to the runtime bundle of its request
+		PublishError(ctx context.Context, ie *IntegrationError) error
+	}
+
+	// Options configures a Worker
+	Options struct {
+		Concurrency int          // Requests handled at once, DefaultConcurrency when 0
+		Logger      *slog.Logger // Receives a record per failed request, none when nil
+
+		// RequeueRedelivered requeues redelivered requests which fail again with a Retry
+		// or a publish error. By default they are rejected without requeue, to be
+		// dead-lettered, so a request is not redelivered forever
+		RequeueRedelivered bool
+	}
+
+	// redeliveredKey is the context key marking redelivered requests
+	redeliveredKey struct{}
+
+	// Worker dispatches integration requests to the registered handlers
+	Worker struct {
+		transport Transport
+		opts      Options
+
+		mu       sync.RWMutex
+		handlers map[string]Handler
+	}
+)
+
+// Handle implements Handler
+func (fn HandlerFunc) Handle(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+	return fn(ctx, req)
+}
+
+// NewWorker returns a worker receiving requests from the transport
+func NewWorker(t Transport, opts Options) *Worker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	return &Worker{transport: t, opts: opts, handlers: map[string]Handler{}}
+}
+
+// Handle registers the handler of an implementation name, replacing any previous one
+func (w *Worker) Handle(implementation string, h Handler) {
+	w.mu.Lock()
+	w.handlers[implementation] = h
+	w.mu.Unlock()
+}
+
+// HandleFunc registers a function as the handler of an implementation name
+func (w *Worker) HandleFunc(implementation string, fn func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error)) {
+	w.Handle(implementation, HandlerFunc(fn))
+}
+
+// ConnectorTypes returns the registered implementation names, sorted
+func (w *Worker) ConnectorTypes() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	types := make([]string, 0, len(w.handlers))
+	for t := range w.handlers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Run receives and handles requests until ctx is done or the transport closes the
+// delivery channel. Handlers must be registered before
+func (w *Worker) Run(ctx context.Context) error {
+	types := w.ConnectorTypes()
+	if len(types) == 0 {
+		return errors.New("connector: no handler registered")
+	}
+	deliveries, err := w.transport.Receive(ctx, types)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range deliveries {
+				w.deliver(ctx, d)
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Redelivered reports whether the request handled with ctx was delivered before,
+// handlers with side effects may check whether they already ran
+func Redelivered(ctx context.Context) bool {
+	redelivered, _ := ctx.Value(redeliveredKey{}).(bool)
+	return redelivered
+}
+
+// deliver handles one delivery and settles it with the transport.
+// Failed requests are requeued, except redelivered ones without Options.RequeueRedelivered,
+// and requests given back while the worker stops
+func (w *Worker) deliver(ctx context.Context, d Delivery) {
+	var req IntegrationRequest
+	if err := json.Unmarshal(d.Body, &req); err != nil {
+		w.logError(ctx, "malformed integration request", &req, err)
+		if d.Reject != nil {
+			d.Reject(false)
+		}
+		return
+	}
+
+	if d.Redelivered {
+		ctx = context.WithValue(ctx, redeliveredKey{}, true)
+	}
+	if err := w.Dispatch(ctx, &req); err != nil {
+		w.logError(ctx, "integration request not handled", &req, err)
+		if d.Reject != nil {
+			d.Reject(!d.Redelivered || w.opts.RequeueRedelivered || ctx.Err() != nil)
+		}
+		return
+	}
+	if d.Ack != nil {
+		if err := d.Ack(); err != nil {
+			w.logError(ctx, "ack failed", &req, err)
+		}
+	}
+}
+
+// Dispatch runs the handler of the request and publishes its result or error.
+// The returned error means nothing was published and the request should be redelivered:
+// the handler asked for a Retry or publishing failed
+func (w *Worker) Dispatch(ctx context.Context, req *IntegrationRequest) error {
+	res, err := w.call(ctx, req)
+	var re *retryError
+	if errors.As(err, &re) {
+		return err
+	}
+
+	if err != nil {
+		w.logError(ctx, "service task failed", req, err)
+		return w.transport.PublishError(ctx, req.Error(err))
+	}
+	if res == nil {
+		res = req.Result(nil)
+	}
+	return w.transport.PublishResult(ctx, res)
+}
+
+// call runs the handler, turning panics into errors
+func (w *Worker) call(ctx context.Context, req *IntegrationRequest) (res *IntegrationResult, err error) {
+	w.mu.RLock()
+	h, ok := w.handlers[req.IntegrationContext.ConnectorType]
+	w.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoHandler, req.IntegrationContext.ConnectorType)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			res, err = nil, fmt.Errorf("connector %s panicked: %v", req.IntegrationContext.ConnectorType, p)
+		}
+	}()
+	return h.Handle(ctx, req)
+}
+
+func (w *Worker) logError(ctx context.Context, msg string, req *IntegrationRequest, err error) {
+	if w.opts.Logger == nil {
+		return
+	}
+	w.opts.Logger.LogAttrs(ctx, slog.LevelWarn, msg,
+		slog.String("request_id", req.ID),
+		slog.String("connector_type", req.IntegrationContext.ConnectorType),
+		slog.String("process_instance_id", req.IntegrationContext.ProcessInstanceID),
+		slog.String("error", err.Error()),
+	)
+}
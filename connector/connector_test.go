@@ -0,0 +1,203 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorker(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error)
+		connector   string
+		redelivered bool
+		opts        Options
+
+		result   map[string]interface{} // Outbound variables of the published result
+		code     string                 // Error code of the published error
+		message  string                 // Error message of the published error
+		rejected bool
+		requeue  bool
+	}{
+		{
+			name: "ack",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return req.Result(map[string]interface{}{"sent": req.Variables()["to"]}), nil
+			},
+			result: map[string]interface{}{"sent": "bob"},
+		},
+		{
+			name: "nil result",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, nil
+			},
+			result: map[string]interface{}{},
+		},
+		{
+			name: "failure",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, errors.New("smtp down")
+			},
+			message: "smtp down",
+		},
+		{
+			name: "bpmn error",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, &BPMNError{Code: "INVALID_ADDRESS", Message: "no such user"}
+			},
+			code:    "INVALID_ADDRESS",
+			message: "no such user",
+		},
+		{
+			name: "panic",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				panic("boom")
+			},
+			message: "connector sendMail panicked: boom",
+		},
+		{
+			name:      "no handler",
+			connector: "unknown",
+			message:   ErrNoHandler.Error() + " unknown",
+		},
+		{
+			name: "retry",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, Retry(errors.New("busy"))
+			},
+			rejected: true,
+			requeue:  true,
+		},
+		{
+			name: "retry redelivered",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, Retry(errors.New("busy"))
+			},
+			redelivered: true,
+			rejected:    true,
+		},
+		{
+			name: "retry redelivered with requeue",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return nil, Retry(errors.New("busy"))
+			},
+			redelivered: true,
+			opts:        Options{RequeueRedelivered: true},
+			rejected:    true,
+			requeue:     true,
+		},
+		{
+			name: "redelivered",
+			handler: func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+				return req.Result(map[string]interface{}{"redelivered": Redelivered(ctx)}), nil
+			},
+			redelivered: true,
+			result:      map[string]interface{}{"redelivered": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			m := NewMemoryTransport(1)
+			w := NewWorker(m, tt.opts)
+			w.HandleFunc("sendMail", tt.handler)
+			done := make(chan error, 1)
+			go func() { done <- w.Run(ctx) }()
+
+			connector := tt.connector
+			if connector == "" {
+				connector = "sendMail"
+			}
+			req := &IntegrationRequest{ID: "r1", IntegrationContext: IntegrationContext{
+				ConnectorType:    connector,
+				InBoundVariables: map[string]interface{}{"to": "bob"},
+			}}
+			send := m.Send
+			if tt.redelivered {
+				send = m.Redeliver
+			}
+			if err := send(ctx, req); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := m.Next(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Request == nil || r.Request.ID != "r1" {
+				t.Errorf("reply request = %+v, want r1", r.Request)
+			}
+			switch {
+			case tt.rejected:
+				if !r.Rejected || r.Requeue != tt.requeue {
+					t.Errorf("reply = rejected %v requeue %v, want rejected requeue %v", r.Rejected, r.Requeue, tt.requeue)
+				}
+			case tt.result != nil:
+				if r.Result == nil {
+					t.Fatalf("reply = %+v, want a result", r)
+				}
+				for k, v := range tt.result {
+					if got := r.Result.IntegrationContext.OutBoundVariables[k]; got != v {
+						t.Errorf("outbound %s = %v, want %v", k, got, v)
+					}
+				}
+			default:
+				if r.Error == nil {
+					t.Fatalf("reply = %+v, want an error", r)
+				}
+				if r.Error.ErrorCode != tt.code || !strings.Contains(r.Error.ErrorMessage, tt.message) {
+					t.Errorf("error = %s %q, want %s %q", r.Error.ErrorCode, r.Error.ErrorMessage, tt.code, tt.message)
+				}
+			}
+
+			cancel()
+			if err := <-done; !errors.Is(err, context.Canceled) {
+				t.Errorf("Run = %v, want %v", err, context.Canceled)
+			}
+		})
+	}
+}
+
+func TestWorkerRequeuesOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := NewMemoryTransport(1)
+	w := NewWorker(m, Options{})
+	started := make(chan struct{})
+	w.HandleFunc("slow", func(ctx context.Context, req *IntegrationRequest) (*IntegrationResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, Retry(ctx.Err())
+	})
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	if err := m.Redeliver(ctx, &IntegrationRequest{ID: "r1", IntegrationContext: IntegrationContext{ConnectorType: "slow"}}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	cancel()
+	<-done
+
+	next, stop := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stop()
+	r, err := m.Next(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Rejected || !r.Requeue {
+		t.Errorf("reply = rejected %v requeue %v, want a requeued rejection", r.Rejected, r.Requeue)
+	}
+}
+
+func TestRunWithoutHandlers(t *testing.T) {
+	w := NewWorker(NewMemoryTransport(1), Options{})
+	if err := w.Run(context.Background()); err == nil {
+		t.Error("Run without handlers succeeded")
+	}
+}
@@ -0,0 +1,142 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+type (
+	// MemoryTransport is an in-process Transport for tests: requests are sent with Send
+	// and everything the worker publishes or rejects is read back with Next
+	MemoryTransport struct {
+		requests chan Delivery
+
+		mu      sync.Mutex
+		replies []Reply
+		notify  chan struct{}
+	}
+
+	// Reply is what the worker did with a request sent to a MemoryTransport,
+	// exactly one of Result, Error and Rejected is set
+	Reply struct {
+		Request  *IntegrationRequest
+		Result   *IntegrationResult
+		Error    *IntegrationError
+		Rejected bool
+		Requeue  bool // The rejected request asked to be redelivered
+	}
+)
+
+// NewMemoryTransport returns a transport buffering up to size unhandled requests
+func NewMemoryTransport(size int) *MemoryTransport {
+	return &MemoryTransport{requests: make(chan Delivery, size), notify: make(chan struct{})}
+}
+
+// Send queues a request for the worker. The request is encoded as a broker would
+func (m *MemoryTransport) Send(ctx context.Context, req *IntegrationRequest) error {
+	return m.send(ctx, req, false)
+}
+
+// Redeliver queues a request marked as redelivered, as a broker does with requeued requests
+func (m *MemoryTransport) Redeliver(ctx context.Context, req *IntegrationRequest) error {
+	return m.send(ctx, req, true)
+}
+
+func (m *MemoryTransport) send(ctx context.Context, req *IntegrationRequest, redelivered bool) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var once sync.Once
+	settle := func(r Reply) error {
+		once.Do(func() {
+			if !r.Rejected {
+				// Ack after a publish already recorded the reply
+				return
+			}
+			r.Request = req
+			m.add(r)
+		})
+		return nil
+	}
+	d := Delivery{
+		Body:        body,
+		Redelivered: redelivered,
+		Ack:         func() error { return settle(Reply{}) },
+		Reject:      func(requeue bool) error { return settle(Reply{Rejected: true, Requeue: requeue}) },
+	}
+
+	select {
+	case m.requests <- d:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive implements Transport, the connector types are ignored
+func (m *MemoryTransport) Receive(ctx context.Context, connectorTypes []string) (<-chan Delivery, error) {
+	out := make(chan Delivery)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case d := <-m.requests:
+				select {
+				case out <- d:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// PublishResult implements Transport
+func (m *MemoryTransport) PublishResult(ctx context.Context, res *IntegrationResult) error {
+	req := res.IntegrationRequest
+	m.add(Reply{Request: &req, Result: res})
+	return nil
+}
+
+// PublishError implements Transport
+func (m *MemoryTransport) PublishError(ctx context.Context, ie *IntegrationError) error {
+	req := ie.IntegrationRequest
+	m.add(Reply{Request: &req, Error: ie})
+	return nil
+}
+
+// Next waits for the next reply in the order they were made
+func (m *MemoryTransport) Next(ctx context.Context) (Reply, error) {
+	for {
+		m.mu.Lock()
+		if len(m.replies) > 0 {
+			r := m.replies[0]
+			m.replies = m.replies[1:]
+			m.mu.Unlock()
+			return r, nil
+		}
+		notify := m.notify
+		m.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return Reply{}, errors.Join(errors.New("connector: no reply"), ctx.Err())
+		}
+	}
+}
+
+func (m *MemoryTransport) add(r Reply) {
+	m.mu.Lock()
+	m.replies = append(m.replies, r)
+	close(m.notify)
+	m.notify = make(chan struct{})
+	m.mu.Unlock()
+}
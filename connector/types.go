@@ -0,0 +1,153 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+)
+
+type (
+	// IntegrationContext is the service task execution a request is about
+	IntegrationContext struct {
+		ID                       string                 `json:"id,omitempty"`
+		ConnectorType            string                 `json:"connectorType,omitempty"` // The implementation of the service task
+		ClientID                 string                 `json:"clientId,omitempty"`      // The BPMN id of the service task
+		ClientName               string                 `json:"clientName,omitempty"`
+		ClientType               string                 `json:"clientType,omitempty"`
+		ProcessInstanceID        string                 `json:"processInstanceId,omitempty"`
+		ParentProcessInstanceID  string                 `json:"parentProcessInstanceId,omitempty"`
+		RootProcessInstanceID    string                 `json:"rootProcessInstanceId,omitempty"`
+		ProcessDefinitionID      string                 `json:"processDefinitionId,omitempty"`
+		ProcessDefinitionKey     string                 `json:"processDefinitionKey,omitempty"`
+		ProcessDefinitionVersion int                    `json:"processDefinitionVersion,omitempty"`
+		BusinessKey              string                 `json:"businessKey,omitempty"`
+		ExecutionID              string                 `json:"executionId,omitempty"`
+		AppVersion               string                 `json:"appVersion,omitempty"`
+		InBoundVariables         map[string]interface{} `json:"inBoundVariables,omitempty"`
+		OutBoundVariables        map[string]interface{} `json:"outBoundVariables,omitempty"`
+	}
+
+	// IntegrationRequest is sent by the runtime bundle when a process reaches a service task
+	IntegrationRequest struct {
+		ID                 string             `json:"id,omitempty"`
+		IntegrationContext IntegrationContext `json:"integrationContext"`
+		AppName            string             `json:"appName,omitempty"`
+		AppVersion         string             `json:"appVersion,omitempty"`
+		ServiceName        string             `json:"serviceName,omitempty"`
+		ServiceFullName    string             `json:"serviceFullName,omitempty"`
+		ServiceType        string             `json:"serviceType,omitempty"`
+		ServiceVersion     string             `json:"serviceVersion,omitempty"`
+	}
+
+	// IntegrationResult completes the service task with the outbound variables of its context
+	IntegrationResult struct {
+		ID                 string             `json:"id,omitempty"`
+		IntegrationRequest IntegrationRequest `json:"integrationRequest"`
+		IntegrationContext IntegrationContext `json:"integrationContext"`
+		AppName            string             `json:"appName,omitempty"`
+		AppVersion         string             `json:"appVersion,omitempty"`
+		ServiceName        string             `json:"serviceName,omitempty"`
+		ServiceFullName    string             `json:"serviceFullName,omitempty"`
+		ServiceType        string             `json:"serviceType,omitempty"`
+		ServiceVersion     string             `json:"serviceVersion,omitempty"`
+	}
+
+	// IntegrationError reports a failed service task to the runtime bundle.
+	// An ErrorCode matching a BPMN error boundary event triggers that event
+	IntegrationError struct {
+		ID                 string             `json:"id,omitempty"`
+		IntegrationRequest IntegrationRequest `json:"integrationRequest"`
+		IntegrationContext IntegrationContext `json:"integrationContext"`
+		ErrorCode          string             `json:"errorCode,omitempty"`
+		ErrorMessage       string             `json:"errorMessage,omitempty"`
+		ErrorClassName     string             `json:"errorClassName,omitempty"`
+	}
+
+	// BPMNError is returned by handlers to raise a BPMN error with a code
+	BPMNError struct {
+		Code    string
+		Message string
+	}
+
+	// retryError marks an error as transient, see Retry
+	retryError struct {
+		err error
+	}
+)
+
+// Variables returns the inbound variables of the request
+func (r *IntegrationRequest) Variables() map[string]interface{} {
+	return r.IntegrationContext.InBoundVariables
+}
+
+// ServiceTaskKey returns the BPMN id of the service task
+func (r *IntegrationRequest) ServiceTaskKey() string {
+	return r.IntegrationContext.ClientID
+}
+
+// ProcessInstanceID returns the id of the process instance executing the service task
+func (r *IntegrationRequest) ProcessInstanceID() string {
+	return r.IntegrationContext.ProcessInstanceID
+}
+
+// Result returns the result completing the request with the outbound variables
+func (r *IntegrationRequest) Result(outBoundVariables map[string]interface{}) *IntegrationResult {
+	ictx := r.IntegrationContext
+	ictx.OutBoundVariables = outBoundVariables
+	return &IntegrationResult{
+		ID:                 r.ID,
+		IntegrationRequest: *r,
+		IntegrationContext: ictx,
+		AppName:            r.AppName,
+		AppVersion:         r.AppVersion,
+		ServiceName:        r.ServiceName,
+		ServiceFullName:    r.ServiceFullName,
+		ServiceType:        r.ServiceType,
+		ServiceVersion:     r.ServiceVersion,
+	}
+}
+
+// Error returns the error reporting err for the request
+func (r *IntegrationRequest) Error(err error) *IntegrationError {
+	ie := &IntegrationError{
+		ID:                 r.ID,
+		IntegrationRequest: *r,
+		IntegrationContext: r.IntegrationContext,
+		ErrorMessage:       err.Error(),
+		ErrorClassName:     fmt.Sprintf("%T", err),
+	}
+	var be *BPMNError
+	if errors.As(err, &be) {
+		ie.ErrorCode = be.Code
+		if be.Message != "" {
+			ie.ErrorMessage = be.Message
+		}
+	}
+	return ie
+}
+
+// Error implements error
+func (e *BPMNError) Error() string {
+	if e.Message == "" {
+		return "bpmn error " + e.Code
+	}
+	return fmt.Sprintf("bpmn error %s: %s", e.Code, e.Message)
+}
+
+// Retry marks err as transient: the request is rejected and redelivered by the
+// transport instead of failing the service task
+func Retry(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryError{err: err}
+}
+
+// Error implements error
+func (e *retryError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the transient error
+func (e *retryError) Unwrap() error {
+	return e.err
+}
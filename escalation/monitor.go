@@ -0,0 +1,273 @@
+// Package escalation monitors open tasks and escalates the ones sitting unclaimed,
+// open for too long or overdue.
+//
+//	m := escalation.NewMonitor(client, escalation.Options{
+//		Rules: []escalation.Rule{{
+//			Name:              "approval-unclaimed",
+//			TaskDefinitionKey: "approveLeave",
+//			MaxUnclaimed:      4 * time.Hour,
+//			Reassign:          "hrmanager",
+//			Priority:          80,
+//			Notify:            true,
+//		}},
+//		Notifier: escalation.NotifierFunc(func(ctx context.Context, v escalation.Violation) error {
+//			return mail.Send("hr@example.com", v.String())
+//		}),
+//	})
+//	go m.Run(ctx)
+//
+// The query service is polled through ActClient, the client must be allowed to see
+// and act on the monitored tasks. The actions of a rule run once per task and breach,
+// or every Rule.Repeat while the task stays in breach.
+//
+// The actions which ran are only remembered in memory: a new Monitor, for example after
+// a restart, runs the actions of the tasks still in breach again and calls the Notifier
+// again for them.
+package escalation
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const (
+	// DefaultInterval is the poll interval when Options.Interval is 0
+	DefaultInterval = time.Minute
+	// DefaultPageSize is the query page size when Options.PageSize is 0
+	DefaultPageSize = 100
+)
+
+// DefaultTaskStatuses are the task statuses which are monitored
+var DefaultTaskStatuses = []string{"CREATED", "ASSIGNED"}
+
+type (
+	// Options configures a Monitor
+	Options struct {
+		Rules        []Rule
+		Notifier     Notifier         // Called by the rules with Notify
+		Interval     time.Duration    // Time between polls
+		PageSize     int              // maxItems of each query page
+		TaskStatuses []string         // Task statuses which are monitored
+		Params       url.Values       // Extra task filters, for example {"appName": {"hr"}}
+		Logger       *slog.Logger     // Logs the violations and failed actions when set
+		Now          func() time.Time // time.Now when nil
+	}
+
+	// Monitor polls the open tasks and applies the rules
+	Monitor struct {
+		c    Client
+		opts Options
+
+		mu    sync.Mutex
+		fired map[firing]time.Time
+		// applied holds the actions which succeeded for a firing whose other actions failed
+		applied map[firing]map[int]bool
+		// running holds the firings whose actions a poll is running
+		running map[firing]bool
+	}
+
+	// pending is a firing whose actions a poll runs
+	pending struct {
+		key     firing
+		v       Violation
+		applied map[int]bool
+		ok      bool
+	}
+
+	// firing identifies the actions run for a breach of a rule by a task
+	firing struct {
+		task   string
+		rule   int
+		breach Breach
+	}
+)
+
+// NewMonitor returns a Monitor polling c, usually an *activiti.ActClient
+func NewMonitor(c Client, opts Options) *Monitor {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if len(opts.TaskStatuses) == 0 {
+		opts.TaskStatuses = DefaultTaskStatuses
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Monitor{
+		c:       c,
+		opts:    opts,
+		fired:   map[firing]time.Time{},
+		applied: map[firing]map[int]bool{},
+		running: map[firing]bool{},
+	}
+}
+
+// Run polls immediately and then on every interval until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Poll(ctx); err != nil && ctx.Err() == nil && m.opts.Logger != nil {
+			m.opts.Logger.Warn("task poll failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the open tasks once and runs the actions of new violations, which are returned.
+// The actions of a violation which failed are retried on the next poll, the ones which
+// succeeded are not run again
+func (m *Monitor) Poll(ctx context.Context) ([]Violation, error) {
+	c := withContext(ctx, m.c)
+	tasks, err := m.openTasks(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	now := m.opts.Now()
+	open := map[string]bool{}
+	var due []pending
+	for _, t := range tasks {
+		open[t.ID] = true
+		for i := range m.opts.Rules {
+			r := &m.opts.Rules[i]
+			for _, v := range r.check(t, now) {
+				key := firing{task: t.ID, rule: i, breach: v.Breach}
+				if last, ok := m.fired[key]; ok && (r.Repeat <= 0 || now.Sub(last) < r.Repeat) {
+					continue
+				}
+				// Applied by a concurrent poll
+				if m.running[key] {
+					continue
+				}
+				m.running[key] = true
+				applied := m.applied[key]
+				if applied == nil {
+					applied = map[int]bool{}
+				}
+				due = append(due, pending{key: key, v: v, applied: applied})
+			}
+		}
+	}
+
+	// Forget the tasks which were completed or no longer match
+	for key := range m.fired {
+		if !open[key.task] {
+			delete(m.fired, key)
+		}
+	}
+	for key := range m.applied {
+		if !open[key.task] {
+			delete(m.applied, key)
+		}
+	}
+	m.mu.Unlock()
+
+	// The actions make requests and call the notifier, other polls may run meanwhile
+	var fired []Violation
+	for i := range due {
+		due[i].ok = m.apply(ctx, c, due[i].v, due[i].applied)
+		fired = append(fired, due[i].v)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range due {
+		delete(m.running, p.key)
+		if p.ok {
+			m.fired[p.key] = now
+			delete(m.applied, p.key)
+		} else {
+			m.applied[p.key] = p.applied
+		}
+	}
+	return fired, nil
+}
+
+// apply runs the actions of a violation which are not in applied, adds the ones
+// which succeed to it and reports whether they all succeeded
+func (m *Monitor) apply(ctx context.Context, c Client, v Violation, applied map[int]bool) bool {
+	log := m.opts.Logger
+	if log != nil && len(applied) == 0 {
+		log.Info("task escalated", slog.String("rule", v.Rule.Name), slog.String("task", v.Task.ID),
+			slog.String("breach", string(v.Breach)), slog.Duration("over", v.Over))
+	}
+
+	ok := true
+	for i, a := range v.Rule.actions(m.opts.Notifier) {
+		if applied[i] {
+			continue
+		}
+		if err := a.Apply(ctx, c, v); err != nil {
+			ok = false
+			if log != nil {
+				log.Warn("escalation action failed", slog.String("rule", v.Rule.Name), slog.String("task", v.Task.ID),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+		applied[i] = true
+	}
+	return ok
+}
+
+// withContext returns c making its requests with ctx, when it can
+func withContext(ctx context.Context, c Client) Client {
+	switch cc := c.(type) {
+	case *activiti.ActClient:
+		return cc.WithContext(ctx)
+	case ContextClient:
+		return cc.WithContext(ctx)
+	}
+	return c
+}
+
+// openTasks pages through the tasks with a monitored status
+func (m *Monitor) openTasks(ctx context.Context, c Client) ([]activiti.Task, error) {
+	var tasks []activiti.Task
+	for _, status := range m.opts.TaskStatuses {
+		for skip := 0; ; skip += m.opts.PageSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			page, err := c.QueryTasks(m.params(status, skip))
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range page.List.Tasks {
+				tasks = append(tasks, t.Task)
+			}
+			if !page.List.Pagination.HasMoreItems || len(page.List.Tasks) == 0 {
+				break
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// params returns the query filter of one page
+func (m *Monitor) params(status string, skip int) url.Values {
+	params := url.Values{}
+	for k, v := range m.opts.Params {
+		params[k] = v
+	}
+	params.Set("status", status)
+	params.Set("skipCount", strconv.Itoa(skip))
+	params.Set("maxItems", strconv.Itoa(m.opts.PageSize))
+	return params
+}
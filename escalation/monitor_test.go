@@ -0,0 +1,231 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// fakeClient records the actions and fails the ones in fail
+type fakeClient struct {
+	tasks []activiti.Task
+	calls *[]string
+	fail  map[string]bool
+}
+
+func (f *fakeClient) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	l := &activiti.ActListTasks{}
+	if params.Get("status") == "CREATED" {
+		for _, t := range f.tasks {
+			l.List.Tasks = append(l.List.Tasks, activiti.ActTask{Task: t})
+		}
+	}
+	return l, nil
+}
+
+func (f *fakeClient) TaskActionAssign(tid string, assignee string) error {
+	return f.record(fmt.Sprintf("assign %s %s", tid, assignee))
+}
+
+func (f *fakeClient) UpdateTask(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error) {
+	return nil, f.record(fmt.Sprintf("priority %s %d", tid, u.Priority))
+}
+
+func (f *fakeClient) SetProcessVariables(pid string, variables map[string]interface{}) error {
+	return f.record("variables " + pid)
+}
+
+func (f *fakeClient) record(call string) error {
+	*f.calls = append(*f.calls, call)
+	if f.fail[call] {
+		return errors.New(call + " failed")
+	}
+	return nil
+}
+
+func TestMonitorPoll(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	task := activiti.Task{ID: "t1", ProcessInstanceId: "p1", CreatedDate: "2026-01-10T06:00:00Z"}
+	rule := Rule{Name: "unclaimed", MaxUnclaimed: time.Hour, Reassign: "boss", Variables: map[string]interface{}{"escalated": true}}
+
+	type poll struct {
+		after time.Duration // Since the first poll
+		fail  []string
+		tasks []activiti.Task
+		want  []string
+	}
+	tests := []struct {
+		name   string
+		repeat time.Duration
+		polls  []poll
+	}{
+		{
+			name: "once",
+			polls: []poll{
+				{tasks: []activiti.Task{task}, want: []string{"assign t1 boss", "variables p1"}},
+				{after: time.Hour, tasks: []activiti.Task{task}},
+			},
+		},
+		{
+			name: "failed action retried alone",
+			polls: []poll{
+				{tasks: []activiti.Task{task}, fail: []string{"variables p1"}, want: []string{"assign t1 boss", "variables p1"}},
+				{after: time.Minute, tasks: []activiti.Task{task}, fail: []string{"variables p1"}, want: []string{"variables p1"}},
+				{after: 2 * time.Minute, tasks: []activiti.Task{task}, want: []string{"variables p1"}},
+				{after: 3 * time.Minute, tasks: []activiti.Task{task}},
+			},
+		},
+		{
+			name:   "repeat",
+			repeat: 30 * time.Minute,
+			polls: []poll{
+				{tasks: []activiti.Task{task}, want: []string{"assign t1 boss", "variables p1"}},
+				{after: 10 * time.Minute, tasks: []activiti.Task{task}},
+				{after: 30 * time.Minute, tasks: []activiti.Task{task}, want: []string{"assign t1 boss", "variables p1"}},
+			},
+		},
+		{
+			name: "completed task forgotten",
+			polls: []poll{
+				{tasks: []activiti.Task{task}, fail: []string{"assign t1 boss"}, want: []string{"assign t1 boss", "variables p1"}},
+				{after: time.Minute},
+				{after: 2 * time.Minute, tasks: []activiti.Task{task}, want: []string{"assign t1 boss", "variables p1"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			c := &fakeClient{calls: &calls}
+			r := rule
+			r.Repeat = tt.repeat
+			var at time.Time
+			m := NewMonitor(c, Options{Rules: []Rule{r}, Now: func() time.Time { return at }})
+
+			for i, p := range tt.polls {
+				at = now.Add(p.after)
+				c.tasks = p.tasks
+				c.fail = map[string]bool{}
+				for _, f := range p.fail {
+					c.fail[f] = true
+				}
+				calls = nil
+				vs, err := m.Poll(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(calls, p.want) {
+					t.Errorf("poll %d: actions %q, want %q", i, calls, p.want)
+				}
+				if len(vs) != 0 != (len(p.want) != 0) {
+					t.Errorf("poll %d: %d violations for actions %q", i, len(vs), p.want)
+				}
+			}
+		})
+	}
+}
+
+// contextClient is a fakeClient recording the context of WithContext
+type contextClient struct {
+	*fakeClient
+	ctx context.Context
+}
+
+func (c *contextClient) WithContext(ctx context.Context) Client {
+	return &contextClient{fakeClient: c.fakeClient, ctx: ctx}
+}
+
+func (c *contextClient) TaskActionAssign(tid string, assignee string) error {
+	if c.ctx == nil {
+		return errors.New("request without context ")
+	}
+	return c.fakeClient.TaskActionAssign(tid, assignee)
+}
+
+func TestMonitorPollWithContext(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	var calls []string
+	c := &contextClient{fakeClient: &fakeClient{calls: &calls, tasks: []activiti.Task{{ID: "t1", CreatedDate: "2026-01-10T06:00:00Z"}}}}
+	m := NewMonitor(c, Options{Rules: []Rule{{Name: "unclaimed", MaxUnclaimed: time.Hour, Reassign: "boss"}}, Now: func() time.Time { return now }})
+	if _, err := m.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"assign t1 boss"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("actions %q, want %q", calls, want)
+	}
+
+	// The requests of an ActClient are cancelled with ctx
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	ac, _ := activiti.NewClient("token", srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewMonitor(ac, Options{}).Poll(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll was not cancelled")
+	}
+}
+
+func TestMonitorPollDuringActions(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	var calls []string
+	c := &fakeClient{calls: &calls, tasks: []activiti.Task{{ID: "t1", CreatedDate: "2026-01-10T06:00:00Z"}}}
+	notified, release := make(chan struct{}), make(chan struct{})
+	var notifications int
+	m := NewMonitor(c, Options{
+		Rules: []Rule{{Name: "unclaimed", MaxUnclaimed: time.Hour, Notify: true}},
+		Notifier: NotifierFunc(func(ctx context.Context, v Violation) error {
+			notifications++
+			notified <- struct{}{}
+			<-release
+			return nil
+		}),
+		Now: func() time.Time { return now },
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.Poll(context.Background())
+		done <- err
+	}()
+	<-notified
+
+	// The monitor is not locked while the notifier runs, and the violation being
+	// notified is not notified again
+	vs, err := m.Poll(context.Background())
+	if err != nil || len(vs) != 0 {
+		t.Errorf("concurrent poll = %v, %v, want no violations", vs, err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if vs, _ := m.Poll(context.Background()); len(vs) != 0 || notifications != 1 {
+		t.Errorf("%d notifications, then %d violations, want 1 and none", notifications, len(vs))
+	}
+}
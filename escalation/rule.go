@@ -0,0 +1,178 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// Breach is the limit of a rule exceeded by a task
+type Breach string
+
+const (
+	BreachUnclaimed Breach = "UNCLAIMED" // Unassigned for longer than Rule.MaxUnclaimed
+	BreachDuration  Breach = "DURATION"  // Open for longer than Rule.MaxDuration
+	BreachOverdue   Breach = "OVERDUE"   // Past its due date, with Rule.Overdue
+)
+
+type (
+	// Rule sets the limits of the tasks of a task definition and the actions taken when one is exceeded.
+	// Actions run in the order reassign, priority, variables, notify, Actions
+	Rule struct {
+		Name              string `json:"name" yaml:"name"`
+		TaskDefinitionKey string `json:"taskDefinitionKey,omitempty" yaml:"taskDefinitionKey,omitempty"` // Every task when empty
+
+		MaxUnclaimed time.Duration `json:"maxUnclaimed,omitempty" yaml:"maxUnclaimed,omitempty"` // Since creation, without an assignee
+		MaxDuration  time.Duration `json:"maxDuration,omitempty" yaml:"maxDuration,omitempty"`   // Since creation, until completion
+		Overdue      bool          `json:"overdue,omitempty" yaml:"overdue,omitempty"`           // Past the task due date
+
+		// Repeat runs the actions again while the task stays in breach, only once when 0
+		Repeat time.Duration `json:"repeat,omitempty" yaml:"repeat,omitempty"`
+
+		Reassign  string                 `json:"reassign,omitempty" yaml:"reassign,omitempty"`   // Assign the task to this user
+		Priority  int                    `json:"priority,omitempty" yaml:"priority,omitempty"`   // Raise the task priority to at least this value
+		Variables map[string]interface{} `json:"variables,omitempty" yaml:"variables,omitempty"` // Set these process variables
+		Notify    bool                   `json:"notify,omitempty" yaml:"notify,omitempty"`       // Call Options.Notifier
+		Actions   []Action               `json:"-" yaml:"-"`
+	}
+
+	// Violation is a task exceeding a limit of a rule
+	Violation struct {
+		Rule   *Rule
+		Breach Breach
+		Task   activiti.Task
+		Since  time.Time     // Start of the measured time, the creation or due date
+		Over   time.Duration // Time beyond the limit
+	}
+
+	// Client is the part of ActClient used by the monitor. A poll makes the requests with
+	// its ctx when the client is an *activiti.ActClient or a ContextClient, otherwise ctx
+	// is only checked between requests
+	Client interface {
+		QueryTasks(params url.Values) (*activiti.ActListTasks, error)
+		TaskActionAssign(tid string, assignee string) error
+		UpdateTask(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error)
+		SetProcessVariables(pid string, variables map[string]interface{}) error
+	}
+
+	// ContextClient is a Client whose requests can be made with a context
+	ContextClient interface {
+		Client
+		WithContext(ctx context.Context) Client
+	}
+
+	// Action is taken on a violation
+	Action interface {
+		Apply(ctx context.Context, c Client, v Violation) error
+	}
+
+	// ActionFunc adapts a function to Action
+	ActionFunc func(ctx context.Context, c Client, v Violation) error
+
+	// Notifier is told about violations, for example by mail or chat
+	Notifier interface {
+		Notify(ctx context.Context, v Violation) error
+	}
+
+	// NotifierFunc adapts a function to Notifier
+	NotifierFunc func(ctx context.Context, v Violation) error
+)
+
+// Apply calls f
+func (f ActionFunc) Apply(ctx context.Context, c Client, v Violation) error {
+	return f(ctx, c, v)
+}
+
+// Notify calls f
+func (f NotifierFunc) Notify(ctx context.Context, v Violation) error {
+	return f(ctx, v)
+}
+
+// String describes the violation for logs and notifications
+func (v Violation) String() string {
+	return fmt.Sprintf("task %s (%s) of rule %s is %s by %s", v.Task.ID, v.Task.Name, v.Rule.Name, v.Breach, v.Over.Round(time.Second))
+}
+
+// Reassign assigns the task to assignee
+func Reassign(assignee string) Action {
+	return ActionFunc(func(ctx context.Context, c Client, v Violation) error {
+		if v.Task.Assignee == assignee {
+			return nil
+		}
+		return c.TaskActionAssign(v.Task.ID, assignee)
+	})
+}
+
+// RaisePriority raises the task priority to priority, lower priorities are left unchanged
+func RaisePriority(priority int) Action {
+	return ActionFunc(func(ctx context.Context, c Client, v Violation) error {
+		if v.Task.Priority >= priority {
+			return nil
+		}
+		_, err := c.UpdateTask(v.Task.ID, activiti.ActUpdateTask{Priority: priority})
+		return err
+	})
+}
+
+// SetVariables sets process variables of the task process instance
+func SetVariables(variables map[string]interface{}) Action {
+	return ActionFunc(func(ctx context.Context, c Client, v Violation) error {
+		if v.Task.ProcessInstanceId == "" {
+			return errors.New("standalone task has no process variables ")
+		}
+		return c.SetProcessVariables(v.Task.ProcessInstanceId, variables)
+	})
+}
+
+// Notify calls n
+func Notify(n Notifier) Action {
+	return ActionFunc(func(ctx context.Context, c Client, v Violation) error {
+		return n.Notify(ctx, v)
+	})
+}
+
+// actions returns the actions of the rule, n is the notifier of the monitor
+func (r *Rule) actions(n Notifier) []Action {
+	var actions []Action
+	if r.Reassign != "" {
+		actions = append(actions, Reassign(r.Reassign))
+	}
+	if r.Priority > 0 {
+		actions = append(actions, RaisePriority(r.Priority))
+	}
+	if len(r.Variables) > 0 {
+		actions = append(actions, SetVariables(r.Variables))
+	}
+	if r.Notify && n != nil {
+		actions = append(actions, Notify(n))
+	}
+	return append(actions, r.Actions...)
+}
+
+// check returns the violations of t at now
+func (r *Rule) check(t activiti.Task, now time.Time) []Violation {
+	if r.TaskDefinitionKey != "" && r.TaskDefinitionKey != t.TaskDefinitionKey {
+		return nil
+	}
+
+	var vs []Violation
+	if created, err := activiti.ParseDate(t.CreatedDate); err == nil {
+		age := now.Sub(created)
+		if r.MaxUnclaimed > 0 && t.Assignee == "" && age > r.MaxUnclaimed {
+			vs = append(vs, Violation{Rule: r, Breach: BreachUnclaimed, Task: t, Since: created, Over: age - r.MaxUnclaimed})
+		}
+		if r.MaxDuration > 0 && age > r.MaxDuration {
+			vs = append(vs, Violation{Rule: r, Breach: BreachDuration, Task: t, Since: created, Over: age - r.MaxDuration})
+		}
+	}
+	if r.Overdue && t.DueDate != "" {
+		if due, err := activiti.ParseDate(t.DueDate); err == nil && now.After(due) {
+			vs = append(vs, Violation{Rule: r, Breach: BreachOverdue, Task: t, Since: due, Over: now.Sub(due)})
+		}
+	}
+	return vs
+}
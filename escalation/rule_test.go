@@ -0,0 +1,102 @@
+package escalation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+func TestRuleCheck(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	created := "2026-01-10T06:00:00.000+0000" // 6h before now
+	tests := []struct {
+		name string
+		rule Rule
+		task activiti.Task
+		want map[Breach]time.Duration // Over by breach
+	}{
+		{"unclaimed", Rule{MaxUnclaimed: 4 * time.Hour}, activiti.Task{CreatedDate: created}, map[Breach]time.Duration{BreachUnclaimed: 2 * time.Hour}},
+		{"claimed", Rule{MaxUnclaimed: 4 * time.Hour}, activiti.Task{CreatedDate: created, Assignee: "bob"}, map[Breach]time.Duration{}},
+		{"within limit", Rule{MaxUnclaimed: 8 * time.Hour, MaxDuration: 8 * time.Hour}, activiti.Task{CreatedDate: created}, map[Breach]time.Duration{}},
+		{"duration", Rule{MaxDuration: 5 * time.Hour}, activiti.Task{CreatedDate: created, Assignee: "bob"}, map[Breach]time.Duration{BreachDuration: time.Hour}},
+		{
+			"unclaimed and duration",
+			Rule{MaxUnclaimed: 4 * time.Hour, MaxDuration: 5 * time.Hour},
+			activiti.Task{CreatedDate: created},
+			map[Breach]time.Duration{BreachUnclaimed: 2 * time.Hour, BreachDuration: time.Hour},
+		},
+		{"overdue", Rule{Overdue: true}, activiti.Task{DueDate: "2026-01-10T11:30:00Z"}, map[Breach]time.Duration{BreachOverdue: 30 * time.Minute}},
+		{"not due yet", Rule{Overdue: true}, activiti.Task{DueDate: "2026-01-10T12:30:00Z"}, map[Breach]time.Duration{}},
+		{"overdue disabled", Rule{}, activiti.Task{DueDate: "2026-01-10T11:30:00Z"}, map[Breach]time.Duration{}},
+		{"invalid created date", Rule{MaxUnclaimed: time.Minute}, activiti.Task{CreatedDate: "yesterday"}, map[Breach]time.Duration{}},
+		{"other task definition", Rule{TaskDefinitionKey: "approve", MaxUnclaimed: time.Minute}, activiti.Task{TaskDefinitionKey: "review", CreatedDate: created}, map[Breach]time.Duration{}},
+		{"task definition", Rule{TaskDefinitionKey: "approve", MaxUnclaimed: 4 * time.Hour}, activiti.Task{TaskDefinitionKey: "approve", CreatedDate: created}, map[Breach]time.Duration{BreachUnclaimed: 2 * time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[Breach]time.Duration{}
+			for _, v := range tt.rule.check(tt.task, now) {
+				if v.Rule != &tt.rule {
+					t.Errorf("violation of another rule")
+				}
+				got[v.Breach] = v.Over
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleActions(t *testing.T) {
+	var calls []string
+	c := &fakeClient{calls: &calls}
+	n := NotifierFunc(func(ctx context.Context, v Violation) error {
+		calls = append(calls, "notify")
+		return nil
+	})
+	custom := ActionFunc(func(ctx context.Context, c Client, v Violation) error {
+		calls = append(calls, "custom")
+		return nil
+	})
+	tests := []struct {
+		name string
+		rule Rule
+		task activiti.Task
+		want []string
+	}{
+		{"none", Rule{}, activiti.Task{ID: "t1"}, nil},
+		{
+			"all in order",
+			Rule{Reassign: "boss", Priority: 80, Variables: map[string]interface{}{"escalated": true}, Notify: true, Actions: []Action{custom}},
+			activiti.Task{ID: "t1", ProcessInstanceId: "p1", Priority: 50},
+			[]string{"assign t1 boss", "priority t1 80", "variables p1", "notify", "custom"},
+		},
+		{"already assigned", Rule{Reassign: "boss"}, activiti.Task{ID: "t1", Assignee: "boss"}, nil},
+		{"priority already higher", Rule{Priority: 80}, activiti.Task{ID: "t1", Priority: 90}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			v := Violation{Rule: &tt.rule, Task: tt.task}
+			for _, a := range tt.rule.actions(n) {
+				if err := a.Apply(context.Background(), c, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Errorf("got %q, want %q", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetVariablesStandaloneTask(t *testing.T) {
+	v := Violation{Task: activiti.Task{ID: "t1"}}
+	if err := SetVariables(map[string]interface{}{"a": 1}).Apply(context.Background(), &fakeClient{}, v); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// FromChange converts a change reported by activiti.Watcher to the runtime event
+// with the same meaning, so handlers can be fed by polling where no broker is reachable
+func FromChange(ch activiti.Change) RuntimeEvent {
+	switch {
+	case ch.Task != nil:
+		t := ch.Task
+		e := Event{
+			EventType:           TaskUpdated,
+			EntityID:            t.ID,
+			AppName:             t.AppName,
+			ServiceName:         t.ServiceName,
+			ServiceFullName:     t.ServiceFullName,
+			ServiceType:         t.ServiceType,
+			ServiceVersion:      t.ServiceVersion,
+			ProcessInstanceID:   t.ProcessInstanceId,
+			ProcessDefinitionID: t.ProcessDefinitionId,
+			BusinessKey:         t.BusinessKey,
+		}
+		switch ch.Kind {
+		case activiti.ChangeCreated:
+			e.EventType = TaskCreated
+		case activiti.ChangeCompleted:
+			e.EventType = TaskCompleted
+			if t.Status == "CANCELLED" {
+				e.EventType = TaskCancelled
+			}
+		}
+		// Definition ids are '<key>:<version>:<uuid>'
+		if i := strings.Index(t.ProcessDefinitionId, ":"); i > 0 {
+			e.ProcessDefinitionKey = t.ProcessDefinitionId[:i]
+		}
+		e.Timestamp = timestamp(t.LastModified)
+		return &TaskEvent{Event: e, Task: *t}
+
+	case ch.ProcessInstance != nil:
+		pi := ch.ProcessInstance
+		e := Event{
+			EventType:                ProcessUpdated,
+			EntityID:                 pi.ID,
+			AppName:                  pi.AppName,
+			ServiceName:              pi.ServiceName,
+			ServiceFullName:          pi.ServiceFullName,
+			ServiceType:              pi.ServiceType,
+			ServiceVersion:           pi.ServiceVersion,
+			ProcessInstanceID:        pi.ID,
+			ProcessDefinitionID:      pi.ProcessDefinitionId,
+			ProcessDefinitionKey:     pi.ProcessDefinitionKey,
+			ProcessDefinitionVersion: pi.ProcessDefinitionVersion,
+			BusinessKey:              pi.BusinessKey,
+		}
+		switch ch.Kind {
+		case activiti.ChangeCreated:
+			e.EventType = ProcessStarted
+		case activiti.ChangeCompleted:
+			e.EventType = ProcessCompleted
+			if pi.Status == "CANCELLED" {
+				e.EventType = ProcessCancelled
+			}
+		}
+		e.Timestamp = timestamp(pi.LastModified)
+		return &ProcessEvent{Event: e, ProcessInstance: *pi}
+	}
+	return &GenericEvent{}
+}
+
+// timestamp returns a date of the REST API in milliseconds, 0 when it does not parse
+func timestamp(date string) int64 {
+	t, err := activiti.ParseDate(date)
+	if err != nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
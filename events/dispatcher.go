@@ -0,0 +1,201 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// AllEvents registers a handler for every event type
+const AllEvents EventType = ""
+
+type (
+	// Handler receives the decoded events it was registered for
+	Handler interface {
+		HandleEvent(ctx context.Context, ev RuntimeEvent) error
+	}
+
+	// HandlerFunc adapts a function to Handler
+	HandlerFunc func(ctx context.Context, ev RuntimeEvent) error
+
+	// Filter selects the events passed to a handler
+	Filter func(ev RuntimeEvent) bool
+
+	// Message is a broker message holding runtime events
+	Message struct {
+		Body []byte
+		// Ack acknowledges the message once every handler succeeded
+		Ack func() error
+		// Reject hands the message back, to be redelivered when requeue is true
+		Reject func(requeue bool) error
+	}
+
+	// Source delivers runtime event messages until ctx is done, then closes the channel
+	Source interface {
+		Messages(ctx context.Context) (<-chan Message, error)
+	}
+
+	// Dispatcher routes runtime events to handlers
+	Dispatcher struct {
+		// Logger receives a record per failed message, none when nil
+		Logger *slog.Logger
+
+		mu       sync.RWMutex
+		handlers []registration
+	}
+
+	registration struct {
+		eventType EventType
+		handler   Handler
+		filters   []Filter
+	}
+)
+
+// HandleEvent implements Handler
+func (fn HandlerFunc) HandleEvent(ctx context.Context, ev RuntimeEvent) error {
+	return fn(ctx, ev)
+}
+
+// ProcessDefinitionKey passes events of process instances of the keys
+func ProcessDefinitionKey(keys ...string) Filter {
+	return func(ev RuntimeEvent) bool {
+		key := ev.Envelope().ProcessDefinitionKey
+		for _, k := range keys {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewDispatcher returns a dispatcher without handlers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Handle registers a handler for an event type, or AllEvents, receiving the events passing every filter
+func (d *Dispatcher) Handle(t EventType, h Handler, filters ...Filter) {
+	d.mu.Lock()
+	d.handlers = append(d.handlers, registration{eventType: t, handler: h, filters: filters})
+	d.mu.Unlock()
+}
+
+// HandleFunc registers a function for an event type
+func (d *Dispatcher) HandleFunc(t EventType, fn func(ctx context.Context, ev RuntimeEvent) error, filters ...Filter) {
+	d.Handle(t, HandlerFunc(fn), filters...)
+}
+
+// OnProcess registers a function for a process event type, or AllEvents for all of them
+func (d *Dispatcher) OnProcess(t EventType, fn func(ctx context.Context, ev *ProcessEvent) error, filters ...Filter) {
+	d.Handle(t, HandlerFunc(func(ctx context.Context, ev RuntimeEvent) error {
+		if pe, ok := ev.(*ProcessEvent); ok {
+			return fn(ctx, pe)
+		}
+		return nil
+	}), filters...)
+}
+
+// OnTask registers a function for a task event type, or AllEvents for all of them
+func (d *Dispatcher) OnTask(t EventType, fn func(ctx context.Context, ev *TaskEvent) error, filters ...Filter) {
+	d.Handle(t, HandlerFunc(func(ctx context.Context, ev RuntimeEvent) error {
+		if te, ok := ev.(*TaskEvent); ok {
+			return fn(ctx, te)
+		}
+		return nil
+	}), filters...)
+}
+
+// OnVariable registers a function for a variable event type, or AllEvents for all of them
+func (d *Dispatcher) OnVariable(t EventType, fn func(ctx context.Context, ev *VariableEvent) error, filters ...Filter) {
+	d.Handle(t, HandlerFunc(func(ctx context.Context, ev RuntimeEvent) error {
+		if ve, ok := ev.(*VariableEvent); ok {
+			return fn(ctx, ve)
+		}
+		return nil
+	}), filters...)
+}
+
+// Dispatch passes an event to its handlers in registration order and returns their errors
+func (d *Dispatcher) Dispatch(ctx context.Context, ev RuntimeEvent) error {
+	d.mu.RLock()
+	handlers := d.handlers
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, r := range handlers {
+		if !r.accepts(ev) {
+			continue
+		}
+		if err := r.handler.HandleEvent(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run dispatches the events of the source until ctx is done or the source closes.
+// A message is acknowledged when every handler succeeded and requeued otherwise, so
+// handlers must tolerate seeing an event twice. Undecodable messages are rejected
+func (d *Dispatcher) Run(ctx context.Context, src Source) error {
+	msgs, err := src.Messages(ctx)
+	if err != nil {
+		return err
+	}
+	for m := range msgs {
+		d.deliver(ctx, m)
+	}
+	return ctx.Err()
+}
+
+// deliver dispatches the events of a message and settles it
+func (d *Dispatcher) deliver(ctx context.Context, m Message) {
+	evs, err := Decode(m.Body)
+	if err != nil {
+		d.logError(ctx, "undecodable runtime events", err)
+		if m.Reject != nil {
+			m.Reject(false)
+		}
+		return
+	}
+
+	var errs []error
+	for _, ev := range evs {
+		if err := d.Dispatch(ctx, ev); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", ev.Envelope().EventType, ev.Envelope().EntityID, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		d.logError(ctx, "runtime event handler failed", err)
+		if m.Reject != nil {
+			m.Reject(true)
+		}
+		return
+	}
+	if m.Ack != nil {
+		if err := m.Ack(); err != nil {
+			d.logError(ctx, "ack failed", err)
+		}
+	}
+}
+
+func (d *Dispatcher) logError(ctx context.Context, msg string, err error) {
+	if d.Logger != nil {
+		d.Logger.LogAttrs(ctx, slog.LevelWarn, msg, slog.String("error", err.Error()))
+	}
+}
+
+// accepts reports whether the registration receives the event
+func (r registration) accepts(ev RuntimeEvent) bool {
+	if r.eventType != AllEvents && r.eventType != ev.Envelope().EventType {
+		return false
+	}
+	for _, f := range r.filters {
+		if !f(ev) {
+			return false
+		}
+	}
+	return true
+}
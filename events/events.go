@@ -0,0 +1,217 @@
+// Package events decodes Activiti Cloud runtime events and dispatches them to handlers
+// registered by event type, instead of polling the REST API for changes.
+//
+//	d := events.NewDispatcher()
+//	d.OnTask(events.TaskCreated, func(ctx context.Context, e *events.TaskEvent) error {
+//		...
+//	}, events.ProcessDefinitionKey("leave"))
+//	err := d.Run(ctx, source)
+//
+// Runtime bundles publish the events to the engineEvents destination, see
+// amqptransport.Transport.EventSource.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// EventType is the eventType of a runtime event
+type EventType string
+
+const (
+	ProcessCreated   EventType = "PROCESS_CREATED"
+	ProcessStarted   EventType = "PROCESS_STARTED"
+	ProcessCompleted EventType = "PROCESS_COMPLETED"
+	ProcessCancelled EventType = "PROCESS_CANCELLED"
+	ProcessSuspended EventType = "PROCESS_SUSPENDED"
+	ProcessResumed   EventType = "PROCESS_RESUMED"
+	ProcessUpdated   EventType = "PROCESS_UPDATED"
+	ProcessDeleted   EventType = "PROCESS_DELETED"
+	ProcessDeployed  EventType = "PROCESS_DEPLOYED"
+
+	TaskCreated   EventType = "TASK_CREATED"
+	TaskAssigned  EventType = "TASK_ASSIGNED"
+	TaskCompleted EventType = "TASK_COMPLETED"
+	TaskUpdated   EventType = "TASK_UPDATED"
+	TaskActivated EventType = "TASK_ACTIVATED"
+	TaskSuspended EventType = "TASK_SUSPENDED"
+	TaskCancelled EventType = "TASK_CANCELLED"
+
+	VariableCreated EventType = "VARIABLE_CREATED"
+	VariableUpdated EventType = "VARIABLE_UPDATED"
+	VariableDeleted EventType = "VARIABLE_DELETED"
+)
+
+type (
+	// RuntimeEvent is a decoded event: a *ProcessEvent, *ProcessDeployedEvent, *TaskEvent,
+	// *VariableEvent or, for the other event types, a *GenericEvent
+	RuntimeEvent interface {
+		Envelope() *Event
+	}
+
+	// Event holds the fields common to all runtime events
+	Event struct {
+		ID                       string          `json:"id,omitempty"`
+		EventType                EventType       `json:"eventType,omitempty"`
+		Timestamp                int64           `json:"timestamp,omitempty"` // Milliseconds since the epoch
+		EntityID                 string          `json:"entityId,omitempty"`
+		AppName                  string          `json:"appName,omitempty"`
+		AppVersion               string          `json:"appVersion,omitempty"`
+		ServiceName              string          `json:"serviceName,omitempty"`
+		ServiceFullName          string          `json:"serviceFullName,omitempty"`
+		ServiceType              string          `json:"serviceType,omitempty"`
+		ServiceVersion           string          `json:"serviceVersion,omitempty"`
+		ProcessInstanceID        string          `json:"processInstanceId,omitempty"`
+		ParentProcessInstanceID  string          `json:"parentProcessInstanceId,omitempty"`
+		ProcessDefinitionID      string          `json:"processDefinitionId,omitempty"`
+		ProcessDefinitionKey     string          `json:"processDefinitionKey,omitempty"`
+		ProcessDefinitionVersion int             `json:"processDefinitionVersion,omitempty"`
+		BusinessKey              string          `json:"businessKey,omitempty"`
+		MessageID                string          `json:"messageId,omitempty"`
+		SequenceNumber           int             `json:"sequenceNumber,omitempty"`
+		Entity                   json.RawMessage `json:"entity,omitempty"`
+	}
+
+	ProcessEvent struct {
+		Event
+		ProcessInstance activiti.ProcessInstance
+	}
+
+	// ProcessDeployedEvent is raised for each process definition of a deployment
+	ProcessDeployedEvent struct {
+		Event
+		ProcessDefinition activiti.ProcessDefinition
+	}
+
+	TaskEvent struct {
+		Event
+		Task activiti.Task
+	}
+
+	VariableEvent struct {
+		Event
+		Variable      Variable
+		PreviousValue interface{} // With VARIABLE_UPDATED
+	}
+
+	// GenericEvent is an event without a typed entity, such as ACTIVITY_STARTED
+	GenericEvent struct {
+		Event
+	}
+
+	// Variable is the entity of variable events
+	Variable struct {
+		Name              string      `json:"name,omitempty"`
+		Type              string      `json:"type,omitempty"`
+		Value             interface{} `json:"value,omitempty"`
+		ProcessInstanceID string      `json:"processInstanceId,omitempty"`
+		TaskID            string      `json:"taskId,omitempty"`
+		TaskVariable      bool        `json:"taskVariable,omitempty"`
+	}
+)
+
+// Envelope implements RuntimeEvent
+func (e *Event) Envelope() *Event {
+	return e
+}
+
+// Time returns the time the event was raised
+func (e *Event) Time() time.Time {
+	return time.UnixMilli(e.Timestamp)
+}
+
+// Decode decodes a message of the runtime bundle, which holds an array of events or a single event
+func Decode(body []byte) ([]RuntimeEvent, error) {
+	body = bytes.TrimSpace(body)
+	var raws []json.RawMessage
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &raws); err != nil {
+			return nil, fmt.Errorf("invalid runtime events: %v", err)
+		}
+	} else {
+		raws = []json.RawMessage{body}
+	}
+
+	evs := make([]RuntimeEvent, 0, len(raws))
+	for _, raw := range raws {
+		ev, err := DecodeEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		evs = append(evs, ev)
+	}
+	return evs, nil
+}
+
+// DecodeEvent decodes one runtime event
+func DecodeEvent(raw []byte) (RuntimeEvent, error) {
+	var e Event
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, fmt.Errorf("invalid runtime event: %v", err)
+	}
+
+	var (
+		ev     RuntimeEvent
+		entity interface{}
+	)
+	switch {
+	case e.EventType == ProcessDeployed:
+		de := &ProcessDeployedEvent{Event: e}
+		ev, entity = de, &de.ProcessDefinition
+	case strings.HasPrefix(string(e.EventType), "PROCESS_"):
+		pe := &ProcessEvent{Event: e}
+		ev, entity = pe, &pe.ProcessInstance
+	case strings.HasPrefix(string(e.EventType), "TASK_") && !strings.HasPrefix(string(e.EventType), "TASK_CANDIDATE_"):
+		te := &TaskEvent{Event: e}
+		ev, entity = te, &te.Task
+	case strings.HasPrefix(string(e.EventType), "VARIABLE_"):
+		ve := &VariableEvent{Event: e}
+		var prev struct {
+			PreviousValue interface{} `json:"previousValue"`
+		}
+		if err := json.Unmarshal(raw, &prev); err == nil {
+			ve.PreviousValue = prev.PreviousValue
+		}
+		ev, entity = ve, &ve.Variable
+	default:
+		return &GenericEvent{Event: e}, nil
+	}
+
+	if len(e.Entity) > 0 && string(e.Entity) != "null" {
+		if err := json.Unmarshal(normalizeDates(e.Entity), entity); err != nil {
+			return nil, fmt.Errorf("invalid %s entity: %v", e.EventType, err)
+		}
+	}
+	return ev, nil
+}
+
+// normalizeDates rewrites date fields sent as epoch milliseconds to RFC 3339 strings,
+// the format of the REST API the entity types are shaped after
+func normalizeDates(entity json.RawMessage) json.RawMessage {
+	var fields map[string]interface{}
+	if json.Unmarshal(entity, &fields) != nil {
+		return entity
+	}
+	changed := false
+	for k, v := range fields {
+		ms, ok := v.(float64)
+		if ok && (strings.HasSuffix(k, "Date") || k == "lastModified") {
+			fields[k] = time.UnixMilli(int64(ms)).UTC().Format(time.RFC3339Nano)
+			changed = true
+		}
+	}
+	if !changed {
+		return entity
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return entity
+	}
+	return data
+}
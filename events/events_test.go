@@ -0,0 +1,164 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		check func(t *testing.T, ev RuntimeEvent)
+	}{
+		{
+			name: "process",
+			raw:  `{"eventType":"PROCESS_STARTED","entityId":"pi-1","processDefinitionKey":"leave","entity":{"id":"pi-1","startDate":1700000000000}}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				pe, ok := ev.(*ProcessEvent)
+				if !ok {
+					t.Fatalf("got %T, want *ProcessEvent", ev)
+				}
+				if pe.ProcessInstance.ID != "pi-1" || pe.ProcessDefinitionKey != "leave" {
+					t.Errorf("got %+v", pe)
+				}
+				if pe.ProcessInstance.StartDate != "2023-11-14T22:13:20Z" {
+					t.Errorf("startDate = %q, want the epoch milliseconds as RFC 3339", pe.ProcessInstance.StartDate)
+				}
+			},
+		},
+		{
+			name: "process deployed",
+			raw:  `{"eventType":"PROCESS_DEPLOYED","entityId":"leave:2:abc","entity":{"id":"leave:2:abc","key":"leave","version":2}}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				de, ok := ev.(*ProcessDeployedEvent)
+				if !ok {
+					t.Fatalf("got %T, want *ProcessDeployedEvent", ev)
+				}
+				if de.ProcessDefinition.Key != "leave" || de.ProcessDefinition.Version != 2 {
+					t.Errorf("got %+v", de.ProcessDefinition)
+				}
+			},
+		},
+		{
+			name: "task",
+			raw:  `{"eventType":"TASK_CREATED","entityId":"t-1","entity":{"id":"t-1","name":"approve","createdDate":"2024-01-02T03:04:05.000+0000"}}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				te, ok := ev.(*TaskEvent)
+				if !ok {
+					t.Fatalf("got %T, want *TaskEvent", ev)
+				}
+				if te.Task.Name != "approve" || te.Task.CreatedDate != "2024-01-02T03:04:05.000+0000" {
+					t.Errorf("got %+v", te.Task)
+				}
+			},
+		},
+		{
+			name: "task candidate is generic",
+			raw:  `{"eventType":"TASK_CANDIDATE_USER_ADDED","entityId":"t-1","entity":{"userId":"bob"}}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				if _, ok := ev.(*GenericEvent); !ok {
+					t.Fatalf("got %T, want *GenericEvent", ev)
+				}
+			},
+		},
+		{
+			name: "variable updated",
+			raw:  `{"eventType":"VARIABLE_UPDATED","entity":{"name":"days","type":"integer","value":3,"processInstanceId":"pi-1"},"previousValue":2}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				ve, ok := ev.(*VariableEvent)
+				if !ok {
+					t.Fatalf("got %T, want *VariableEvent", ev)
+				}
+				if ve.Variable.Name != "days" || ve.Variable.Value != 3.0 || ve.PreviousValue != 2.0 {
+					t.Errorf("got %+v, previous %v", ve.Variable, ve.PreviousValue)
+				}
+			},
+		},
+		{
+			name: "activity is generic",
+			raw:  `{"eventType":"ACTIVITY_STARTED","entityId":"a-1","timestamp":1700000000000}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				ge, ok := ev.(*GenericEvent)
+				if !ok {
+					t.Fatalf("got %T, want *GenericEvent", ev)
+				}
+				if ge.Time().UnixMilli() != 1700000000000 {
+					t.Errorf("time = %v", ge.Time())
+				}
+			},
+		},
+		{
+			name: "null entity",
+			raw:  `{"eventType":"PROCESS_DELETED","entityId":"pi-1","entity":null}`,
+			check: func(t *testing.T, ev RuntimeEvent) {
+				pe, ok := ev.(*ProcessEvent)
+				if !ok {
+					t.Fatalf("got %T, want *ProcessEvent", ev)
+				}
+				if pe.EntityID != "pi-1" || pe.ProcessInstance.ID != "" {
+					t.Errorf("got %+v", pe)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := DecodeEvent([]byte(tt.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, ev)
+		})
+	}
+}
+
+func TestDecodeEventErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"not json", `{`},
+		{"entity of the wrong shape", `{"eventType":"TASK_CREATED","entity":["t-1"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeEvent([]byte(tt.raw)); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []EventType
+		wantErr bool
+	}{
+		{"single", `{"eventType":"PROCESS_STARTED"}`, []EventType{ProcessStarted}, false},
+		{"array", ` [{"eventType":"PROCESS_STARTED"},{"eventType":"TASK_CREATED"}] `, []EventType{ProcessStarted, TaskCreated}, false},
+		{"empty array", `[]`, []EventType{}, false},
+		{"invalid array", `[{"eventType":"PROCESS_STARTED"},`, nil, true},
+		{"invalid element", `[{"eventType":"TASK_CREATED","entity":1}]`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evs, err := Decode([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := []EventType{}
+			for _, ev := range evs {
+				got = append(got, ev.Envelope().EventType)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package activiti
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type (
+	// ProcessExtensions is the extension model of one process, the '<process>-extensions.json'
+	// file deployed next to the BPMN by the Activiti Cloud modeler
+	ProcessExtensions struct {
+		// Properties are the process variables declared in the modeler, by property id
+		Properties map[string]ExtensionProperty `json:"properties,omitempty"`
+		// Mappings are the variable mappings of tasks, by BPMN element id
+		Mappings map[string]ExtensionMapping `json:"mappings,omitempty"`
+		// Constants are the constant values of tasks, such as a form key, by BPMN element id
+		Constants map[string]map[string]ExtensionConstant `json:"constants,omitempty"`
+	}
+
+	ExtensionProperty struct {
+		ID       string      `json:"id,omitempty"`
+		Name     string      `json:"name,omitempty"`
+		Type     string      `json:"type,omitempty"`
+		Required bool        `json:"required,omitempty"`
+		Value    interface{} `json:"value,omitempty"` // Default value
+	}
+
+	ExtensionMapping struct {
+		MappingType string                  `json:"mappingType,omitempty"` // For example MAP_ALL
+		Inputs      map[string]MappingValue `json:"inputs,omitempty"`      // By task variable name
+		Outputs     map[string]MappingValue `json:"outputs,omitempty"`     // By process variable name
+	}
+
+	MappingValue struct {
+		Type  string      `json:"type,omitempty"` // variable, value or static_value
+		Value interface{} `json:"value,omitempty"`
+	}
+
+	ExtensionConstant struct {
+		Value interface{} `json:"value,omitempty"`
+	}
+
+	// ProcessExtensionsFile is a decoded extensions file. Recent modelers key the
+	// extensions by process id, older ones have a single process
+	ProcessExtensionsFile struct {
+		ID         string                       `json:"id,omitempty"`
+		Name       string                       `json:"name,omitempty"`
+		Extensions map[string]ProcessExtensions `json:"extensions,omitempty"`
+	}
+
+	// FormField describes one input of a generated start form
+	FormField struct {
+		Name     string      `json:"name"`
+		Type     string      `json:"type,omitempty"`
+		Required bool        `json:"required,omitempty"`
+		Default  interface{} `json:"default,omitempty"`
+	}
+)
+
+// DecodeProcessExtensions reads an extensions file in either layout
+func DecodeProcessExtensions(r io.Reader) (*ProcessExtensionsFile, error) {
+	var raw struct {
+		ID         string          `json:"id"`
+		Name       string          `json:"name"`
+		Extensions json.RawMessage `json:"extensions"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	f := &ProcessExtensionsFile{ID: raw.ID, Name: raw.Name, Extensions: map[string]ProcessExtensions{}}
+	if len(raw.Extensions) == 0 {
+		return f, nil
+	}
+
+	// A single process has properties, mappings or constants at the top level
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(raw.Extensions, &probe); err != nil {
+		return nil, fmt.Errorf("invalid extensions: %v", err)
+	}
+	_, hasProperties := probe["properties"]
+	_, hasMappings := probe["mappings"]
+	_, hasConstants := probe["constants"]
+	if hasProperties || hasMappings || hasConstants {
+		var ext ProcessExtensions
+		if err := json.Unmarshal(raw.Extensions, &ext); err != nil {
+			return nil, fmt.Errorf("invalid extensions: %v", err)
+		}
+		f.Extensions[raw.ID] = ext
+		return f, nil
+	}
+
+	if err := json.Unmarshal(raw.Extensions, &f.Extensions); err != nil {
+		return nil, fmt.Errorf("invalid extensions: %v", err)
+	}
+	return f, nil
+}
+
+// LoadProcessExtensions reads the extensions file at path
+func LoadProcessExtensions(path string) (*ProcessExtensionsFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DecodeProcessExtensions(f)
+}
+
+// Process returns the extensions of a process by its BPMN id, or of the only process of the file
+func (f *ProcessExtensionsFile) Process(processID string) (ProcessExtensions, bool) {
+	if ext, ok := f.Extensions[processID]; ok {
+		return ext, true
+	}
+	if len(f.Extensions) == 1 {
+		for _, ext := range f.Extensions {
+			return ext, true
+		}
+	}
+	return ProcessExtensions{}, false
+}
+
+// Constant returns a constant of a BPMN element, for example Constant("Task_1", "formKey")
+func (e ProcessExtensions) Constant(elementID, name string) (interface{}, bool) {
+	c, ok := e.Constants[elementID][name]
+	return c.Value, ok
+}
+
+// StartFormFields merges the variables declared in the definition metadata with the
+// extension properties, which add required flags and default values. Either may be nil
+func StartFormFields(meta *ProcessDefinitionMeta, ext *ProcessExtensions) []FormField {
+	byName := map[string]*FormField{}
+	var names []string
+	add := func(name string) *FormField {
+		if f, ok := byName[name]; ok {
+			return f
+		}
+		f := &FormField{Name: name}
+		byName[name] = f
+		names = append(names, name)
+		return f
+	}
+
+	if meta != nil {
+		for _, v := range meta.Variables {
+			add(v.Name).Type = v.Type
+		}
+	}
+	if ext != nil {
+		for _, p := range ext.Properties {
+			f := add(p.Name)
+			if p.Type != "" {
+				f.Type = p.Type
+			}
+			f.Required = p.Required
+			f.Default = p.Value
+		}
+	}
+
+	sort.Strings(names)
+	fields := make([]FormField, len(names))
+	for i, name := range names {
+		fields[i] = *byName[name]
+	}
+	return fields
+}
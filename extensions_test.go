@@ -0,0 +1,204 @@
+package activiti
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	// keyedExtensions is the layout of recent modelers, extensions by process id
+	keyedExtensions = `{
+  "id": "process-1",
+  "name": "leave",
+  "extensions": {
+    "Process_leave": {
+      "properties": {
+        "p1": {"id": "p1", "name": "days", "type": "integer", "required": true, "value": 1},
+        "p2": {"id": "p2", "name": "reason", "type": "string"}
+      },
+      "mappings": {
+        "Task_approve": {"outputs": {"approved": {"type": "variable", "value": "decision"}}}
+      },
+      "constants": {
+        "Task_approve": {"formKey": {"value": "approve-form"}}
+      }
+    },
+    "Process_other": {}
+  }
+}`
+
+	// singleExtensions is the layout of older modelers, one process at the top level
+	singleExtensions = `{
+  "id": "Process_leave",
+  "name": "leave",
+  "extensions": {
+    "properties": {
+      "p1": {"id": "p1", "name": "days", "type": "integer", "required": true}
+    },
+    "constants": {
+      "Task_approve": {"formKey": {"value": "approve-form"}}
+    }
+  }
+}`
+)
+
+func TestDecodeProcessExtensions(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		processes []string
+		wantErr   bool
+	}{
+		{"keyed by process", keyedExtensions, []string{"Process_leave", "Process_other"}, false},
+		{"single process", singleExtensions, []string{"Process_leave"}, false},
+		{"mappings only", `{"id":"P","extensions":{"mappings":{}}}`, []string{"P"}, false},
+		{"no extensions", `{"id":"P","name":"leave"}`, nil, false},
+		{"empty extensions", `{"id":"P","extensions":{}}`, nil, false},
+		{"not json", `{"id":`, nil, true},
+		{"extensions not an object", `{"id":"P","extensions":[1]}`, nil, true},
+		{"invalid single process", `{"id":"P","extensions":{"properties":[1]}}`, nil, true},
+		{"invalid process", `{"id":"P","extensions":{"Process_1":{"properties":"x"}}}`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := DecodeProcessExtensions(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []string
+			for id := range f.Extensions {
+				got = append(got, id)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.processes) {
+				t.Errorf("processes = %q, want %q", got, tt.processes)
+			}
+		})
+	}
+}
+
+func TestLoadProcessExtensions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leave-extensions.json")
+	if err := os.WriteFile(path, []byte(singleExtensions), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadProcessExtensions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ID != "Process_leave" || f.Name != "leave" || len(f.Extensions["Process_leave"].Properties) != 1 {
+		t.Errorf("got %+v", f)
+	}
+	if _, err := LoadProcessExtensions(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("missing file err = %v", err)
+	}
+}
+
+func TestProcessExtensionsLookup(t *testing.T) {
+	keyed, err := DecodeProcessExtensions(strings.NewReader(keyedExtensions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := DecodeProcessExtensions(strings.NewReader(singleExtensions))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		file      *ProcessExtensionsFile
+		processID string
+		found     bool
+		formKey   interface{}
+	}{
+		{"keyed by id", keyed, "Process_leave", true, "approve-form"},
+		{"keyed without constants", keyed, "Process_other", true, nil},
+		{"keyed unknown id", keyed, "Process_x", false, nil},
+		{"single by id", single, "Process_leave", true, "approve-form"},
+		// The only process of a file is returned whatever the id, older modelers used the model id
+		{"single other id", single, "Process_x", true, "approve-form"},
+		{"empty file", &ProcessExtensionsFile{}, "Process_leave", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, ok := tt.file.Process(tt.processID)
+			if ok != tt.found {
+				t.Fatalf("found = %v, want %v", ok, tt.found)
+			}
+			v, ok := ext.Constant("Task_approve", "formKey")
+			if ok != (tt.formKey != nil) || v != tt.formKey {
+				t.Errorf("formKey = %v %v, want %v", v, ok, tt.formKey)
+			}
+			if _, ok := ext.Constant("Task_approve", "missing"); ok {
+				t.Error("found a missing constant")
+			}
+		})
+	}
+}
+
+func TestStartFormFields(t *testing.T) {
+	keyed, err := DecodeProcessExtensions(strings.NewReader(keyedExtensions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := DecodeProcessExtensions(strings.NewReader(singleExtensions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyedExt, _ := keyed.Process("Process_leave")
+	singleExt, _ := single.Process("Process_leave")
+	meta := &ProcessDefinitionMeta{Variables: []ProcessDefinitionVariable{
+		{Name: "reason", Type: "string"},
+		{Name: "days", Type: "long"},
+		{Name: "comment", Type: "string"},
+	}}
+
+	tests := []struct {
+		name string
+		meta *ProcessDefinitionMeta
+		ext  *ProcessExtensions
+		want []FormField
+	}{
+		{"none", nil, nil, []FormField{}},
+		{"metadata only", meta, nil, []FormField{
+			{Name: "comment", Type: "string"},
+			{Name: "days", Type: "long"},
+			{Name: "reason", Type: "string"},
+		}},
+		{"keyed extensions only", nil, &keyedExt, []FormField{
+			{Name: "days", Type: "integer", Required: true, Default: 1.0},
+			{Name: "reason", Type: "string"},
+		}},
+		{"metadata and keyed extensions", meta, &keyedExt, []FormField{
+			{Name: "comment", Type: "string"},
+			{Name: "days", Type: "integer", Required: true, Default: 1.0},
+			{Name: "reason", Type: "string"},
+		}},
+		{"metadata and single extensions", meta, &singleExt, []FormField{
+			{Name: "comment", Type: "string"},
+			{Name: "days", Type: "integer", Required: true},
+			{Name: "reason", Type: "string"},
+		}},
+		{"property without type keeps the metadata type", meta, &ProcessExtensions{Properties: map[string]ExtensionProperty{
+			"p1": {Name: "comment", Value: "none"},
+		}}, []FormField{
+			{Name: "comment", Type: "string", Default: "none"},
+			{Name: "days", Type: "long"},
+			{Name: "reason", Type: "string"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StartFormFields(tt.meta, tt.ext); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package forms resolves the form of a user task by its FormKey, validates the submitted
+// values and completes the task with them.
+//
+//	src := forms.NewModelingSource(client, "https://host/modeling-service")
+//	form, task, err := forms.Resolve(ctx, src, client, taskID)
+//	...
+//	err = forms.Submit(ctx, src, client, taskID, values, forms.SubmitOptions{})
+//
+// Forms use the JSON layout of the Activiti Cloud modeler, either the form representation
+// itself or wrapped in a "formRepresentation" object.
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Field types of the Activiti Cloud modeler
+const (
+	FieldText            = "text"
+	FieldMultilineText   = "multi-line-text"
+	FieldInteger         = "integer"
+	FieldAmount          = "amount"
+	FieldBoolean         = "boolean"
+	FieldDate            = "date"
+	FieldDateTime        = "datetime"
+	FieldDropdown        = "dropdown"
+	FieldRadioButtons    = "radio-buttons"
+	FieldPeople          = "people"
+	FieldFunctionalGroup = "functional-group"
+	FieldUpload          = "upload"
+	FieldContainer       = "container"
+	FieldReadonlyText    = "readonly-text"
+	FieldDisplayValue    = "display-value"
+)
+
+// ErrNoForm is returned for tasks without a FormKey
+var ErrNoForm = errors.New("task has no form")
+
+type (
+	// Form is a form definition
+	Form struct {
+		ID          string     `json:"id,omitempty"`
+		Name        string     `json:"name,omitempty"`
+		Description string     `json:"description,omitempty"`
+		Fields      []Field    `json:"fields,omitempty"` // Input fields, containers are flattened
+		Outcomes    []Outcome  `json:"outcomes,omitempty"`
+		Variables   []Variable `json:"variables,omitempty"`
+	}
+
+	Field struct {
+		ID           string      `json:"id"`
+		Name         string      `json:"name,omitempty"`
+		Type         string      `json:"type,omitempty"`
+		Required     bool        `json:"required,omitempty"`
+		ReadOnly     bool        `json:"readOnly,omitempty"`
+		Value        interface{} `json:"value,omitempty"` // Default value
+		Placeholder  string      `json:"placeholder,omitempty"`
+		MinLength    int         `json:"minLength,omitempty"`
+		MaxLength    int         `json:"maxLength,omitempty"`
+		MinValue     string      `json:"minValue,omitempty"`
+		MaxValue     string      `json:"maxValue,omitempty"`
+		RegexPattern string      `json:"regexPattern,omitempty"`
+		Options      []Option    `json:"options,omitempty"`
+	}
+
+	Option struct {
+		ID   string `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	Outcome struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	}
+
+	Variable struct {
+		ID    string      `json:"id,omitempty"`
+		Name  string      `json:"name,omitempty"`
+		Type  string      `json:"type,omitempty"`
+		Value interface{} `json:"value,omitempty"`
+	}
+
+	// rawField is a field as stored by the modeler, containers hold their fields by column
+	rawField struct {
+		Field
+		Fields map[string][]rawField `json:"fields,omitempty"`
+	}
+
+	rawForm struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Description    string `json:"description"`
+		FormDefinition struct {
+			Fields    []rawField `json:"fields"`
+			Outcomes  []Outcome  `json:"outcomes"`
+			Variables []Variable `json:"variables"`
+		} `json:"formDefinition"`
+	}
+)
+
+// Decode reads a form definition
+func Decode(r io.Reader) (*Form, error) {
+	var doc struct {
+		rawForm
+		FormRepresentation *rawForm `json:"formRepresentation"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid form: %v", err)
+	}
+	raw := &doc.rawForm
+	if doc.FormRepresentation != nil {
+		raw = doc.FormRepresentation
+	}
+
+	f := &Form{
+		ID:          raw.ID,
+		Name:        raw.Name,
+		Description: raw.Description,
+		Outcomes:    raw.FormDefinition.Outcomes,
+		Variables:   raw.FormDefinition.Variables,
+	}
+	f.Fields = flatten(raw.FormDefinition.Fields, nil)
+	return f, nil
+}
+
+// flatten appends the fields of containers in column order
+func flatten(fields []rawField, out []Field) []Field {
+	for _, rf := range fields {
+		if len(rf.Fields) == 0 {
+			if rf.Type != FieldContainer {
+				out = append(out, rf.Field)
+			}
+			continue
+		}
+		columns := make([]string, 0, len(rf.Fields))
+		for col := range rf.Fields {
+			columns = append(columns, col)
+		}
+		sort.Slice(columns, func(i, j int) bool { return columnLess(columns[i], columns[j]) })
+		for _, col := range columns {
+			out = append(out, flatten(rf.Fields[col], nil)...)
+		}
+	}
+	return out
+}
+
+// columnLess orders container columns by number, "2" before "10",
+// columns which are not numbers come last in string order
+func columnLess(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil || errB == nil:
+		return errA == nil
+	}
+	return a < b
+}
+
+// Field returns the field with the id
+func (f *Form) Field(id string) (Field, bool) {
+	for _, fd := range f.Fields {
+		if fd.ID == id {
+			return fd, true
+		}
+	}
+	return Field{}, false
+}
+
+// Input reports whether the field takes a value from the user
+func (fd Field) Input() bool {
+	switch fd.Type {
+	case FieldContainer, FieldReadonlyText, FieldDisplayValue:
+		return false
+	}
+	return !fd.ReadOnly
+}
@@ -0,0 +1,61 @@
+package forms
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		ids  []string
+	}{
+		{"fields", `{"id":"f","formDefinition":{"fields":[{"id":"a","type":"text"},{"id":"b","type":"integer"}]}}`, []string{"a", "b"}},
+		{"form representation", `{"formRepresentation":{"id":"f","formDefinition":{"fields":[{"id":"a","type":"text"}]}}}`, []string{"a"}},
+		{"container columns", `{"formDefinition":{"fields":[{"id":"c","type":"container","fields":{
+			"10":[{"id":"j","type":"text"}],
+			"2":[{"id":"b","type":"text"}],
+			"1":[{"id":"a1","type":"text"},{"id":"a2","type":"text"}],
+			"x":[{"id":"x","type":"text"}]}}]}}`, []string{"a1", "a2", "b", "j", "x"}},
+		{"empty container", `{"formDefinition":{"fields":[{"id":"c","type":"container"},{"id":"a","type":"text"}]}}`, []string{"a"}},
+		{"nested containers", `{"formDefinition":{"fields":[{"id":"c","type":"container","fields":{
+			"1":[{"id":"inner","type":"container","fields":{"11":[{"id":"z","type":"text"}],"3":[{"id":"y","type":"text"}]}}],
+			"2":[{"id":"b","type":"text"}]}}]}}`, []string{"y", "z", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Decode(strings.NewReader(tt.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, fd := range f.Fields {
+				ids = append(ids, fd.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("fields = %v, want %v", ids, tt.ids)
+			}
+		})
+	}
+}
+
+func TestColumnLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"2", "10", true},
+		{"10", "2", false},
+		{"1", "x", true},
+		{"x", "1", false},
+		{"a", "b", true},
+	}
+	for _, tt := range tests {
+		if got := columnLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("columnLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
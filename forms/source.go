@@ -0,0 +1,83 @@
+package forms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+type (
+	// Source resolves a form definition by the FormKey of a task
+	Source interface {
+		Form(ctx context.Context, formKey string) (*Form, error)
+	}
+
+	// SourceFunc adapts a function to Source
+	SourceFunc func(ctx context.Context, formKey string) (*Form, error)
+
+	// ModelingSource reads forms from the Activiti Cloud modeling service, where
+	// the FormKey of a task is the id of the form model
+	ModelingSource struct {
+		Client *activiti.ActClient
+		URL    string // Modeling service url, for example https://host/modeling-service
+	}
+
+	// DirSource reads forms from '<FormKey>.json' or '<FormKey>.form' files in a directory
+	DirSource string
+)
+
+// Form implements Source
+func (fn SourceFunc) Form(ctx context.Context, formKey string) (*Form, error) {
+	return fn(ctx, formKey)
+}
+
+// NewModelingSource returns a Source reading form models through the client
+func NewModelingSource(client *activiti.ActClient, modelingURL string) *ModelingSource {
+	return &ModelingSource{Client: client, URL: strings.TrimRight(modelingURL, "/")}
+}
+
+// Form retrieves the content of the form model
+// Endpoint: GET modeling/v1/models/{modelId}/content
+func (s *ModelingSource) Form(ctx context.Context, formKey string) (*Form, error) {
+	if formKey == "" {
+		return nil, errors.New("Form key is required ")
+	}
+	req, err := s.Client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s%s%s", s.URL, "/v1/models/", formKey, "/content"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var content json.RawMessage
+	if err = s.Client.SendWithBasicAuth(req, &content); err != nil {
+		return nil, err
+	}
+	return Decode(bytes.NewReader(content))
+}
+
+// Form implements Source
+func (d DirSource) Form(ctx context.Context, formKey string) (*Form, error) {
+	if formKey == "" || strings.ContainsAny(formKey, `/\`) || formKey == "." || formKey == ".." {
+		return nil, fmt.Errorf("invalid form key %q", formKey)
+	}
+
+	for _, ext := range []string{".json", ".form"} {
+		f, err := os.Open(filepath.Join(string(d), formKey+ext))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		form, err := Decode(f)
+		f.Close()
+		return form, err
+	}
+	return nil, fmt.Errorf("form %s not found in %s", formKey, string(d))
+}
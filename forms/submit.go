@@ -0,0 +1,70 @@
+package forms
+
+import (
+	"context"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+type (
+	// Tasks is the part of ActClient used to resolve and complete tasks
+	Tasks interface {
+		GetTask(tid string) (*activiti.ActTask, error)
+		CompleteTask(tid string, variables map[string]interface{}) error
+	}
+
+	// SubmitOptions changes how values become task variables
+	SubmitOptions struct {
+		// Mapping renames field ids to variable names, fields not listed keep their id
+		Mapping map[string]string
+		// Variables are sent along with the form values, form values win on conflicts
+		Variables map[string]interface{}
+	}
+)
+
+// Resolve returns the task and its form. ErrNoForm is returned with the task when it has no FormKey
+func Resolve(ctx context.Context, src Source, tasks Tasks, taskID string) (*Form, *activiti.Task, error) {
+	tk, err := tasks.GetTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tk.Task.FormKey == "" {
+		return nil, &tk.Task, ErrNoForm
+	}
+
+	form, err := src.Form(ctx, tk.Task.FormKey)
+	if err != nil {
+		return nil, &tk.Task, err
+	}
+	return form, &tk.Task, nil
+}
+
+// Submit validates values, by field id, against the form of the task and completes
+// the task with them. Invalid values are returned as ValidationErrors and the task is left open
+func Submit(ctx context.Context, src Source, tasks Tasks, taskID string, values map[string]interface{}, opts SubmitOptions) error {
+	form, _, err := Resolve(ctx, src, tasks, taskID)
+	if err != nil {
+		return err
+	}
+
+	valid, err := form.Validate(values)
+	if err != nil {
+		return err
+	}
+	return tasks.CompleteTask(taskID, Variables(valid, opts))
+}
+
+// Variables maps validated form values to task variables
+func Variables(values map[string]interface{}, opts SubmitOptions) map[string]interface{} {
+	vars := make(map[string]interface{}, len(values)+len(opts.Variables))
+	for k, v := range opts.Variables {
+		vars[k] = v
+	}
+	for id, v := range values {
+		if name, ok := opts.Mapping[id]; ok && name != "" {
+			id = name
+		}
+		vars[id] = v
+	}
+	return vars
+}
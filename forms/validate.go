@@ -0,0 +1,238 @@
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+type (
+	// FieldError is a rejected form value
+	FieldError struct {
+		Field   string // Field id
+		Message string
+	}
+
+	// ValidationErrors lists every rejected value of a submission
+	ValidationErrors []FieldError
+)
+
+// Error implements error
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Error implements error
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "invalid form values: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks values, by field id, against the types and constraints of the form
+// fields and returns them converted to the variable types Activiti expects: int64 for
+// integer, float64 for amount, bool for boolean, "2006-01-02" strings for date, RFC 3339
+// strings for datetime and option ids for dropdown and radio-buttons.
+// Defaults fill missing values, values of unknown or read only fields are rejected.
+// The error is a ValidationErrors
+func (f *Form) Validate(values map[string]interface{}) (map[string]interface{}, error) {
+	var errs ValidationErrors
+	out := map[string]interface{}{}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if fd, ok := f.Field(id); !ok || !fd.Input() {
+			errs = append(errs, FieldError{Field: id, Message: "not an input field of the form"})
+		}
+	}
+
+	for _, fd := range f.Fields {
+		if !fd.Input() {
+			continue
+		}
+		v, ok := values[fd.ID]
+		if !ok || empty(v) {
+			v = fd.Value
+		}
+		if empty(v) {
+			if fd.Required {
+				errs = append(errs, FieldError{Field: fd.ID, Message: "is required"})
+			}
+			continue
+		}
+
+		nv, err := fd.convert(v)
+		if err != nil {
+			errs = append(errs, FieldError{Field: fd.ID, Message: err.Error()})
+			continue
+		}
+		out[fd.ID] = nv
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return out, nil
+}
+
+// empty reports whether v counts as no value
+func empty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// convert checks v against the field and returns the variable value
+func (fd Field) convert(v interface{}) (interface{}, error) {
+	switch fd.Type {
+	case FieldText, FieldMultilineText:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a string")
+		}
+		return s, fd.checkText(s)
+
+	case FieldInteger:
+		n, err := toFloat(v)
+		if err != nil || n != math.Trunc(n) {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		return int64(n), fd.checkRange(n)
+
+	case FieldAmount:
+		n, err := toFloat(v)
+		if err != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		return n, fd.checkRange(n)
+
+	case FieldBoolean:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			if pb, err := strconv.ParseBool(b); err == nil {
+				return pb, nil
+			}
+		}
+		return nil, fmt.Errorf("must be a boolean")
+
+	case FieldDate, FieldDateTime:
+		t, err := toTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("must be a date")
+		}
+		if fd.Type == FieldDate {
+			return t.Format("2006-01-02"), nil
+		}
+		return t.Format(time.RFC3339), nil
+
+	case FieldDropdown, FieldRadioButtons:
+		s, ok := v.(string)
+		if !ok {
+			if o, isMap := v.(map[string]interface{}); isMap {
+				s, ok = o["id"].(string)
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("must be an option id")
+		}
+		if len(fd.Options) == 0 {
+			return s, nil
+		}
+		for _, o := range fd.Options {
+			if o.ID == s || (o.Name == s && o.Name != "") {
+				return o.ID, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not an option", s)
+	}
+
+	// people, functional-group, upload and custom fields are passed through
+	return v, nil
+}
+
+// checkText applies the length and pattern constraints
+func (fd Field) checkText(s string) error {
+	n := len([]rune(s))
+	if fd.MinLength > 0 && n < fd.MinLength {
+		return fmt.Errorf("must have at least %d characters", fd.MinLength)
+	}
+	if fd.MaxLength > 0 && n > fd.MaxLength {
+		return fmt.Errorf("must have at most %d characters", fd.MaxLength)
+	}
+	if fd.RegexPattern != "" {
+		re, err := regexp.Compile(fd.RegexPattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q in form: %v", fd.RegexPattern, err)
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("must match %s", fd.RegexPattern)
+		}
+	}
+	return nil
+}
+
+// checkRange applies minValue and maxValue
+func (fd Field) checkRange(n float64) error {
+	if min, err := strconv.ParseFloat(fd.MinValue, 64); err == nil && n < min {
+		return fmt.Errorf("must be at least %s", fd.MinValue)
+	}
+	if max, err := strconv.ParseFloat(fd.MaxValue, 64); err == nil && n > max {
+		return fmt.Errorf("must be at most %s", fd.MaxValue)
+	}
+	return nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	}
+	return 0, fmt.Errorf("not a number: %T", v)
+}
+
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		s := strings.TrimSpace(t)
+		if d, err := time.Parse("2006-01-02", s); err == nil {
+			return d, nil
+		}
+		return activiti.ParseDate(s)
+	}
+	return time.Time{}, fmt.Errorf("not a date: %T", v)
+}
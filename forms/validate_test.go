@@ -0,0 +1,169 @@
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldConvert(t *testing.T) {
+	options := []Option{{ID: "eu", Name: "Europe"}, {ID: "us", Name: "United States"}}
+	tests := []struct {
+		name    string
+		field   Field
+		value   interface{}
+		want    interface{}
+		wantErr string
+	}{
+		{"text", Field{Type: FieldText}, "hello", "hello", ""},
+		{"text not a string", Field{Type: FieldText}, 1.0, nil, "must be a string"},
+		{"text too short", Field{Type: FieldText, MinLength: 3}, "ab", nil, "must have at least 3 characters"},
+		{"text too long in runes", Field{Type: FieldMultilineText, MaxLength: 2}, "été", nil, "must have at most 2 characters"},
+		{"text pattern", Field{Type: FieldText, RegexPattern: `^[A-Z]+$`}, "ABC", "ABC", ""},
+		{"text pattern mismatch", Field{Type: FieldText, RegexPattern: `^[A-Z]+$`}, "abc", nil, "must match ^[A-Z]+$"},
+		{"text invalid pattern", Field{Type: FieldText, RegexPattern: `(`}, "abc", nil, `invalid pattern "(" in form`},
+
+		{"integer from float64", Field{Type: FieldInteger}, 42.0, int64(42), ""},
+		{"integer from int", Field{Type: FieldInteger}, 42, int64(42), ""},
+		{"integer from string", Field{Type: FieldInteger}, " 42 ", int64(42), ""},
+		{"integer from json.Number", Field{Type: FieldInteger}, json.Number("42"), int64(42), ""},
+		{"integer with a fraction", Field{Type: FieldInteger}, 4.5, nil, "must be an integer"},
+		{"integer not a number", Field{Type: FieldInteger}, "many", nil, "must be an integer"},
+		{"integer below min", Field{Type: FieldInteger, MinValue: "1"}, 0.0, nil, "must be at least 1"},
+		{"integer above max", Field{Type: FieldInteger, MaxValue: "10"}, 11, nil, "must be at most 10"},
+		{"integer in range", Field{Type: FieldInteger, MinValue: "1", MaxValue: "10"}, 10, int64(10), ""},
+
+		{"amount", Field{Type: FieldAmount}, "12.50", 12.5, ""},
+		{"amount from int64", Field{Type: FieldAmount}, int64(3), 3.0, ""},
+		{"amount not a number", Field{Type: FieldAmount}, true, nil, "must be a number"},
+		{"amount below min", Field{Type: FieldAmount, MinValue: "0.5"}, 0.1, nil, "must be at least 0.5"},
+
+		{"boolean", Field{Type: FieldBoolean}, true, true, ""},
+		{"boolean from string", Field{Type: FieldBoolean}, "false", false, ""},
+		{"boolean invalid string", Field{Type: FieldBoolean}, "yes", nil, "must be a boolean"},
+		{"boolean from number", Field{Type: FieldBoolean}, 1.0, nil, "must be a boolean"},
+
+		{"date", Field{Type: FieldDate}, "2026-03-01", "2026-03-01", ""},
+		{"date from datetime", Field{Type: FieldDate}, "2026-03-01T10:00:00.000+0000", "2026-03-01", ""},
+		{"date from time", Field{Type: FieldDate}, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), "2026-03-01", ""},
+		{"datetime", Field{Type: FieldDateTime}, "2026-03-01T10:00:00Z", "2026-03-01T10:00:00Z", ""},
+		{"datetime from date", Field{Type: FieldDateTime}, "2026-03-01", "2026-03-01T00:00:00Z", ""},
+		{"date invalid", Field{Type: FieldDate}, "March 1st", nil, "must be a date"},
+		{"date not a string", Field{Type: FieldDate}, 20260301.0, nil, "must be a date"},
+
+		{"dropdown by id", Field{Type: FieldDropdown, Options: options}, "us", "us", ""},
+		{"dropdown by name", Field{Type: FieldDropdown, Options: options}, "Europe", "eu", ""},
+		{"dropdown option object", Field{Type: FieldRadioButtons, Options: options}, map[string]interface{}{"id": "eu", "name": "Europe"}, "eu", ""},
+		{"dropdown unknown option", Field{Type: FieldDropdown, Options: options}, "asia", nil, `"asia" is not an option`},
+		{"dropdown not an id", Field{Type: FieldDropdown, Options: options}, 1.0, nil, "must be an option id"},
+		{"dropdown without options", Field{Type: FieldDropdown}, "any", "any", ""},
+		{"empty option name", Field{Type: FieldDropdown, Options: []Option{{ID: "a"}}}, "", nil, `"" is not an option`},
+
+		{"people passed through", Field{Type: FieldPeople}, []interface{}{"bob"}, []interface{}{"bob"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.convert(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormValidate(t *testing.T) {
+	form := &Form{Fields: []Field{
+		{ID: "days", Type: FieldInteger, Required: true},
+		{ID: "reason", Type: FieldText},
+		{ID: "urgent", Type: FieldBoolean, Value: false},
+		{ID: "region", Type: FieldDropdown, Required: true, Value: "eu", Options: []Option{{ID: "eu"}, {ID: "us"}}},
+		{ID: "computed", Type: FieldText, ReadOnly: true},
+		{ID: "note", Type: FieldDisplayValue},
+	}}
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   map[string]interface{}
+		errs   ValidationErrors
+	}{
+		{
+			name:   "converted with defaults",
+			values: map[string]interface{}{"days": "3", "reason": "holiday"},
+			want:   map[string]interface{}{"days": int64(3), "reason": "holiday", "urgent": false, "region": "eu"},
+		},
+		{
+			name:   "values replace defaults",
+			values: map[string]interface{}{"days": 3.0, "urgent": "true", "region": "us"},
+			want:   map[string]interface{}{"days": int64(3), "urgent": true, "region": "us"},
+		},
+		{
+			name:   "empty values take the default",
+			values: map[string]interface{}{"days": 1, "region": " ", "reason": ""},
+			want:   map[string]interface{}{"days": int64(1), "urgent": false, "region": "eu"},
+		},
+		{
+			name:   "required",
+			values: map[string]interface{}{"days": ""},
+			errs:   ValidationErrors{{Field: "days", Message: "is required"}},
+		},
+		{
+			name:   "unknown and read only fields",
+			values: map[string]interface{}{"days": 1, "zzz": 1, "computed": "x", "note": "y"},
+			errs: ValidationErrors{
+				{Field: "computed", Message: "not an input field of the form"},
+				{Field: "note", Message: "not an input field of the form"},
+				{Field: "zzz", Message: "not an input field of the form"},
+			},
+		},
+		{
+			name:   "every error is reported",
+			values: map[string]interface{}{"days": 1.5, "urgent": "maybe", "region": "asia"},
+			errs: ValidationErrors{
+				{Field: "days", Message: "must be an integer"},
+				{Field: "urgent", Message: "must be a boolean"},
+				{Field: "region", Message: `"asia" is not an option`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := form.Validate(tt.values)
+			if tt.errs != nil {
+				var errs ValidationErrors
+				if !errors.As(err, &errs) {
+					t.Fatalf("err = %v, want ValidationErrors", err)
+				}
+				if !reflect.DeepEqual(errs, tt.errs) {
+					t.Errorf("errors = %v, want %v", errs, tt.errs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsMessage(t *testing.T) {
+	err := ValidationErrors{{Field: "a", Message: "is required"}, {Field: "b", Message: "must be a number"}}
+	if want := "invalid form values: a: is required; b: must be a number"; err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
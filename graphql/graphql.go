@@ -0,0 +1,100 @@
+// Package graphql queries the GraphQL endpoint of the Activiti Cloud query service and
+// subscribes to engine events of the notifications service over graphql-ws.
+//
+//	gc := graphql.NewClient(client, "https://host/query/graphql", "wss://host/notifications/ws/graphql")
+//	tasks, err := gc.Tasks(ctx, graphql.TaskQuery{Assignee: "hruser", Status: "ASSIGNED"})
+//
+//	evs, err := gc.SubscribeEngineEvents(ctx, graphql.EngineEventFilter{EventType: []events.EventType{events.TaskCreated}}, graphql.SubscribeOptions{})
+//	for ev := range evs {
+//		...
+//	}
+//
+// Queries go through the ActClient, so its token, headers and middleware apply.
+// Subscriptions dial with its http.Client, token and headers only: the middleware,
+// rate limits and circuit breaker do not see the websocket connection.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+type (
+	// Client sends GraphQL requests with an ActClient
+	Client struct {
+		client *activiti.ActClient
+		url    string // Query service GraphQL endpoint
+		wsURL  string // Notifications service graphql-ws endpoint
+	}
+
+	// Error is an error of a GraphQL response
+	Error struct {
+		Message   string        `json:"message"`
+		Path      []interface{} `json:"path,omitempty"`
+		Locations []struct {
+			Line   int `json:"line"`
+			Column int `json:"column"`
+		} `json:"locations,omitempty"`
+	}
+
+	// Errors are the errors of a GraphQL response
+	Errors []Error
+
+	request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}
+
+	response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors Errors          `json:"errors,omitempty"`
+	}
+)
+
+// NewClient returns a client of the query service endpoint url and the notifications
+// endpoint wsURL. Either may be empty when only queries or subscriptions are used
+func NewClient(client *activiti.ActClient, url, wsURL string) *Client {
+	return &Client{client: client, url: url, wsURL: wsURL}
+}
+
+// Error implements error
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Error implements error
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+	}
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// Do sends a query and decodes its data into v. Errors in the response are returned as Errors
+// Endpoint: POST query/graphql
+func (c *Client) Do(ctx context.Context, query string, variables map[string]interface{}, v interface{}) error {
+	if c.url == "" {
+		return errors.New("GraphQL url is required ")
+	}
+	req, err := c.client.NewRequestWithContext(ctx, "POST", c.url, request{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err = c.client.SendWithBasicAuth(req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+	if v == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, v)
+}
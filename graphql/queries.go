@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// DefaultLimit is the page size when a query has no Limit
+const DefaultLimit = 100
+
+// enumPattern matches the enum values accepted in where clauses, such as RUNNING
+var enumPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+const processInstanceFields = `id name status businessKey initiator appName appVersion
+	processDefinitionId processDefinitionKey processDefinitionVersion
+	serviceName serviceFullName serviceType serviceVersion startDate lastModified`
+
+const taskFields = `id name description status assignee owner priority formKey
+	taskDefinitionKey processInstanceId processDefinitionId businessKey appName
+	serviceName serviceFullName serviceType serviceVersion createdDate dueDate
+	claimedDate completedDate completedBy lastModified`
+
+type (
+	// ProcessInstanceQuery selects process instances by every non empty field
+	ProcessInstanceQuery struct {
+		ID                   string
+		Status               string // For example RUNNING
+		ProcessDefinitionKey string
+		BusinessKey          string
+		Initiator            string
+		Page                 int // 1 based, 1 when 0
+		Limit                int // DefaultLimit when 0
+	}
+
+	// TaskQuery selects tasks by every non empty field
+	TaskQuery struct {
+		ID                string
+		Status            string // For example ASSIGNED
+		Assignee          string
+		ProcessInstanceID string
+		TaskDefinitionKey string
+		Page              int // 1 based, 1 when 0
+		Limit             int // DefaultLimit when 0
+	}
+
+	// ProcessInstancePage is a page of process instances
+	ProcessInstancePage struct {
+		Pages            int                        `json:"pages"`
+		Total            int                        `json:"total"`
+		ProcessInstances []activiti.ProcessInstance `json:"select"`
+	}
+
+	// TaskPage is a page of tasks
+	TaskPage struct {
+		Pages int             `json:"pages"`
+		Total int             `json:"total"`
+		Tasks []activiti.Task `json:"select"`
+	}
+
+	// condition is a where clause entry
+	condition struct {
+		field string
+		value string
+		enum  bool
+	}
+)
+
+// ProcessInstances queries process instances
+func (c *Client) ProcessInstances(ctx context.Context, q ProcessInstanceQuery) (*ProcessInstancePage, error) {
+	where, err := whereClause([]condition{
+		{field: "id", value: q.ID},
+		{field: "status", value: q.Status, enum: true},
+		{field: "processDefinitionKey", value: q.ProcessDefinitionKey},
+		{field: "businessKey", value: q.BusinessKey},
+		{field: "initiator", value: q.Initiator},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		ProcessInstances ProcessInstancePage `json:"ProcessInstances"`
+	}
+	query := fmt.Sprintf("query { ProcessInstances(%s%s) { pages total select { %s } } }", pageClause(q.Page, q.Limit), where, processInstanceFields)
+	if err := c.Do(ctx, query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data.ProcessInstances, nil
+}
+
+// Tasks queries tasks
+func (c *Client) Tasks(ctx context.Context, q TaskQuery) (*TaskPage, error) {
+	where, err := whereClause([]condition{
+		{field: "id", value: q.ID},
+		{field: "status", value: q.Status, enum: true},
+		{field: "assignee", value: q.Assignee},
+		{field: "processInstanceId", value: q.ProcessInstanceID},
+		{field: "taskDefinitionKey", value: q.TaskDefinitionKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Tasks TaskPage `json:"Tasks"`
+	}
+	query := fmt.Sprintf("query { Tasks(%s%s) { pages total select { %s } } }", pageClause(q.Page, q.Limit), where, taskFields)
+	if err := c.Do(ctx, query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data.Tasks, nil
+}
+
+// pageClause returns the page argument
+func pageClause(page, limit int) string {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return fmt.Sprintf("page: {start: %d, limit: %d}", page, limit)
+}
+
+// whereClause returns the where argument of the non empty conditions, values are
+// inlined as the argument types differ between query service versions
+func whereClause(conds []condition) (string, error) {
+	var parts []string
+	for _, c := range conds {
+		if c.value == "" {
+			continue
+		}
+		quoted, _ := json.Marshal(c.value)
+		value := string(quoted)
+		if c.enum {
+			if !enumPattern.MatchString(c.value) {
+				return "", fmt.Errorf("invalid %s %q", c.field, c.value)
+			}
+			value = c.value
+		}
+		parts = append(parts, fmt.Sprintf("%s: {EQ: %s}", c.field, value))
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return ", where: {" + strings.Join(parts, ", ") + "}", nil
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+func TestWhereClause(t *testing.T) {
+	tests := []struct {
+		name    string
+		conds   []condition
+		want    string
+		wantErr bool
+	}{
+		{"empty", nil, "", false},
+		{"all values empty", []condition{{field: "id"}, {field: "status", enum: true}}, "", false},
+		{"string", []condition{{field: "id", value: "pi-1"}}, `, where: {id: {EQ: "pi-1"}}`, false},
+		{"quoted", []condition{{field: "businessKey", value: `a"b\c}`}}, `, where: {businessKey: {EQ: "a\"b\\c}"}}`, false},
+		{"injection stays a string", []condition{{field: "assignee", value: `x"}, status: {EQ: CANCELLED`}}, `, where: {assignee: {EQ: "x\"}, status: {EQ: CANCELLED"}}`, false},
+		{"enum", []condition{{field: "status", value: "RUNNING", enum: true}}, `, where: {status: {EQ: RUNNING}}`, false},
+		{"several", []condition{{field: "id", value: "t1"}, {field: "assignee"}, {field: "status", value: "ASSIGNED", enum: true}}, `, where: {id: {EQ: "t1"}, status: {EQ: ASSIGNED}}`, false},
+		{"lower case enum", []condition{{field: "status", value: "running", enum: true}}, "", true},
+		{"enum injection", []condition{{field: "status", value: "RUNNING}, id: {EQ: 1", enum: true}}, "", true},
+		{"quoted enum", []condition{{field: "status", value: `"RUNNING"`, enum: true}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := whereClause(tt.conds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageClause(t *testing.T) {
+	tests := []struct {
+		page, limit int
+		want        string
+	}{
+		{0, 0, "page: {start: 1, limit: 100}"},
+		{-1, -5, "page: {start: 1, limit: 100}"},
+		{3, 20, "page: {start: 3, limit: 20}"},
+	}
+	for _, tt := range tests {
+		if got := pageClause(tt.page, tt.limit); got != tt.want {
+			t.Errorf("pageClause(%d, %d) = %s, want %s", tt.page, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestEngineEventsQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  EngineEventFilter
+		want    string
+		wantErr bool
+	}{
+		{"no filter", EngineEventFilter{}, "subscription { engineEvents { ", false},
+		{"filters", EngineEventFilter{
+			ProcessDefinitionKey: []string{"leave", `a"b`},
+			EventType:            []events.EventType{events.TaskCreated, events.TaskCompleted},
+			AppName:              []string{"app"},
+		}, `subscription { engineEvents(processDefinitionKey: ["leave", "a\"b"], eventType: [TASK_CREATED, TASK_COMPLETED], appName: ["app"]) { `, false},
+		{"invalid event type", EngineEventFilter{EventType: []events.EventType{"task_created"}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := engineEventsQuery(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("got %s, want prefix %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTasks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatal(err)
+		}
+		if want := `Tasks(page: {start: 2, limit: 10}, where: {status: {EQ: ASSIGNED}, assignee: {EQ: "bob"}})`; !strings.Contains(req.Query, want) {
+			t.Errorf("query %s does not contain %s", req.Query, want)
+		}
+		w.Write([]byte(`{"data":{"Tasks":{"pages":3,"total":21,"select":[{"id":"t1","assignee":"bob"}]}}}`))
+	}))
+	defer srv.Close()
+	c, _ := activiti.NewClient("token", srv.URL)
+
+	page, err := NewClient(c, srv.URL+"/graphql", "").Tasks(context.Background(), TaskQuery{Status: "ASSIGNED", Assignee: "bob", Page: 2, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Pages != 3 || page.Total != 21 || len(page.Tasks) != 1 || page.Tasks[0].ID != "t1" {
+		t.Errorf("got %+v", page)
+	}
+
+	if _, err := NewClient(c, srv.URL+"/graphql", "").Tasks(context.Background(), TaskQuery{Status: "assigned"}); err == nil {
+		t.Error("got no error for an invalid status")
+	}
+}
+
+func TestDoErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":[{"message":"bad field"},{"message":"bad type"}]}`))
+	}))
+	defer srv.Close()
+	c, _ := activiti.NewClient("token", srv.URL)
+
+	err := NewClient(c, srv.URL, "").Do(context.Background(), "query { x }", nil, nil)
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 || err.Error() != "graphql: bad field; bad type" {
+		t.Errorf("err = %v", err)
+	}
+	if err := NewClient(c, "", "").Do(context.Background(), "query { x }", nil, nil); err == nil {
+		t.Error("got no error without url")
+	}
+}
@@ -0,0 +1,325 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+const (
+	// DefaultMinBackoff is the first reconnect delay when SubscribeOptions.MinBackoff is 0
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff is the longest reconnect delay when SubscribeOptions.MaxBackoff is 0
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultKeepAliveTimeout is SubscribeOptions.KeepAliveTimeout when 0
+	DefaultKeepAliveTimeout = time.Minute
+
+	// closeTimeout bounds the stop and close messages sent when the subscription ends
+	closeTimeout = time.Second
+
+	// subprotocol is the Apollo subscriptions-transport-ws protocol spoken by the notifications service
+	subprotocol = "graphql-ws"
+)
+
+const engineEventFields = `id timestamp eventType entityId appName appVersion serviceName
+	serviceFullName serviceType serviceVersion processInstanceId parentProcessInstanceId
+	processDefinitionId processDefinitionKey processDefinitionVersion businessKey
+	messageId sequenceNumber entity`
+
+type (
+	// SubscribeOptions configures a subscription
+	SubscribeOptions struct {
+		MinBackoff time.Duration // DefaultMinBackoff when 0
+		MaxBackoff time.Duration // DefaultMaxBackoff when 0
+		// KeepAliveTimeout is the longest silence of a server sending ka messages before
+		// the connection is considered dead and reconnected, DefaultKeepAliveTimeout when 0.
+		// Servers which never send ka are not timed out
+		KeepAliveTimeout time.Duration
+		// OnError is called with every connection or subscription error before reconnecting
+		OnError func(err error)
+		// Buffer is the capacity of the returned channel
+		Buffer int
+	}
+
+	// EngineEventFilter selects engine events by every non empty field
+	EngineEventFilter struct {
+		ProcessDefinitionKey []string
+		EventType            []events.EventType
+		ServiceName          []string
+		AppName              []string
+	}
+
+	// message is a graphql-ws protocol message
+	message struct {
+		ID      string          `json:"id,omitempty"`
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload,omitempty"`
+	}
+)
+
+// Subscribe sends a subscription and delivers the data of every result on the channel,
+// reconnecting with exponential backoff until ctx is done. Then it stops the subscription,
+// terminates the connection and closes the channel
+// Endpoint: GET notifications/ws/graphql
+func (c *Client) Subscribe(ctx context.Context, query string, variables map[string]interface{}, opts SubscribeOptions) (<-chan json.RawMessage, error) {
+	if c.wsURL == "" {
+		return nil, errors.New("GraphQL websocket url is required ")
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if opts.KeepAliveTimeout <= 0 {
+		opts.KeepAliveTimeout = DefaultKeepAliveTimeout
+	}
+
+	out := make(chan json.RawMessage, opts.Buffer)
+	go func() {
+		defer close(out)
+		backoff := opts.MinBackoff
+		for {
+			acked, err := c.subscribe(ctx, request{Query: query, Variables: variables}, opts.KeepAliveTimeout, out)
+			if ctx.Err() != nil {
+				return
+			}
+			if acked {
+				backoff = opts.MinBackoff
+			}
+			if err != nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > opts.MaxBackoff {
+				backoff = opts.MaxBackoff
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeEngineEvents subscribes to the engine events of the notifications service
+func (c *Client) SubscribeEngineEvents(ctx context.Context, f EngineEventFilter, opts SubscribeOptions) (<-chan events.RuntimeEvent, error) {
+	query, err := engineEventsQuery(f)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Subscribe(ctx, query, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan events.RuntimeEvent, opts.Buffer)
+	go func() {
+		defer close(out)
+		for d := range data {
+			var payload struct {
+				EngineEvents []json.RawMessage `json:"engineEvents"`
+			}
+			if err := json.Unmarshal(d, &payload); err != nil {
+				if opts.OnError != nil {
+					opts.OnError(fmt.Errorf("invalid engine events: %v", err))
+				}
+				continue
+			}
+			for _, raw := range payload.EngineEvents {
+				ev, err := events.DecodeEvent(raw)
+				if err != nil {
+					if opts.OnError != nil {
+						opts.OnError(err)
+					}
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// subscribe runs one connection until it fails or ctx is done and reports whether the server acknowledged it
+func (c *Client) subscribe(ctx context.Context, req request, keepAlive time.Duration, out chan<- json.RawMessage) (bool, error) {
+	header := http.Header{}
+	for k, v := range c.client.Header {
+		header[k] = v
+	}
+	if c.client.Token != "" {
+		header.Set("Authorization", "Bearer "+c.client.Token)
+	}
+	hc := c.client.Client
+	if hc != nil && hc.Timeout != 0 {
+		// A client timeout would close the long lived connection
+		cp := *hc
+		cp.Timeout = 0
+		hc = &cp
+	}
+	conn, _, err := websocket.Dial(ctx, c.wsURL, &websocket.DialOptions{
+		HTTPClient:   hc,
+		HTTPHeader:   header,
+		Subprotocols: []string{subprotocol},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(-1)
+
+	// A read cancelled by its context closes the connection without a message, so reads
+	// use their own context and the subscription is stopped when ctx is done
+	readCtx, cancelRead := context.WithCancel(context.Background())
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		select {
+		case <-ctx.Done():
+			stop(conn)
+		case <-readCtx.Done():
+		}
+	}()
+	defer func() {
+		cancelRead()
+		<-closed
+	}()
+
+	// The notifications service reads the token from the connection payload
+	init, _ := json.Marshal(map[string]string{"X-Authorization": "Bearer " + c.client.Token})
+	if err = wsjson.Write(ctx, conn, message{Type: "connection_init", Payload: init}); err != nil {
+		return false, err
+	}
+
+	acked, kaSeen := false, false
+	for {
+		var m message
+		if err = read(readCtx, conn, &m, kaSeen, keepAlive); err != nil {
+			if ctx.Err() != nil {
+				return acked, ctx.Err()
+			}
+			return acked, err
+		}
+
+		switch m.Type {
+		case "connection_ack":
+			acked = true
+			start, _ := json.Marshal(req)
+			if err = wsjson.Write(ctx, conn, message{ID: "1", Type: "start", Payload: start}); err != nil {
+				return acked, err
+			}
+		case "connection_error":
+			return acked, fmt.Errorf("graphql-ws connection error: %s", m.Payload)
+		case "data":
+			var resp response
+			if err = json.Unmarshal(m.Payload, &resp); err != nil {
+				return acked, fmt.Errorf("invalid graphql-ws data: %v", err)
+			}
+			if len(resp.Errors) > 0 {
+				return acked, resp.Errors
+			}
+			select {
+			case out <- resp.Data:
+			case <-ctx.Done():
+				return acked, ctx.Err()
+			}
+		case "error":
+			var errs Errors
+			if json.Unmarshal(m.Payload, &errs) != nil {
+				errs = Errors{{Message: string(m.Payload)}}
+			}
+			return acked, errs
+		case "complete":
+			return acked, errors.New("graphql-ws: subscription completed by the server")
+		case "ka":
+			kaSeen = true
+		}
+	}
+}
+
+// read reads the next message, within keepAlive once the server sends ka messages
+func read(ctx context.Context, conn *websocket.Conn, m *message, kaSeen bool, keepAlive time.Duration) error {
+	if !kaSeen {
+		return wsjson.Read(ctx, conn, m)
+	}
+	kaCtx, cancel := context.WithTimeout(ctx, keepAlive)
+	defer cancel()
+	err := wsjson.Read(kaCtx, conn, m)
+	// The expired read closes the connection, the error may only say so
+	if err != nil && ctx.Err() == nil && kaCtx.Err() != nil {
+		return fmt.Errorf("graphql-ws: no message from the server for %v", keepAlive)
+	}
+	return err
+}
+
+// stop ends the subscription and the connection with the stop, connection_terminate
+// and close messages, so the server releases them at once
+func stop(conn *websocket.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if wsjson.Write(ctx, conn, message{ID: "1", Type: "stop"}) == nil {
+		wsjson.Write(ctx, conn, message{Type: "connection_terminate"})
+	}
+	conn.Close(websocket.StatusNormalClosure, "")
+}
+
+// engineEventsQuery returns the engineEvents subscription of the filter
+func engineEventsQuery(f EngineEventFilter) (string, error) {
+	var args []string
+	add := func(name string, values []string, enum bool) error {
+		if len(values) == 0 {
+			return nil
+		}
+		items := make([]string, len(values))
+		for i, v := range values {
+			if enum {
+				if !enumPattern.MatchString(v) {
+					return fmt.Errorf("invalid %s %q", name, v)
+				}
+				items[i] = v
+				continue
+			}
+			quoted, _ := json.Marshal(v)
+			items[i] = string(quoted)
+		}
+		args = append(args, fmt.Sprintf("%s: [%s]", name, strings.Join(items, ", ")))
+		return nil
+	}
+
+	types := make([]string, len(f.EventType))
+	for i, t := range f.EventType {
+		types[i] = string(t)
+	}
+	if err := add("processDefinitionKey", f.ProcessDefinitionKey, false); err != nil {
+		return "", err
+	}
+	if err := add("eventType", types, true); err != nil {
+		return "", err
+	}
+	if err := add("serviceName", f.ServiceName, false); err != nil {
+		return "", err
+	}
+	if err := add("appName", f.AppName, false); err != nil {
+		return "", err
+	}
+
+	field := "engineEvents"
+	if len(args) > 0 {
+		field += "(" + strings.Join(args, ", ") + ")"
+	}
+	return fmt.Sprintf("subscription { %s { %s } }", field, engineEventFields), nil
+}
@@ -0,0 +1,382 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+// wsServer is a graphql-ws server running a script per connection, n counts from 1.
+// The script runs after connection_init was received
+type wsServer struct {
+	t      *testing.T
+	srv    *httptest.Server
+	script func(ctx context.Context, conn *websocket.Conn, n int)
+
+	mu    sync.Mutex
+	dials []time.Time
+	// reject fails the dials with a 500 while it returns true
+	reject func(n int) bool
+}
+
+func newWSServer(t *testing.T, script func(ctx context.Context, conn *websocket.Conn, n int)) *wsServer {
+	s := &wsServer{t: t, script: script}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *wsServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.dials = append(s.dials, time.Now())
+	n := len(s.dials)
+	s.mu.Unlock()
+	if s.reject != nil && s.reject(n) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if got := r.Header.Get("Authorization"); got != "Bearer token" {
+		s.t.Errorf("Authorization = %q", got)
+	}
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{subprotocol}})
+	if err != nil {
+		s.t.Errorf("accept: %v", err)
+		return
+	}
+	defer conn.CloseNow()
+	if conn.Subprotocol() != subprotocol {
+		s.t.Errorf("subprotocol = %q", conn.Subprotocol())
+	}
+
+	ctx := r.Context()
+	init := expect(ctx, s.t, conn, "connection_init")
+	var payload map[string]string
+	if json.Unmarshal(init.Payload, &payload); payload["X-Authorization"] != "Bearer token" {
+		s.t.Errorf("connection_init payload = %s", init.Payload)
+	}
+	s.script(ctx, conn, n)
+}
+
+func (s *wsServer) client() *Client {
+	c, _ := activiti.NewClient("token", s.srv.URL)
+	return NewClient(c, "", "ws"+strings.TrimPrefix(s.srv.URL, "http"))
+}
+
+func (s *wsServer) dialTimes() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.dials...)
+}
+
+// expect reads a message and checks its type, it returns an empty message on errors
+func expect(ctx context.Context, t *testing.T, conn *websocket.Conn, typ string) message {
+	var m message
+	if err := wsjson.Read(ctx, conn, &m); err != nil {
+		t.Errorf("reading %s: %v", typ, err)
+		return m
+	}
+	if m.Type != typ {
+		t.Errorf("message %s %s, want %s", m.Type, m.Payload, typ)
+	}
+	return m
+}
+
+// send writes a message with a raw payload
+func send(ctx context.Context, conn *websocket.Conn, typ, payload string) {
+	m := message{Type: typ}
+	if typ != "connection_ack" && typ != "ka" && typ != "connection_error" {
+		m.ID = "1"
+	}
+	if payload != "" {
+		m.Payload = json.RawMessage(payload)
+	}
+	wsjson.Write(ctx, conn, m)
+}
+
+// ackAndStart acknowledges the connection and reads the start of the subscription
+func ackAndStart(ctx context.Context, t *testing.T, conn *websocket.Conn) {
+	send(ctx, conn, "connection_ack", "")
+	start := expect(ctx, t, conn, "start")
+	if start.ID != "1" || !strings.Contains(string(start.Payload), `"query":"subscription { x }"`) {
+		t.Errorf("start = %s %s", start.ID, start.Payload)
+	}
+}
+
+// receive reads n values of the channel
+func receive(t *testing.T, data <-chan json.RawMessage, n int) []string {
+	var got []string
+	for len(got) < n {
+		select {
+		case d, ok := <-data:
+			if !ok {
+				t.Fatalf("channel closed after %q", got)
+			}
+			got = append(got, string(d))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout after %q", got)
+		}
+	}
+	return got
+}
+
+// errorLog collects the errors passed to OnError
+type errorLog struct {
+	mu   sync.Mutex
+	errs []string
+}
+
+func (l *errorLog) add(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err.Error())
+}
+
+func (l *errorLog) list() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.errs...)
+}
+
+func TestSubscribe(t *testing.T) {
+	stopped := make(chan []string, 1)
+	s := newWSServer(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		ackAndStart(ctx, t, conn)
+		switch n {
+		case 1:
+			send(ctx, conn, "ka", "")
+			send(ctx, conn, "data", `{"data":{"n":1}}`)
+			send(ctx, conn, "ka", "")
+			send(ctx, conn, "data", `{"data":{"n":2}}`)
+			send(ctx, conn, "complete", "")
+			conn.Read(ctx)
+		case 2:
+			send(ctx, conn, "data", `{"data":{"n":3}}`)
+			// The client stops the subscription and terminates the connection on shutdown
+			var got []string
+			for _, typ := range []string{"stop", "connection_terminate"} {
+				m := expect(ctx, t, conn, typ)
+				got = append(got, m.ID+" "+m.Type)
+			}
+			_, _, err := conn.Read(ctx)
+			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+				t.Errorf("close = %v, want a normal closure", err)
+			}
+			stopped <- got
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := &errorLog{}
+	data, err := s.client().Subscribe(ctx, "subscription { x }", nil, SubscribeOptions{MinBackoff: time.Millisecond, OnError: log.add})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{`{"n":1}`, `{"n":2}`, `{"n":3}`}
+	if got := receive(t, data, 3); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if errs := log.list(); len(errs) != 1 || !strings.Contains(errs[0], "completed by the server") {
+		t.Errorf("errors = %q", errs)
+	}
+
+	cancel()
+	select {
+	case got := <-stopped:
+		if want := []string{"1 stop", " connection_terminate"}; strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("shutdown messages = %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server saw no stop")
+	}
+	for range data {
+	}
+}
+
+func TestSubscribeReconnectsOnErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		payload string
+		want    string
+	}{
+		{"connection error", "connection_error", `{"message":"invalid token"}`, "graphql-ws connection error: {\"message\":\"invalid token\"}"},
+		{"subscription error", "error", `[{"message":"unknown field"}]`, "graphql: unknown field"},
+		{"unstructured error", "error", `"boom"`, `graphql: "boom"`},
+		{"data errors", "data", `{"data":null,"errors":[{"message":"denied"}]}`, "graphql: denied"},
+		{"invalid data", "data", `[1]`, "invalid graphql-ws data"},
+		{"complete", "complete", "", "graphql-ws: subscription completed by the server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWSServer(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+				if n == 1 && tt.typ == "connection_error" {
+					send(ctx, conn, tt.typ, tt.payload)
+					conn.Read(ctx)
+					return
+				}
+				ackAndStart(ctx, t, conn)
+				if n == 1 {
+					send(ctx, conn, tt.typ, tt.payload)
+				} else {
+					send(ctx, conn, "data", `{"data":{"ok":true}}`)
+				}
+				conn.Read(ctx)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			log := &errorLog{}
+			data, err := s.client().Subscribe(ctx, "subscription { x }", nil, SubscribeOptions{MinBackoff: time.Millisecond, OnError: log.add})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := receive(t, data, 1); got[0] != `{"ok":true}` {
+				t.Errorf("got %q", got)
+			}
+			if errs := log.list(); len(errs) != 1 || !strings.Contains(errs[0], tt.want) {
+				t.Errorf("errors = %q, want %q", errs, tt.want)
+			}
+			if n := len(s.dialTimes()); n != 2 {
+				t.Errorf("%d connections, want 2", n)
+			}
+		})
+	}
+}
+
+func TestSubscribeKeepAliveTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		ka        bool
+		wantDials int
+		wantErr   string
+	}{
+		// A silent server which sent ka is reconnected
+		{"after ka", true, 2, "no message from the server for 50ms"},
+		// A server without ka is never timed out
+		{"without ka", false, 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWSServer(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+				ackAndStart(ctx, t, conn)
+				if n == 1 {
+					if tt.ka {
+						send(ctx, conn, "ka", "")
+					}
+					time.Sleep(200 * time.Millisecond)
+				}
+				send(ctx, conn, "data", `{"data":{"n":`+strconv.Itoa(n)+`}}`)
+				conn.Read(ctx)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			log := &errorLog{}
+			data, err := s.client().Subscribe(ctx, "subscription { x }", nil, SubscribeOptions{
+				MinBackoff:       time.Millisecond,
+				KeepAliveTimeout: 50 * time.Millisecond,
+				OnError:          log.add,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `{"n":` + strconv.Itoa(tt.wantDials) + `}`
+			if got := receive(t, data, 1); got[0] != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+			errs := log.list()
+			if tt.wantErr == "" && len(errs) != 0 || tt.wantErr != "" && (len(errs) == 0 || !strings.Contains(errs[0], tt.wantErr)) {
+				t.Errorf("errors = %q, want %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscribeBackoff(t *testing.T) {
+	const min = 10 * time.Millisecond
+	s := newWSServer(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		ackAndStart(ctx, t, conn)
+		if n == 5 {
+			send(ctx, conn, "complete", "")
+		} else {
+			send(ctx, conn, "data", `{"data":{}}`)
+		}
+		conn.Read(ctx)
+	})
+	s.reject = func(n int) bool { return n <= 4 }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	data, err := s.client().Subscribe(ctx, "subscription { x }", nil, SubscribeOptions{MinBackoff: min, MaxBackoff: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(t, data, 1)
+
+	dials := s.dialTimes()
+	if len(dials) != 6 {
+		t.Fatalf("%d connections, want 6", len(dials))
+	}
+	// The delay doubles after every failed dial
+	for i, want := range []time.Duration{min, 2 * min, 4 * min, 8 * min} {
+		if gap := dials[i+1].Sub(dials[i]); gap < want {
+			t.Errorf("delay %d = %v, want at least %v", i+1, gap, want)
+		}
+	}
+	// and starts again from MinBackoff after an acknowledged connection
+	if gap := dials[5].Sub(dials[4]); gap < min || gap >= 16*min {
+		t.Errorf("delay after ack = %v, want from %v", gap, min)
+	}
+}
+
+func TestSubscribeEngineEvents(t *testing.T) {
+	s := newWSServer(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		send(ctx, conn, "connection_ack", "")
+		start := expect(ctx, t, conn, "start")
+		if !strings.Contains(string(start.Payload), "engineEvents(eventType: [TASK_CREATED])") {
+			t.Errorf("start = %s", start.Payload)
+		}
+		send(ctx, conn, "data", `{"data":{"engineEvents":[
+			{"eventType":"TASK_CREATED","entityId":"t1","entity":{"id":"t1"}},
+			{"eventType":"PROCESS_STARTED","entityId":"p1","entity":{"id":"p1"}}]}}`)
+		conn.Read(ctx)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evs, err := s.client().SubscribeEngineEvents(ctx, EngineEventFilter{EventType: []events.EventType{events.TaskCreated}}, SubscribeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []events.EventType{events.TaskCreated, events.ProcessStarted} {
+		select {
+		case ev := <-evs:
+			if ev.Envelope().EventType != want {
+				t.Errorf("event = %s, want %s", ev.Envelope().EventType, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestSubscribeRequiresURL(t *testing.T) {
+	c, _ := activiti.NewClient("token", "http://localhost")
+	if _, err := NewClient(c, "http://localhost/graphql", "").Subscribe(context.Background(), "subscription { x }", nil, SubscribeOptions{}); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,401 @@
+// Package inbox builds a user's task inbox from the Activiti Cloud query service.
+//
+//	box, err := inbox.Load(ctx, client, "hruser", inbox.Options{GroupBy: inbox.GroupBySLA})
+//	for _, g := range box.Groups {
+//		for _, it := range g.Items {
+//			fmt.Println(g.Title, it.Name, it.ProcessInstanceName, it.SLA)
+//		}
+//	}
+//
+// The query service only returns the tasks the authenticated user can see, so the
+// client must authenticate as the user. Tasks assigned to the user and unassigned
+// tasks the user can claim are enriched with their process instance and definition
+// and sorted by urgency.
+package inbox
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const (
+	// DefaultPageSize is the query page size when Options.PageSize is 0
+	DefaultPageSize = 100
+	// instanceBatch is the number of process instance ids queried at once
+	instanceBatch = 50
+)
+
+// GroupBy selects how the inbox items are grouped
+type GroupBy string
+
+const (
+	GroupNone             GroupBy = ""                  // A single group
+	GroupByAssignment     GroupBy = "assignment"        // Assigned, then claimable
+	GroupByProcess        GroupBy = "processDefinition" // By process definition name
+	GroupBySLA            GroupBy = "sla"               // Overdue, at risk, on track, no due date
+	GroupByTaskDefinition GroupBy = "taskDefinition"    // By task name
+)
+
+// Group keys of GroupNone and GroupByAssignment
+const (
+	allGroup       = "all"
+	assignedGroup  = "assigned"
+	claimableGroup = "claimable"
+)
+
+// SortBy selects the order of the items of a group
+type SortBy string
+
+const (
+	SortByDueDate  SortBy = ""         // Most urgent SLA first, then earliest due date and highest priority
+	SortByPriority SortBy = "priority" // Highest priority first, then earliest due date
+	SortByCreated  SortBy = "created"  // Oldest task first
+)
+
+type (
+	// Client is the part of ActClient used by the inbox. Load makes the requests with its
+	// ctx when the client is an *activiti.ActClient or a ContextClient, otherwise ctx is
+	// only checked between requests
+	Client interface {
+		QueryTasks(params url.Values) (*activiti.ActListTasks, error)
+		QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error)
+		FindProcessDefinitions(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error)
+		GetTaskCandidateGroups(tid string) ([]string, error)
+	}
+
+	// ContextClient is a Client whose requests can be made with a context
+	ContextClient interface {
+		Client
+		WithContext(ctx context.Context) Client
+	}
+
+	// Options configures Load
+	Options struct {
+		// Groups keeps only the claimable tasks offered to one of these candidate groups.
+		// All claimable tasks are kept when empty
+		Groups   []string
+		Params   url.Values // Extra task filters, for example {"appName": {"hr"}}
+		GroupBy  GroupBy
+		SortBy   SortBy
+		SLA      SLAPolicy
+		PageSize int              // maxItems of each query page
+		Now      func() time.Time // Time of the SLA status, time.Now when nil
+	}
+
+	// Item is a task of the inbox
+	Item struct {
+		activiti.Task
+		Claimable             bool      `json:"claimable"`
+		CandidateGroups       []string  `json:"candidateGroups,omitempty"` // Only loaded with Options.Groups
+		ProcessInstanceName   string    `json:"processInstanceName,omitempty"`
+		ProcessDefinitionKey  string    `json:"processDefinitionKey,omitempty"`
+		ProcessDefinitionName string    `json:"processDefinitionName,omitempty"`
+		Due                   time.Time `json:"-"` // Parsed DueDate, zero without one
+		SLA                   SLAStatus `json:"sla"`
+	}
+
+	// Group is a titled list of items
+	Group struct {
+		Key   string `json:"key"`
+		Title string `json:"title"`
+		Items []Item `json:"items"`
+	}
+
+	// Inbox is the inbox of a user
+	Inbox struct {
+		User      string            `json:"user"`
+		Groups    []Group           `json:"groups"`
+		Total     int               `json:"total"`
+		Assigned  int               `json:"assigned"`
+		Claimable int               `json:"claimable"`
+		SLA       map[SLAStatus]int `json:"sla"` // Number of items by SLA status
+	}
+)
+
+// Load returns the inbox of user
+func Load(ctx context.Context, c Client, user string, opts Options) (*Inbox, error) {
+	if user == "" {
+		return nil, errors.New("inbox user is required ")
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+	c = withContext(ctx, c)
+
+	items, err := loadItems(ctx, c, user, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = enrich(ctx, c, items); err != nil {
+		return nil, err
+	}
+
+	box := &Inbox{User: user, Total: len(items), SLA: map[SLAStatus]int{}}
+	for i := range items {
+		items[i].SLA, items[i].Due = opts.SLA.Status(items[i].Task, now)
+		box.SLA[items[i].SLA]++
+		if items[i].Claimable {
+			box.Claimable++
+		} else {
+			box.Assigned++
+		}
+	}
+	sortItems(items, opts.SortBy)
+	box.Groups = group(items, opts.GroupBy)
+	return box, nil
+}
+
+// withContext returns c making its requests with ctx, when it can
+func withContext(ctx context.Context, c Client) Client {
+	switch cc := c.(type) {
+	case *activiti.ActClient:
+		return cc.WithContext(ctx)
+	case ContextClient:
+		return cc.WithContext(ctx)
+	}
+	return c
+}
+
+// loadItems returns the tasks assigned to user and the claimable tasks
+func loadItems(ctx context.Context, c Client, user string, opts Options) ([]Item, error) {
+	groups := map[string]bool{}
+	for _, g := range opts.Groups {
+		groups[g] = true
+	}
+
+	var items []Item
+	for _, status := range []string{"ASSIGNED", "CREATED"} {
+		tasks, err := queryTasks(ctx, c, status, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			it := Item{Task: t}
+			switch {
+			case t.Assignee == user:
+			case t.Assignee == "":
+				it.Claimable = true
+				if len(groups) > 0 {
+					if it.CandidateGroups, err = c.GetTaskCandidateGroups(t.ID); err != nil {
+						return nil, err
+					}
+					if !offered(it.CandidateGroups, groups) {
+						continue
+					}
+				}
+			default:
+				// Visible to the user but assigned to someone else
+				continue
+			}
+			items = append(items, it)
+		}
+	}
+	return items, nil
+}
+
+func offered(candidates []string, groups map[string]bool) bool {
+	for _, g := range candidates {
+		if groups[g] {
+			return true
+		}
+	}
+	return false
+}
+
+// queryTasks pages through the tasks with status
+func queryTasks(ctx context.Context, c Client, status string, opts Options) ([]activiti.Task, error) {
+	var tasks []activiti.Task
+	for skip := 0; ; skip += opts.PageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		params := url.Values{}
+		for k, v := range opts.Params {
+			params[k] = v
+		}
+		params.Set("status", status)
+		params.Set("skipCount", strconv.Itoa(skip))
+		params.Set("maxItems", strconv.Itoa(opts.PageSize))
+
+		page, err := c.QueryTasks(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page.List.Tasks {
+			tasks = append(tasks, t.Task)
+		}
+		if !page.List.Pagination.HasMoreItems || len(page.List.Tasks) == 0 {
+			return tasks, nil
+		}
+	}
+}
+
+// enrich fills the process instance and definition of the items
+func enrich(ctx context.Context, c Client, items []Item) error {
+	var ids []string
+	seen := map[string]bool{}
+	for _, it := range items {
+		if id := it.ProcessInstanceId; id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	instances := map[string]activiti.ProcessInstance{}
+	for len(ids) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := instanceBatch
+		if n > len(ids) {
+			n = len(ids)
+		}
+		page, err := c.QueryProcessInstances(url.Values{"id": ids[:n], "maxItems": {strconv.Itoa(instanceBatch)}})
+		if err != nil {
+			return err
+		}
+		for _, pi := range page.List.ProcessInstances {
+			instances[pi.ProcessInstance.ID] = pi.ProcessInstance
+		}
+		ids = ids[n:]
+	}
+
+	// Definition names are only looked up when the query service did not return them
+	var definitions map[string]activiti.ProcessDefinition
+	for i := range items {
+		it := &items[i]
+		pi, ok := instances[it.ProcessInstanceId]
+		if ok {
+			it.ProcessInstanceName = pi.Name
+			it.ProcessDefinitionKey = pi.ProcessDefinitionKey
+			it.ProcessDefinitionName = pi.ProcessDefinitionName
+			if pi.BusinessKey != "" {
+				it.BusinessKey = pi.BusinessKey
+			}
+		}
+		if it.ProcessDefinitionName != "" || it.ProcessDefinitionId == "" {
+			continue
+		}
+		if definitions == nil {
+			pds, err := c.FindProcessDefinitions(activiti.ProcessDefinitionFilter{})
+			if err != nil {
+				return err
+			}
+			definitions = map[string]activiti.ProcessDefinition{}
+			for _, pd := range pds {
+				definitions[pd.ID] = pd
+			}
+		}
+		if pd, ok := definitions[it.ProcessDefinitionId]; ok {
+			it.ProcessDefinitionName = pd.Name
+			if it.ProcessDefinitionKey == "" {
+				it.ProcessDefinitionKey = pd.Key
+			}
+		}
+	}
+	return nil
+}
+
+// sortItems orders items in place
+func sortItems(items []Item, by SortBy) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch by {
+		case SortByPriority:
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			return dueBefore(a, b)
+		case SortByCreated:
+			return a.CreatedDate < b.CreatedDate
+		}
+		if a.SLA.severity() != b.SLA.severity() {
+			return a.SLA.severity() < b.SLA.severity()
+		}
+		if !a.Due.Equal(b.Due) {
+			return dueBefore(a, b)
+		}
+		return a.Priority > b.Priority
+	})
+}
+
+// dueBefore orders by due date, items without one last
+func dueBefore(a, b Item) bool {
+	switch {
+	case a.Due.IsZero():
+		return false
+	case b.Due.IsZero():
+		return true
+	}
+	return a.Due.Before(b.Due)
+}
+
+// group splits sorted items, keeping their order within each group
+func group(items []Item, by GroupBy) []Group {
+	var groups []Group
+	index := map[string]int{}
+	for _, it := range items {
+		key, title := groupKey(it, by)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, Group{Key: key, Title: title})
+		}
+		groups[i].Items = append(groups[i].Items, it)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		switch by {
+		case GroupByAssignment:
+			return a.Key == assignedGroup && b.Key != assignedGroup
+		case GroupBySLA:
+			return SLAStatus(a.Key).severity() < SLAStatus(b.Key).severity()
+		case GroupByProcess, GroupByTaskDefinition:
+			return a.Title < b.Title
+		}
+		return false
+	})
+	return groups
+}
+
+func groupKey(it Item, by GroupBy) (string, string) {
+	switch by {
+	case GroupByAssignment:
+		if it.Claimable {
+			return claimableGroup, "Claimable"
+		}
+		return assignedGroup, "Assigned"
+	case GroupBySLA:
+		return string(it.SLA), slaTitles[it.SLA]
+	case GroupByProcess:
+		if it.ProcessDefinitionName == "" {
+			return it.ProcessDefinitionKey, it.ProcessDefinitionKey
+		}
+		return it.ProcessDefinitionKey, it.ProcessDefinitionName
+	case GroupByTaskDefinition:
+		if it.Name == "" {
+			return it.TaskDefinitionKey, it.TaskDefinitionKey
+		}
+		return it.TaskDefinitionKey, it.Name
+	}
+	return allGroup, "All"
+}
+
+var slaTitles = map[SLAStatus]string{
+	SLAOverdue: "Overdue",
+	SLAAtRisk:  "At risk",
+	SLAOnTrack: "On track",
+	SLANone:    "No due date",
+}
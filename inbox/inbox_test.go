@@ -0,0 +1,238 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+// fakeClient serves tasks by status, pages of PageSize, and fixed instances and definitions
+type fakeClient struct {
+	tasks       map[string][]activiti.Task
+	instances   []activiti.ProcessInstance
+	definitions []activiti.ProcessDefinition
+	groups      map[string][]string
+
+	definitionCalls int
+}
+
+func (f *fakeClient) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	tasks := f.tasks[params.Get("status")]
+	skip, _ := strconv.Atoi(params.Get("skipCount"))
+	max, _ := strconv.Atoi(params.Get("maxItems"))
+	l := &activiti.ActListTasks{}
+	for i := skip; i < len(tasks) && i < skip+max; i++ {
+		l.List.Tasks = append(l.List.Tasks, activiti.ActTask{Task: tasks[i]})
+	}
+	l.List.Pagination.HasMoreItems = skip+max < len(tasks)
+	return l, nil
+}
+
+func (f *fakeClient) QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error) {
+	ids := map[string]bool{}
+	for _, id := range params["id"] {
+		ids[id] = true
+	}
+	l := &activiti.ActListProcessInstances{}
+	for _, pi := range f.instances {
+		if ids[pi.ID] {
+			l.List.ProcessInstances = append(l.List.ProcessInstances, activiti.ActProcessInstance{ProcessInstance: pi})
+		}
+	}
+	return l, nil
+}
+
+func (f *fakeClient) FindProcessDefinitions(activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error) {
+	f.definitionCalls++
+	return f.definitions, nil
+}
+
+func (f *fakeClient) GetTaskCandidateGroups(tid string) ([]string, error) {
+	return f.groups[tid], nil
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		tasks: map[string][]activiti.Task{
+			"ASSIGNED": {
+				{ID: "t1", Name: "Approve", TaskDefinitionKey: "approve", Assignee: "bob", ProcessInstanceId: "p1", DueDate: "2026-01-09T12:00:00.000+0000", Priority: 50, CreatedDate: "2026-01-03"},
+				{ID: "t2", Name: "Review", Assignee: "alice", ProcessInstanceId: "p1"},
+				{ID: "t3", Name: "Check", TaskDefinitionKey: "check", Assignee: "bob", ProcessInstanceId: "p2", ProcessDefinitionId: "exp:1", DueDate: "2026-01-12T00:00:00.000+0000", Priority: 80, CreatedDate: "2026-01-01"},
+			},
+			"CREATED": {
+				{ID: "t4", Name: "Claim me", TaskDefinitionKey: "claim", ProcessInstanceId: "p2", ProcessDefinitionId: "exp:1", DueDate: "2026-01-20T00:00:00Z", Priority: 90, CreatedDate: "2026-01-02"},
+				{ID: "t5", Name: "Not mine", TaskDefinitionKey: "other", ProcessInstanceId: "p3"},
+			},
+		},
+		instances: []activiti.ProcessInstance{
+			{ID: "p1", Name: "Leave 1", BusinessKey: "BK1", ProcessDefinitionKey: "leave", ProcessDefinitionName: "Leave"},
+			{ID: "p2", Name: "Expense 2", ProcessDefinitionKey: "exp"},
+		},
+		definitions: []activiti.ProcessDefinition{{ID: "exp:1", Key: "exp", Name: "Expenses"}},
+		groups:      map[string][]string{"t4": {"hr"}, "t5": {"finance"}},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	c := newFakeClient()
+
+	box, err := Load(context.Background(), c, "bob", Options{Groups: []string{"hr"}, PageSize: 1, Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if box.Total != 3 || box.Assigned != 2 || box.Claimable != 1 {
+		t.Fatalf("total %d, assigned %d, claimable %d", box.Total, box.Assigned, box.Claimable)
+	}
+	want := map[SLAStatus]int{SLAOverdue: 1, SLAAtRisk: 1, SLAOnTrack: 1}
+	if !reflect.DeepEqual(box.SLA, want) {
+		t.Errorf("SLA = %v, want %v", box.SLA, want)
+	}
+
+	items := map[string]Item{}
+	for _, it := range box.Groups[0].Items {
+		items[it.ID] = it
+	}
+	if it := items["t1"]; it.ProcessInstanceName != "Leave 1" || it.BusinessKey != "BK1" || it.ProcessDefinitionName != "Leave" {
+		t.Errorf("t1 = %+v", it)
+	}
+	if it := items["t3"]; it.ProcessDefinitionName != "Expenses" || it.ProcessDefinitionKey != "exp" {
+		t.Errorf("t3 = %+v", it)
+	}
+	if it := items["t4"]; !it.Claimable || !reflect.DeepEqual(it.CandidateGroups, []string{"hr"}) {
+		t.Errorf("t4 = %+v", it)
+	}
+	if c.definitionCalls != 1 {
+		t.Errorf("definitions loaded %d times, want once", c.definitionCalls)
+	}
+}
+
+func TestLoadGroupAndSort(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		groupBy GroupBy
+		sortBy  SortBy
+		want    map[string][]string // Item ids by group title, in order
+		order   []string            // Group titles
+	}{
+		{
+			name:  "none by due date",
+			want:  map[string][]string{"All": {"t1", "t3", "t4", "t5"}},
+			order: []string{"All"},
+		},
+		{
+			name:    "assignment",
+			groupBy: GroupByAssignment,
+			want:    map[string][]string{"Assigned": {"t1", "t3"}, "Claimable": {"t4", "t5"}},
+			order:   []string{"Assigned", "Claimable"},
+		},
+		{
+			name:    "sla",
+			groupBy: GroupBySLA,
+			want:    map[string][]string{"Overdue": {"t1"}, "At risk": {"t3"}, "On track": {"t4"}, "No due date": {"t5"}},
+			order:   []string{"Overdue", "At risk", "On track", "No due date"},
+		},
+		{
+			name:    "process by priority",
+			groupBy: GroupByProcess,
+			sortBy:  SortByPriority,
+			want:    map[string][]string{"": {"t5"}, "Expenses": {"t4", "t3"}, "Leave": {"t1"}},
+			order:   []string{"", "Expenses", "Leave"},
+		},
+		{
+			name:    "task definition by created",
+			groupBy: GroupByTaskDefinition,
+			sortBy:  SortByCreated,
+			want:    map[string][]string{"Approve": {"t1"}, "Check": {"t3"}, "Claim me": {"t4"}, "Not mine": {"t5"}},
+			order:   []string{"Approve", "Check", "Claim me", "Not mine"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, err := Load(context.Background(), newFakeClient(), "bob", Options{GroupBy: tt.groupBy, SortBy: tt.sortBy, Now: func() time.Time { return now }})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var order []string
+			got := map[string][]string{}
+			for _, g := range box.Groups {
+				order = append(order, g.Title)
+				for _, it := range g.Items {
+					got[g.Title] = append(got[g.Title], it.ID)
+				}
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("groups = %q, want %q", order, tt.order)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRequiresUser(t *testing.T) {
+	if _, err := Load(context.Background(), newFakeClient(), "", Options{}); err == nil {
+		t.Error("got no error")
+	}
+}
+
+// contextClient is a fakeClient recording the context of WithContext
+type contextClient struct {
+	*fakeClient
+	ctx context.Context
+}
+
+func (c *contextClient) WithContext(ctx context.Context) Client {
+	return &contextClient{fakeClient: c.fakeClient, ctx: ctx}
+}
+
+func (c *contextClient) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	if c.ctx == nil {
+		return nil, errors.New("request without context ")
+	}
+	return c.fakeClient.QueryTasks(params)
+}
+
+func TestLoadWithContext(t *testing.T) {
+	if _, err := Load(context.Background(), &contextClient{fakeClient: newFakeClient()}, "bob", Options{}); err != nil {
+		t.Errorf("ContextClient: %v", err)
+	}
+
+	// The requests of an ActClient are cancelled with ctx
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	c, _ := activiti.NewClient("token", srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := Load(ctx, c, "bob", Options{})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Load was not cancelled")
+	}
+}
@@ -0,0 +1,83 @@
+package inbox
+
+import (
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const (
+	// DefaultAtRisk is SLAPolicy.AtRisk when 0
+	DefaultAtRisk = 24 * time.Hour
+	// DefaultHighPriority is SLAPolicy.HighPriority when 0, the engine default priority is 50
+	DefaultHighPriority = 75
+	// DefaultHighPriorityAtRisk is SLAPolicy.HighPriorityAtRisk when 0
+	DefaultHighPriorityAtRisk = 72 * time.Hour
+)
+
+// SLAStatus is the state of a task against its due date
+type SLAStatus string
+
+const (
+	SLAOverdue SLAStatus = "OVERDUE"  // Past the due date
+	SLAAtRisk  SLAStatus = "AT_RISK"  // Due within the at risk window
+	SLAOnTrack SLAStatus = "ON_TRACK" // Due later
+	SLANone    SLAStatus = "NONE"     // No due date
+)
+
+// severity orders statuses from the most urgent
+func (s SLAStatus) severity() int {
+	switch s {
+	case SLAOverdue:
+		return 0
+	case SLAAtRisk:
+		return 1
+	case SLAOnTrack:
+		return 2
+	}
+	return 3
+}
+
+// SLAPolicy decides when a task is at risk. High priority tasks are at risk earlier
+type SLAPolicy struct {
+	AtRisk             time.Duration // Window before the due date of normal tasks
+	HighPriority       int           // Tasks of this priority or more are high priority
+	HighPriorityAtRisk time.Duration // Window before the due date of high priority tasks
+}
+
+func (p SLAPolicy) withDefaults() SLAPolicy {
+	if p.AtRisk <= 0 {
+		p.AtRisk = DefaultAtRisk
+	}
+	if p.HighPriority <= 0 {
+		p.HighPriority = DefaultHighPriority
+	}
+	if p.HighPriorityAtRisk <= 0 {
+		p.HighPriorityAtRisk = DefaultHighPriorityAtRisk
+	}
+	return p
+}
+
+// Status returns the SLA status of t at now and its parsed due date, zero without one
+func (p SLAPolicy) Status(t activiti.Task, now time.Time) (SLAStatus, time.Time) {
+	if t.DueDate == "" {
+		return SLANone, time.Time{}
+	}
+	due, err := activiti.ParseDate(t.DueDate)
+	if err != nil {
+		return SLANone, time.Time{}
+	}
+
+	p = p.withDefaults()
+	window := p.AtRisk
+	if t.Priority >= p.HighPriority {
+		window = p.HighPriorityAtRisk
+	}
+	switch {
+	case now.After(due):
+		return SLAOverdue, due
+	case due.Sub(now) <= window:
+		return SLAAtRisk, due
+	}
+	return SLAOnTrack, due
+}
@@ -0,0 +1,41 @@
+package inbox
+
+import (
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+func TestSLAPolicyStatus(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		policy   SLAPolicy
+		task     activiti.Task
+		want     SLAStatus
+		wantDate bool
+	}{
+		{"no due date", SLAPolicy{}, activiti.Task{}, SLANone, false},
+		{"invalid due date", SLAPolicy{}, activiti.Task{DueDate: "tomorrow"}, SLANone, false},
+		{"overdue", SLAPolicy{}, activiti.Task{DueDate: "2026-01-10T11:59:00.000+0000"}, SLAOverdue, true},
+		{"due now", SLAPolicy{}, activiti.Task{DueDate: "2026-01-10T12:00:00Z"}, SLAAtRisk, true},
+		{"within default window", SLAPolicy{}, activiti.Task{DueDate: "2026-01-11T12:00:00Z"}, SLAAtRisk, true},
+		{"past default window", SLAPolicy{}, activiti.Task{DueDate: "2026-01-11T12:00:01Z"}, SLAOnTrack, true},
+		{"high priority window", SLAPolicy{}, activiti.Task{DueDate: "2026-01-13T00:00:00Z", Priority: 75}, SLAAtRisk, true},
+		{"below high priority", SLAPolicy{}, activiti.Task{DueDate: "2026-01-13T00:00:00Z", Priority: 74}, SLAOnTrack, true},
+		{"custom window", SLAPolicy{AtRisk: time.Hour}, activiti.Task{DueDate: "2026-01-10T14:00:00Z"}, SLAOnTrack, true},
+		{"custom high priority", SLAPolicy{HighPriority: 10, HighPriorityAtRisk: 3 * time.Hour}, activiti.Task{DueDate: "2026-01-10T14:00:00Z", Priority: 10}, SLAAtRisk, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, due := tt.policy.Status(tt.task, now)
+			if got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+			if due.IsZero() == tt.wantDate {
+				t.Errorf("due = %v, want a date %v", due, tt.wantDate)
+			}
+		})
+	}
+}
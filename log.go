@@ -0,0 +1,144 @@
+package activiti
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxLogBodySize is the logged body size limit when LogOptions.MaxBodySize is 0
+const DefaultMaxLogBodySize = 4096
+
+// RequestIDHeader carries the id which ties a request to its log record
+const RequestIDHeader = "X-Request-Id"
+
+// redactedFieldsPattern redacts secret fields in bodies which are not valid JSON,
+// typically because they were truncated
+var redactedFieldsPattern = func() *regexp.Regexp {
+	fields := make([]string, 0, len(redactedFields))
+	for f := range redactedFields {
+		fields = append(fields, regexp.QuoteMeta(f))
+	}
+	sort.Strings(fields)
+	return regexp.MustCompile(`(?i)"(` + strings.Join(fields, "|") + `)"\s*:\s*"(?:[^"\\]|\\.)*"?`)
+}()
+
+// log writes one record per request to the logger.
+// Successful requests are logged at info level, error responses at warn and
+// transport failures at error level
+func (c *ActClient) log(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	logger, opts := c.logger()
+	if logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelError
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		level = slog.LevelWarn
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("request_id", req.Header.Get(RequestIDHeader)),
+		slog.String("operation", Operation(req)),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("latency", latency),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if opts.LogHeaders {
+		attrs = append(attrs, slog.Any("request_headers", redactHeader(req.Header)))
+		if resp != nil {
+			attrs = append(attrs, slog.Any("response_headers", redactHeader(resp.Header)))
+		}
+	}
+
+	if opts.LogBodies {
+		if req.GetBody != nil {
+			if body, rerr := req.GetBody(); rerr == nil {
+				data, _ := ioutil.ReadAll(io.LimitReader(body, int64(c.maxLogBodySize())+1))
+				body.Close()
+				attrs = append(attrs, slog.String("request_body", c.logBody(data)))
+			}
+		}
+		if resp != nil && resp.Body != nil {
+			// Only peek at the response, the caller still reads the whole body
+			data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, int64(c.maxLogBodySize())+1))
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+			attrs = append(attrs, slog.String("response_body", c.logBody(data)))
+		}
+	}
+
+	logger.LogAttrs(ctx, level, "activiti request", attrs...)
+}
+
+// logging reports whether requests are logged
+func (c *ActClient) logging() bool {
+	return c.Logger != nil || c.Log != nil
+}
+
+// logger returns the logger of the client and its options.
+// The deprecated Log writer logs everything through a text handler
+func (c *ActClient) logger() (*slog.Logger, LogOptions) {
+	if c.Logger != nil || c.Log == nil {
+		return c.Logger, c.LogOptions
+	}
+	opts := c.LogOptions
+	opts.LogHeaders, opts.LogBodies = true, true
+	handler := slog.NewTextHandler(c.Log, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(handler), opts
+}
+
+// maxLogBodySize returns the logged body size limit
+func (c *ActClient) maxLogBodySize() int {
+	if c.LogOptions.MaxBodySize <= 0 {
+		return DefaultMaxLogBodySize
+	}
+	return c.LogOptions.MaxBodySize
+}
+
+// logBody truncates a body to the size limit and hides secret fields, such as ActUser.Password
+func (c *ActClient) logBody(data []byte) string {
+	limit := c.maxLogBodySize()
+	truncated := len(data) > limit
+	if truncated {
+		data = data[:limit]
+	} else {
+		data = redactBody(data)
+	}
+	data = redactedFieldsPattern.ReplaceAll(data, []byte(`"$1":"`+redacted+`"`))
+	if truncated {
+		return string(data) + "...(truncated)"
+	}
+	return string(data)
+}
+
+// newRequestID returns a random id for a request
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,84 @@
+package activiti
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *ActClient)
+		want  bool
+	}{
+		{"no logger", func(c *ActClient) {}, false},
+		{"opt in", func(c *ActClient) { c.LogOptions.RequestID = true }, true},
+		{"logger", func(c *ActClient) { c.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)), LogOptions{}) }, true},
+		{"deprecated log", func(c *ActClient) { c.Log = io.Discard }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(RequestIDHeader)
+				w.Write([]byte(`{"entry":{"id":"t1"}}`))
+			}))
+			defer srv.Close()
+
+			c, _ := NewClient("token", srv.URL)
+			tt.setup(c)
+			if _, err := c.GetTask("t1"); err != nil {
+				t.Fatal(err)
+			}
+			if (got != "") != tt.want {
+				t.Errorf("%s = %q, want set %v", RequestIDHeader, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecatedLog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entry":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	c, _ := NewClient("token", srv.URL)
+	c.Log = buf
+	if _, err := c.GetTask("t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"activiti request", "operation=GetTask", "response_body=", "Authorization:[REDACTED]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestLogBody(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		body string
+		want string
+	}{
+		{"json", 0, `{"name":"n","password":"pw"}`, `{"name":"n","password":"REDACTED"}`},
+		{"truncated", 20, `{"password":"secret-value","name":"n"}`, `{"password":"REDACTED"...(truncated)`},
+		{"text", 0, "plain", "plain"},
+	}
+	for _, tt := range tests {
+		c := &ActClient{LogOptions: LogOptions{MaxBodySize: tt.max}}
+		if got := c.logBody([]byte(tt.body)); got != tt.want {
+			t.Errorf("%s: logBody = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Package metrics exports the process and task backlog of Activiti Cloud as Prometheus gauges.
+//
+//	exp := metrics.NewExporter(client, metrics.Options{Interval: time.Minute})
+//	go exp.Run(ctx)
+//	http.Handle("/metrics", exp.Handler())
+//
+// The query service is polled through ActClient on every interval, scrapes only
+// read the values of the last poll. To register the gauges in an existing registry
+// pass it as Options.Registerer, Handler then serves that registry.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// DefaultInterval is the poll interval when Options.Interval is 0
+	DefaultInterval = time.Minute
+	// DefaultPageSize is the query page size when Options.PageSize is 0
+	DefaultPageSize = 100
+)
+
+var (
+	// DefaultTaskStatuses are the task statuses counted as open
+	DefaultTaskStatuses = []string{"CREATED", "ASSIGNED", "SUSPENDED"}
+	// DefaultInstanceStatuses are the process instance statuses which are polled
+	DefaultInstanceStatuses = []string{"RUNNING", "SUSPENDED"}
+)
+
+type (
+	// Querier is the part of ActClient used by the exporter
+	Querier interface {
+		QueryTasks(params url.Values) (*activiti.ActListTasks, error)
+		QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error)
+	}
+
+	// Options configures an Exporter
+	Options struct {
+		Interval         time.Duration         // Time between polls
+		PageSize         int                   // maxItems of each query page
+		TaskStatuses     []string              // Task statuses counted as open
+		InstanceStatuses []string              // Process instance statuses to export
+		Namespace        string                // Metric name prefix, "activiti" when empty
+		Registerer       prometheus.Registerer // Register the gauges there instead of a private registry
+		Gatherer         prometheus.Gatherer   // Served by Handler with Registerer, the Registerer itself when it is a Gatherer
+	}
+
+	// Exporter polls the query service and holds the gauges
+	Exporter struct {
+		q        Querier
+		opts     Options
+		gatherer prometheus.Gatherer
+		now      func() time.Time
+
+		openTasks      *prometheus.GaugeVec
+		oldestOpenTask *prometheus.GaugeVec
+		instances      *prometheus.GaugeVec
+		pollErrors     prometheus.Counter
+		lastSuccess    prometheus.Gauge
+	}
+)
+
+// NewExporter returns an Exporter polling q, usually an *activiti.ActClient
+func NewExporter(q Querier, opts Options) *Exporter {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if len(opts.TaskStatuses) == 0 {
+		opts.TaskStatuses = DefaultTaskStatuses
+	}
+	if len(opts.InstanceStatuses) == 0 {
+		opts.InstanceStatuses = DefaultInstanceStatuses
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "activiti"
+	}
+
+	e := &Exporter{q: q, opts: opts, now: time.Now}
+	e.openTasks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: opts.Namespace,
+		Name:      "open_tasks",
+		Help:      "Number of open tasks by task definition key and assignee.",
+	}, []string{"task_definition_key", "assignee"})
+	e.oldestOpenTask = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: opts.Namespace,
+		Name:      "oldest_open_task_age_seconds",
+		Help:      "Age of the oldest open task by task definition key.",
+	}, []string{"task_definition_key"})
+	e.instances = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: opts.Namespace,
+		Name:      "process_instances",
+		Help:      "Number of process instances by status and process definition key.",
+	}, []string{"status", "process_definition_key"})
+	e.pollErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: opts.Namespace,
+		Name:      "exporter_poll_errors_total",
+		Help:      "Number of failed polls of the query service.",
+	})
+	e.lastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: opts.Namespace,
+		Name:      "exporter_last_success_timestamp_seconds",
+		Help:      "Time of the last successful poll of the query service.",
+	})
+
+	reg := opts.Registerer
+	e.gatherer = opts.Gatherer
+	if reg == nil {
+		registry := prometheus.NewRegistry()
+		reg, e.gatherer = registry, registry
+	} else if g, ok := reg.(prometheus.Gatherer); ok && e.gatherer == nil {
+		e.gatherer = g
+	}
+	reg.MustRegister(e.openTasks, e.oldestOpenTask, e.instances, e.pollErrors, e.lastSuccess)
+
+	return e
+}
+
+// Handler serves the gauges from the private registry, or with Options.Registerer from
+// Options.Gatherer or the Registerer. Without a Gatherer it responds 500, serve the
+// registry of the Registerer instead
+func (e *Exporter) Handler() http.Handler {
+	if e.gatherer == nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "metrics: Options.Registerer is not a Gatherer and Options.Gatherer is nil", http.StatusInternalServerError)
+		})
+	}
+	return promhttp.HandlerFor(e.gatherer, promhttp.HandlerOpts{})
+}
+
+// Run polls immediately and then on every interval until ctx is done
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll queries open tasks and process instances once and updates the gauges.
+// The gauges keep their previous values when the poll fails
+func (e *Exporter) Poll(ctx context.Context) error {
+	tasks, err := e.openTaskList(ctx)
+	if err != nil {
+		e.pollErrors.Inc()
+		return err
+	}
+	instances, err := e.instanceList(ctx)
+	if err != nil {
+		e.pollErrors.Inc()
+		return err
+	}
+
+	now := e.now()
+	e.openTasks.Reset()
+	e.oldestOpenTask.Reset()
+	oldest := map[string]time.Time{}
+	for _, t := range tasks {
+		e.openTasks.WithLabelValues(t.TaskDefinitionKey, t.Assignee).Inc()
+		created, err := activiti.ParseDate(t.CreatedDate)
+		if err != nil {
+			continue
+		}
+		if o, ok := oldest[t.TaskDefinitionKey]; !ok || created.Before(o) {
+			oldest[t.TaskDefinitionKey] = created
+		}
+	}
+	for key, created := range oldest {
+		e.oldestOpenTask.WithLabelValues(key).Set(now.Sub(created).Seconds())
+	}
+
+	e.instances.Reset()
+	for _, pi := range instances {
+		e.instances.WithLabelValues(pi.Status, pi.ProcessDefinitionKey).Inc()
+	}
+
+	e.lastSuccess.Set(float64(now.Unix()))
+	return nil
+}
+
+// openTaskList pages through the tasks with an open status
+func (e *Exporter) openTaskList(ctx context.Context) ([]activiti.Task, error) {
+	var tasks []activiti.Task
+	for _, status := range e.opts.TaskStatuses {
+		for skip := 0; ; skip += e.opts.PageSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			page, err := e.q.QueryTasks(e.params(status, skip))
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range page.List.Tasks {
+				tasks = append(tasks, t.Task)
+			}
+			if !page.List.Pagination.HasMoreItems || len(page.List.Tasks) == 0 {
+				break
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// instanceList pages through the process instances with an exported status
+func (e *Exporter) instanceList(ctx context.Context) ([]activiti.ProcessInstance, error) {
+	var instances []activiti.ProcessInstance
+	for _, status := range e.opts.InstanceStatuses {
+		for skip := 0; ; skip += e.opts.PageSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			page, err := e.q.QueryProcessInstances(e.params(status, skip))
+			if err != nil {
+				return nil, err
+			}
+			for _, pi := range page.List.ProcessInstances {
+				instances = append(instances, pi.ProcessInstance)
+			}
+			if !page.List.Pagination.HasMoreItems || len(page.List.ProcessInstances) == 0 {
+				break
+			}
+		}
+	}
+	return instances, nil
+}
+
+// params returns the query filter of one page
+func (e *Exporter) params(status string, skip int) url.Values {
+	return url.Values{
+		"status":    {status},
+		"skipCount": {strconv.Itoa(skip)},
+		"maxItems":  {strconv.Itoa(e.opts.PageSize)},
+	}
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// fakeQuerier pages through the tasks and instances of a status
+type fakeQuerier struct {
+	tasks     map[string][]activiti.Task
+	instances map[string][]activiti.ProcessInstance
+}
+
+func (q *fakeQuerier) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	all := q.tasks[params.Get("status")]
+	skip, _ := strconv.Atoi(params.Get("skipCount"))
+	end := page(params, len(all))
+	res := &activiti.ActListTasks{}
+	for i := skip; i < end; i++ {
+		res.List.Tasks = append(res.List.Tasks, activiti.ActTask{Task: all[i]})
+	}
+	res.List.Pagination.HasMoreItems = end < len(all)
+	return res, nil
+}
+
+func (q *fakeQuerier) QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error) {
+	all := q.instances[params.Get("status")]
+	skip, _ := strconv.Atoi(params.Get("skipCount"))
+	end := page(params, len(all))
+	res := &activiti.ActListProcessInstances{}
+	for i := skip; i < end; i++ {
+		res.List.ProcessInstances = append(res.List.ProcessInstances, activiti.ActProcessInstance{ProcessInstance: all[i]})
+	}
+	res.List.Pagination.HasMoreItems = end < len(all)
+	return res, nil
+}
+
+// page returns the end of the page requested by params
+func page(params url.Values, n int) int {
+	skip, _ := strconv.Atoi(params.Get("skipCount"))
+	max, _ := strconv.Atoi(params.Get("maxItems"))
+	if skip+max > n {
+		return n
+	}
+	return skip + max
+}
+
+func TestExporter(t *testing.T) {
+	q := &fakeQuerier{
+		tasks: map[string][]activiti.Task{
+			"CREATED":  {{TaskDefinitionKey: "approve", CreatedDate: "2026-10-19T10:00:00.000+0000"}, {TaskDefinitionKey: "approve", CreatedDate: "2026-10-19T11:00:00.000+0000"}},
+			"ASSIGNED": {{TaskDefinitionKey: "review", Assignee: "bob"}},
+		},
+		instances: map[string][]activiti.ProcessInstance{
+			"RUNNING": {{Status: "RUNNING", ProcessDefinitionKey: "leave"}},
+		},
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	shared := prometheus.NewRegistry()
+	tests := []struct {
+		name   string
+		opts   Options
+		status int
+	}{
+		{"private registry", Options{}, http.StatusOK},
+		{"registry", Options{Registerer: shared}, http.StatusOK},
+		{"registerer and gatherer", Options{Registerer: prometheus.WrapRegistererWithPrefix("x_", prometheus.NewRegistry()), Gatherer: prometheus.NewRegistry()}, http.StatusOK},
+		{"registerer only", Options{Registerer: prometheus.WrapRegistererWithPrefix("x_", prometheus.NewRegistry())}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.PageSize = 1
+			e := NewExporter(q, tt.opts)
+			e.now = func() time.Time { return now }
+			if err := e.Poll(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			rec := httptest.NewRecorder()
+			e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if rec.Code != http.StatusOK || tt.opts.Gatherer != nil {
+				return
+			}
+			body, _ := io.ReadAll(rec.Body)
+			for _, want := range []string{
+				`activiti_open_tasks{assignee="",task_definition_key="approve"} 2`,
+				`activiti_open_tasks{assignee="bob",task_definition_key="review"} 1`,
+				`activiti_oldest_open_task_age_seconds{task_definition_key="approve"} 7200`,
+				`activiti_process_instances{process_definition_key="leave",status="RUNNING"} 1`,
+			} {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("metrics do not contain %s:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package activiti
+
+import (
+	"net/http"
+)
+
+type (
+	// RoundTripFunc sends a request and returns its response, like http.RoundTripper
+	RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+	// Middleware wraps the sending of every request made by ActClient.
+	// It may change the request, the response, or not call next at all
+	Middleware func(next RoundTripFunc) RoundTripFunc
+)
+
+// Use appends middleware to the client. The first middleware added is the
+// outermost one, it sees the request first and the response last.
+// Middleware run after default headers and the Authorization header are set
+func (c *ActClient) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// BeforeRequest returns a Middleware calling fn before each request is sent,
+// for example to add tenant or correlation id headers.
+// If fn returns an error the request is not sent
+func BeforeRequest(fn func(req *http.Request) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// AfterResponse returns a Middleware calling fn with each response received.
+// If fn returns an error the response body is closed and the error returned to the caller
+func AfterResponse(fn func(resp *http.Response) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+			if err = fn(resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+}
+
+// do sends the request through the middleware chain
+func (c *ActClient) do(req *http.Request) (*http.Response, error) {
+	if (c.logging() || c.LogOptions.RequestID) && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
+
+	send := RoundTripFunc(c.roundTrip)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		send = c.middleware[i](send)
+	}
+	return send(req)
+}
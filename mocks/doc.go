@@ -0,0 +1,4 @@
+// Package mocks contains fakes of the activiti service interfaces generated by moq.
+// Set the <Method>Func fields to control results, and inspect <Method>Calls() for assertions.
+// Regenerate with `go generate` in the root package after changing an interface.
+package mocks
@@ -0,0 +1,3753 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/lihongchen/go-activiti-rest"
+	"net/url"
+	"sync"
+)
+
+// Ensure, that ProcessDefinitionServiceMock does implement activiti.ProcessDefinitionService.
+// If this is not the case, regenerate this file with moq.
+var _ activiti.ProcessDefinitionService = &ProcessDefinitionServiceMock{}
+
+// ProcessDefinitionServiceMock is a mock implementation of activiti.ProcessDefinitionService.
+//
+//	func TestSomethingThatUsesProcessDefinitionService(t *testing.T) {
+//
+//		// make and configure a mocked activiti.ProcessDefinitionService
+//		mockedProcessDefinitionService := &ProcessDefinitionServiceMock{
+//			FindProcessDefinitionsFunc: func(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error) {
+//				panic("mock out the FindProcessDefinitions method")
+//			},
+//			GetLatestProcessDefinitionFunc: func(key string) (*activiti.ProcessDefinition, error) {
+//				panic("mock out the GetLatestProcessDefinition method")
+//			},
+//			GetProcessDefinitionFunc: func(pid string) (*activiti.ActProcessDefinition, error) {
+//				panic("mock out the GetProcessDefinition method")
+//			},
+//			GetProcessDefinitionMetaFunc: func(pid string) (*activiti.ActProcessDefinitionMeta, error) {
+//				panic("mock out the GetProcessDefinitionMeta method")
+//			},
+//			GetProcessDefinitionModelFunc: func(pid string) ([]byte, error) {
+//				panic("mock out the GetProcessDefinitionModel method")
+//			},
+//			GetProcessDefinitionsFunc: func() (activiti.ActListProcessDefinitions, error) {
+//				panic("mock out the GetProcessDefinitions method")
+//			},
+//		}
+//
+//		// use mockedProcessDefinitionService in code that requires activiti.ProcessDefinitionService
+//		// and then make assertions.
+//
+//	}
+type ProcessDefinitionServiceMock struct {
+	// FindProcessDefinitionsFunc mocks the FindProcessDefinitions method.
+	FindProcessDefinitionsFunc func(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error)
+
+	// GetLatestProcessDefinitionFunc mocks the GetLatestProcessDefinition method.
+	GetLatestProcessDefinitionFunc func(key string) (*activiti.ProcessDefinition, error)
+
+	// GetProcessDefinitionFunc mocks the GetProcessDefinition method.
+	GetProcessDefinitionFunc func(pid string) (*activiti.ActProcessDefinition, error)
+
+	// GetProcessDefinitionMetaFunc mocks the GetProcessDefinitionMeta method.
+	GetProcessDefinitionMetaFunc func(pid string) (*activiti.ActProcessDefinitionMeta, error)
+
+	// GetProcessDefinitionModelFunc mocks the GetProcessDefinitionModel method.
+	GetProcessDefinitionModelFunc func(pid string) ([]byte, error)
+
+	// GetProcessDefinitionsFunc mocks the GetProcessDefinitions method.
+	GetProcessDefinitionsFunc func() (activiti.ActListProcessDefinitions, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindProcessDefinitions holds details about calls to the FindProcessDefinitions method.
+		FindProcessDefinitions []struct {
+			// F is the f argument value.
+			F activiti.ProcessDefinitionFilter
+		}
+		// GetLatestProcessDefinition holds details about calls to the GetLatestProcessDefinition method.
+		GetLatestProcessDefinition []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// GetProcessDefinition holds details about calls to the GetProcessDefinition method.
+		GetProcessDefinition []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitionMeta holds details about calls to the GetProcessDefinitionMeta method.
+		GetProcessDefinitionMeta []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitionModel holds details about calls to the GetProcessDefinitionModel method.
+		GetProcessDefinitionModel []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitions holds details about calls to the GetProcessDefinitions method.
+		GetProcessDefinitions []struct {
+		}
+	}
+	lockFindProcessDefinitions     sync.RWMutex
+	lockGetLatestProcessDefinition sync.RWMutex
+	lockGetProcessDefinition       sync.RWMutex
+	lockGetProcessDefinitionMeta   sync.RWMutex
+	lockGetProcessDefinitionModel  sync.RWMutex
+	lockGetProcessDefinitions      sync.RWMutex
+}
+
+// FindProcessDefinitions calls FindProcessDefinitionsFunc.
+func (mock *ProcessDefinitionServiceMock) FindProcessDefinitions(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error) {
+	if mock.FindProcessDefinitionsFunc == nil {
+		panic("ProcessDefinitionServiceMock.FindProcessDefinitionsFunc: method is nil but ProcessDefinitionService.FindProcessDefinitions was just called")
+	}
+	callInfo := struct {
+		F activiti.ProcessDefinitionFilter
+	}{
+		F: f,
+	}
+	mock.lockFindProcessDefinitions.Lock()
+	mock.calls.FindProcessDefinitions = append(mock.calls.FindProcessDefinitions, callInfo)
+	mock.lockFindProcessDefinitions.Unlock()
+	return mock.FindProcessDefinitionsFunc(f)
+}
+
+// FindProcessDefinitionsCalls gets all the calls that were made to FindProcessDefinitions.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.FindProcessDefinitionsCalls())
+func (mock *ProcessDefinitionServiceMock) FindProcessDefinitionsCalls() []struct {
+	F activiti.ProcessDefinitionFilter
+} {
+	var calls []struct {
+		F activiti.ProcessDefinitionFilter
+	}
+	mock.lockFindProcessDefinitions.RLock()
+	calls = mock.calls.FindProcessDefinitions
+	mock.lockFindProcessDefinitions.RUnlock()
+	return calls
+}
+
+// GetLatestProcessDefinition calls GetLatestProcessDefinitionFunc.
+func (mock *ProcessDefinitionServiceMock) GetLatestProcessDefinition(key string) (*activiti.ProcessDefinition, error) {
+	if mock.GetLatestProcessDefinitionFunc == nil {
+		panic("ProcessDefinitionServiceMock.GetLatestProcessDefinitionFunc: method is nil but ProcessDefinitionService.GetLatestProcessDefinition was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockGetLatestProcessDefinition.Lock()
+	mock.calls.GetLatestProcessDefinition = append(mock.calls.GetLatestProcessDefinition, callInfo)
+	mock.lockGetLatestProcessDefinition.Unlock()
+	return mock.GetLatestProcessDefinitionFunc(key)
+}
+
+// GetLatestProcessDefinitionCalls gets all the calls that were made to GetLatestProcessDefinition.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.GetLatestProcessDefinitionCalls())
+func (mock *ProcessDefinitionServiceMock) GetLatestProcessDefinitionCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockGetLatestProcessDefinition.RLock()
+	calls = mock.calls.GetLatestProcessDefinition
+	mock.lockGetLatestProcessDefinition.RUnlock()
+	return calls
+}
+
+// GetProcessDefinition calls GetProcessDefinitionFunc.
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinition(pid string) (*activiti.ActProcessDefinition, error) {
+	if mock.GetProcessDefinitionFunc == nil {
+		panic("ProcessDefinitionServiceMock.GetProcessDefinitionFunc: method is nil but ProcessDefinitionService.GetProcessDefinition was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinition.Lock()
+	mock.calls.GetProcessDefinition = append(mock.calls.GetProcessDefinition, callInfo)
+	mock.lockGetProcessDefinition.Unlock()
+	return mock.GetProcessDefinitionFunc(pid)
+}
+
+// GetProcessDefinitionCalls gets all the calls that were made to GetProcessDefinition.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.GetProcessDefinitionCalls())
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinition.RLock()
+	calls = mock.calls.GetProcessDefinition
+	mock.lockGetProcessDefinition.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitionMeta calls GetProcessDefinitionMetaFunc.
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionMeta(pid string) (*activiti.ActProcessDefinitionMeta, error) {
+	if mock.GetProcessDefinitionMetaFunc == nil {
+		panic("ProcessDefinitionServiceMock.GetProcessDefinitionMetaFunc: method is nil but ProcessDefinitionService.GetProcessDefinitionMeta was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinitionMeta.Lock()
+	mock.calls.GetProcessDefinitionMeta = append(mock.calls.GetProcessDefinitionMeta, callInfo)
+	mock.lockGetProcessDefinitionMeta.Unlock()
+	return mock.GetProcessDefinitionMetaFunc(pid)
+}
+
+// GetProcessDefinitionMetaCalls gets all the calls that were made to GetProcessDefinitionMeta.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.GetProcessDefinitionMetaCalls())
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionMetaCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinitionMeta.RLock()
+	calls = mock.calls.GetProcessDefinitionMeta
+	mock.lockGetProcessDefinitionMeta.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitionModel calls GetProcessDefinitionModelFunc.
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionModel(pid string) ([]byte, error) {
+	if mock.GetProcessDefinitionModelFunc == nil {
+		panic("ProcessDefinitionServiceMock.GetProcessDefinitionModelFunc: method is nil but ProcessDefinitionService.GetProcessDefinitionModel was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinitionModel.Lock()
+	mock.calls.GetProcessDefinitionModel = append(mock.calls.GetProcessDefinitionModel, callInfo)
+	mock.lockGetProcessDefinitionModel.Unlock()
+	return mock.GetProcessDefinitionModelFunc(pid)
+}
+
+// GetProcessDefinitionModelCalls gets all the calls that were made to GetProcessDefinitionModel.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.GetProcessDefinitionModelCalls())
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionModelCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinitionModel.RLock()
+	calls = mock.calls.GetProcessDefinitionModel
+	mock.lockGetProcessDefinitionModel.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitions calls GetProcessDefinitionsFunc.
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitions() (activiti.ActListProcessDefinitions, error) {
+	if mock.GetProcessDefinitionsFunc == nil {
+		panic("ProcessDefinitionServiceMock.GetProcessDefinitionsFunc: method is nil but ProcessDefinitionService.GetProcessDefinitions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProcessDefinitions.Lock()
+	mock.calls.GetProcessDefinitions = append(mock.calls.GetProcessDefinitions, callInfo)
+	mock.lockGetProcessDefinitions.Unlock()
+	return mock.GetProcessDefinitionsFunc()
+}
+
+// GetProcessDefinitionsCalls gets all the calls that were made to GetProcessDefinitions.
+// Check the length with:
+//
+//	len(mockedProcessDefinitionService.GetProcessDefinitionsCalls())
+func (mock *ProcessDefinitionServiceMock) GetProcessDefinitionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProcessDefinitions.RLock()
+	calls = mock.calls.GetProcessDefinitions
+	mock.lockGetProcessDefinitions.RUnlock()
+	return calls
+}
+
+// Ensure, that ProcessInstanceServiceMock does implement activiti.ProcessInstanceService.
+// If this is not the case, regenerate this file with moq.
+var _ activiti.ProcessInstanceService = &ProcessInstanceServiceMock{}
+
+// ProcessInstanceServiceMock is a mock implementation of activiti.ProcessInstanceService.
+//
+//	func TestSomethingThatUsesProcessInstanceService(t *testing.T) {
+//
+//		// make and configure a mocked activiti.ProcessInstanceService
+//		mockedProcessInstanceService := &ProcessInstanceServiceMock{
+//			AdminGetProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the AdminGetProcessInstance method")
+//			},
+//			AdminSetProcessVariablesFunc: func(pid string, variables map[string]interface{}) error {
+//				panic("mock out the AdminSetProcessVariables method")
+//			},
+//			CancelFunc: func(key string) error {
+//				panic("mock out the Cancel method")
+//			},
+//			GetProcessDiagramFunc: func(pid string) ([]byte, error) {
+//				panic("mock out the GetProcessDiagram method")
+//			},
+//			GetProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the GetProcessInstance method")
+//			},
+//			GetProcessInstancesFunc: func() (*activiti.ActListProcessInstances, error) {
+//				panic("mock out the GetProcessInstances method")
+//			},
+//			GetProcessVariablesFunc: func(pid string) (*activiti.ActListProcessVariables, error) {
+//				panic("mock out the GetProcessVariables method")
+//			},
+//			ProcessInstancesTasksFunc: func(key string) (*activiti.ActListTasks, error) {
+//				panic("mock out the ProcessInstancesTasks method")
+//			},
+//			QueryProcessInstancesFunc: func(params url.Values) (*activiti.ActListProcessInstances, error) {
+//				panic("mock out the QueryProcessInstances method")
+//			},
+//			ResumeProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the ResumeProcessInstance method")
+//			},
+//			SetProcessVariablesFunc: func(pid string, variables map[string]interface{}) error {
+//				panic("mock out the SetProcessVariables method")
+//			},
+//			StartProcessInstanceFunc: func(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error) {
+//				panic("mock out the StartProcessInstance method")
+//			},
+//			StartProcessInstanceByIdFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceById method")
+//			},
+//			StartProcessInstanceByKeyFunc: func(key string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceByKey method")
+//			},
+//			StartProcessInstanceOnceFunc: func(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceOnce method")
+//			},
+//			StartProcessInstanceWithBusinessKeyAndVariablesFunc: func(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceWithBusinessKeyAndVariables method")
+//			},
+//			StartProcessInstanceWithVariablesFunc: func(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceWithVariables method")
+//			},
+//			SuspendProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the SuspendProcessInstance method")
+//			},
+//		}
+//
+//		// use mockedProcessInstanceService in code that requires activiti.ProcessInstanceService
+//		// and then make assertions.
+//
+//	}
+type ProcessInstanceServiceMock struct {
+	// AdminGetProcessInstanceFunc mocks the AdminGetProcessInstance method.
+	AdminGetProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// AdminSetProcessVariablesFunc mocks the AdminSetProcessVariables method.
+	AdminSetProcessVariablesFunc func(pid string, variables map[string]interface{}) error
+
+	// CancelFunc mocks the Cancel method.
+	CancelFunc func(key string) error
+
+	// GetProcessDiagramFunc mocks the GetProcessDiagram method.
+	GetProcessDiagramFunc func(pid string) ([]byte, error)
+
+	// GetProcessInstanceFunc mocks the GetProcessInstance method.
+	GetProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// GetProcessInstancesFunc mocks the GetProcessInstances method.
+	GetProcessInstancesFunc func() (*activiti.ActListProcessInstances, error)
+
+	// GetProcessVariablesFunc mocks the GetProcessVariables method.
+	GetProcessVariablesFunc func(pid string) (*activiti.ActListProcessVariables, error)
+
+	// ProcessInstancesTasksFunc mocks the ProcessInstancesTasks method.
+	ProcessInstancesTasksFunc func(key string) (*activiti.ActListTasks, error)
+
+	// QueryProcessInstancesFunc mocks the QueryProcessInstances method.
+	QueryProcessInstancesFunc func(params url.Values) (*activiti.ActListProcessInstances, error)
+
+	// ResumeProcessInstanceFunc mocks the ResumeProcessInstance method.
+	ResumeProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// SetProcessVariablesFunc mocks the SetProcessVariables method.
+	SetProcessVariablesFunc func(pid string, variables map[string]interface{}) error
+
+	// StartProcessInstanceFunc mocks the StartProcessInstance method.
+	StartProcessInstanceFunc func(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error)
+
+	// StartProcessInstanceByIdFunc mocks the StartProcessInstanceById method.
+	StartProcessInstanceByIdFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceByKeyFunc mocks the StartProcessInstanceByKey method.
+	StartProcessInstanceByKeyFunc func(key string) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceOnceFunc mocks the StartProcessInstanceOnce method.
+	StartProcessInstanceOnceFunc func(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceWithBusinessKeyAndVariablesFunc mocks the StartProcessInstanceWithBusinessKeyAndVariables method.
+	StartProcessInstanceWithBusinessKeyAndVariablesFunc func(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceWithVariablesFunc mocks the StartProcessInstanceWithVariables method.
+	StartProcessInstanceWithVariablesFunc func(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error)
+
+	// SuspendProcessInstanceFunc mocks the SuspendProcessInstance method.
+	SuspendProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AdminGetProcessInstance holds details about calls to the AdminGetProcessInstance method.
+		AdminGetProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// AdminSetProcessVariables holds details about calls to the AdminSetProcessVariables method.
+		AdminSetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// Cancel holds details about calls to the Cancel method.
+		Cancel []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// GetProcessDiagram holds details about calls to the GetProcessDiagram method.
+		GetProcessDiagram []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessInstance holds details about calls to the GetProcessInstance method.
+		GetProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessInstances holds details about calls to the GetProcessInstances method.
+		GetProcessInstances []struct {
+		}
+		// GetProcessVariables holds details about calls to the GetProcessVariables method.
+		GetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// ProcessInstancesTasks holds details about calls to the ProcessInstancesTasks method.
+		ProcessInstancesTasks []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// QueryProcessInstances holds details about calls to the QueryProcessInstances method.
+		QueryProcessInstances []struct {
+			// Params is the params argument value.
+			Params url.Values
+		}
+		// ResumeProcessInstance holds details about calls to the ResumeProcessInstance method.
+		ResumeProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// SetProcessVariables holds details about calls to the SetProcessVariables method.
+		SetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// StartProcessInstance holds details about calls to the StartProcessInstance method.
+		StartProcessInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Opts is the opts argument value.
+			Opts activiti.StartOptions
+		}
+		// StartProcessInstanceById holds details about calls to the StartProcessInstanceById method.
+		StartProcessInstanceById []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// StartProcessInstanceByKey holds details about calls to the StartProcessInstanceByKey method.
+		StartProcessInstanceByKey []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// StartProcessInstanceOnce holds details about calls to the StartProcessInstanceOnce method.
+		StartProcessInstanceOnce []struct {
+			// Key is the key argument value.
+			Key string
+			// BusinessKey is the businessKey argument value.
+			BusinessKey string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+			// Policy is the policy argument value.
+			Policy activiti.ConflictPolicy
+		}
+		// StartProcessInstanceWithBusinessKeyAndVariables holds details about calls to the StartProcessInstanceWithBusinessKeyAndVariables method.
+		StartProcessInstanceWithBusinessKeyAndVariables []struct {
+			// Key is the key argument value.
+			Key string
+			// BusinessKey is the BusinessKey argument value.
+			BusinessKey string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// StartProcessInstanceWithVariables holds details about calls to the StartProcessInstanceWithVariables method.
+		StartProcessInstanceWithVariables []struct {
+			// Key is the key argument value.
+			Key string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// SuspendProcessInstance holds details about calls to the SuspendProcessInstance method.
+		SuspendProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+	}
+	lockAdminGetProcessInstance                         sync.RWMutex
+	lockAdminSetProcessVariables                        sync.RWMutex
+	lockCancel                                          sync.RWMutex
+	lockGetProcessDiagram                               sync.RWMutex
+	lockGetProcessInstance                              sync.RWMutex
+	lockGetProcessInstances                             sync.RWMutex
+	lockGetProcessVariables                             sync.RWMutex
+	lockProcessInstancesTasks                           sync.RWMutex
+	lockQueryProcessInstances                           sync.RWMutex
+	lockResumeProcessInstance                           sync.RWMutex
+	lockSetProcessVariables                             sync.RWMutex
+	lockStartProcessInstance                            sync.RWMutex
+	lockStartProcessInstanceById                        sync.RWMutex
+	lockStartProcessInstanceByKey                       sync.RWMutex
+	lockStartProcessInstanceOnce                        sync.RWMutex
+	lockStartProcessInstanceWithBusinessKeyAndVariables sync.RWMutex
+	lockStartProcessInstanceWithVariables               sync.RWMutex
+	lockSuspendProcessInstance                          sync.RWMutex
+}
+
+// AdminGetProcessInstance calls AdminGetProcessInstanceFunc.
+func (mock *ProcessInstanceServiceMock) AdminGetProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.AdminGetProcessInstanceFunc == nil {
+		panic("ProcessInstanceServiceMock.AdminGetProcessInstanceFunc: method is nil but ProcessInstanceService.AdminGetProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockAdminGetProcessInstance.Lock()
+	mock.calls.AdminGetProcessInstance = append(mock.calls.AdminGetProcessInstance, callInfo)
+	mock.lockAdminGetProcessInstance.Unlock()
+	return mock.AdminGetProcessInstanceFunc(pid)
+}
+
+// AdminGetProcessInstanceCalls gets all the calls that were made to AdminGetProcessInstance.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.AdminGetProcessInstanceCalls())
+func (mock *ProcessInstanceServiceMock) AdminGetProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockAdminGetProcessInstance.RLock()
+	calls = mock.calls.AdminGetProcessInstance
+	mock.lockAdminGetProcessInstance.RUnlock()
+	return calls
+}
+
+// AdminSetProcessVariables calls AdminSetProcessVariablesFunc.
+func (mock *ProcessInstanceServiceMock) AdminSetProcessVariables(pid string, variables map[string]interface{}) error {
+	if mock.AdminSetProcessVariablesFunc == nil {
+		panic("ProcessInstanceServiceMock.AdminSetProcessVariablesFunc: method is nil but ProcessInstanceService.AdminSetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid       string
+		Variables map[string]interface{}
+	}{
+		Pid:       pid,
+		Variables: variables,
+	}
+	mock.lockAdminSetProcessVariables.Lock()
+	mock.calls.AdminSetProcessVariables = append(mock.calls.AdminSetProcessVariables, callInfo)
+	mock.lockAdminSetProcessVariables.Unlock()
+	return mock.AdminSetProcessVariablesFunc(pid, variables)
+}
+
+// AdminSetProcessVariablesCalls gets all the calls that were made to AdminSetProcessVariables.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.AdminSetProcessVariablesCalls())
+func (mock *ProcessInstanceServiceMock) AdminSetProcessVariablesCalls() []struct {
+	Pid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Pid       string
+		Variables map[string]interface{}
+	}
+	mock.lockAdminSetProcessVariables.RLock()
+	calls = mock.calls.AdminSetProcessVariables
+	mock.lockAdminSetProcessVariables.RUnlock()
+	return calls
+}
+
+// Cancel calls CancelFunc.
+func (mock *ProcessInstanceServiceMock) Cancel(key string) error {
+	if mock.CancelFunc == nil {
+		panic("ProcessInstanceServiceMock.CancelFunc: method is nil but ProcessInstanceService.Cancel was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockCancel.Lock()
+	mock.calls.Cancel = append(mock.calls.Cancel, callInfo)
+	mock.lockCancel.Unlock()
+	return mock.CancelFunc(key)
+}
+
+// CancelCalls gets all the calls that were made to Cancel.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.CancelCalls())
+func (mock *ProcessInstanceServiceMock) CancelCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockCancel.RLock()
+	calls = mock.calls.Cancel
+	mock.lockCancel.RUnlock()
+	return calls
+}
+
+// GetProcessDiagram calls GetProcessDiagramFunc.
+func (mock *ProcessInstanceServiceMock) GetProcessDiagram(pid string) ([]byte, error) {
+	if mock.GetProcessDiagramFunc == nil {
+		panic("ProcessInstanceServiceMock.GetProcessDiagramFunc: method is nil but ProcessInstanceService.GetProcessDiagram was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDiagram.Lock()
+	mock.calls.GetProcessDiagram = append(mock.calls.GetProcessDiagram, callInfo)
+	mock.lockGetProcessDiagram.Unlock()
+	return mock.GetProcessDiagramFunc(pid)
+}
+
+// GetProcessDiagramCalls gets all the calls that were made to GetProcessDiagram.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.GetProcessDiagramCalls())
+func (mock *ProcessInstanceServiceMock) GetProcessDiagramCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDiagram.RLock()
+	calls = mock.calls.GetProcessDiagram
+	mock.lockGetProcessDiagram.RUnlock()
+	return calls
+}
+
+// GetProcessInstance calls GetProcessInstanceFunc.
+func (mock *ProcessInstanceServiceMock) GetProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.GetProcessInstanceFunc == nil {
+		panic("ProcessInstanceServiceMock.GetProcessInstanceFunc: method is nil but ProcessInstanceService.GetProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessInstance.Lock()
+	mock.calls.GetProcessInstance = append(mock.calls.GetProcessInstance, callInfo)
+	mock.lockGetProcessInstance.Unlock()
+	return mock.GetProcessInstanceFunc(pid)
+}
+
+// GetProcessInstanceCalls gets all the calls that were made to GetProcessInstance.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.GetProcessInstanceCalls())
+func (mock *ProcessInstanceServiceMock) GetProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessInstance.RLock()
+	calls = mock.calls.GetProcessInstance
+	mock.lockGetProcessInstance.RUnlock()
+	return calls
+}
+
+// GetProcessInstances calls GetProcessInstancesFunc.
+func (mock *ProcessInstanceServiceMock) GetProcessInstances() (*activiti.ActListProcessInstances, error) {
+	if mock.GetProcessInstancesFunc == nil {
+		panic("ProcessInstanceServiceMock.GetProcessInstancesFunc: method is nil but ProcessInstanceService.GetProcessInstances was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProcessInstances.Lock()
+	mock.calls.GetProcessInstances = append(mock.calls.GetProcessInstances, callInfo)
+	mock.lockGetProcessInstances.Unlock()
+	return mock.GetProcessInstancesFunc()
+}
+
+// GetProcessInstancesCalls gets all the calls that were made to GetProcessInstances.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.GetProcessInstancesCalls())
+func (mock *ProcessInstanceServiceMock) GetProcessInstancesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProcessInstances.RLock()
+	calls = mock.calls.GetProcessInstances
+	mock.lockGetProcessInstances.RUnlock()
+	return calls
+}
+
+// GetProcessVariables calls GetProcessVariablesFunc.
+func (mock *ProcessInstanceServiceMock) GetProcessVariables(pid string) (*activiti.ActListProcessVariables, error) {
+	if mock.GetProcessVariablesFunc == nil {
+		panic("ProcessInstanceServiceMock.GetProcessVariablesFunc: method is nil but ProcessInstanceService.GetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessVariables.Lock()
+	mock.calls.GetProcessVariables = append(mock.calls.GetProcessVariables, callInfo)
+	mock.lockGetProcessVariables.Unlock()
+	return mock.GetProcessVariablesFunc(pid)
+}
+
+// GetProcessVariablesCalls gets all the calls that were made to GetProcessVariables.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.GetProcessVariablesCalls())
+func (mock *ProcessInstanceServiceMock) GetProcessVariablesCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessVariables.RLock()
+	calls = mock.calls.GetProcessVariables
+	mock.lockGetProcessVariables.RUnlock()
+	return calls
+}
+
+// ProcessInstancesTasks calls ProcessInstancesTasksFunc.
+func (mock *ProcessInstanceServiceMock) ProcessInstancesTasks(key string) (*activiti.ActListTasks, error) {
+	if mock.ProcessInstancesTasksFunc == nil {
+		panic("ProcessInstanceServiceMock.ProcessInstancesTasksFunc: method is nil but ProcessInstanceService.ProcessInstancesTasks was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockProcessInstancesTasks.Lock()
+	mock.calls.ProcessInstancesTasks = append(mock.calls.ProcessInstancesTasks, callInfo)
+	mock.lockProcessInstancesTasks.Unlock()
+	return mock.ProcessInstancesTasksFunc(key)
+}
+
+// ProcessInstancesTasksCalls gets all the calls that were made to ProcessInstancesTasks.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.ProcessInstancesTasksCalls())
+func (mock *ProcessInstanceServiceMock) ProcessInstancesTasksCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockProcessInstancesTasks.RLock()
+	calls = mock.calls.ProcessInstancesTasks
+	mock.lockProcessInstancesTasks.RUnlock()
+	return calls
+}
+
+// QueryProcessInstances calls QueryProcessInstancesFunc.
+func (mock *ProcessInstanceServiceMock) QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error) {
+	if mock.QueryProcessInstancesFunc == nil {
+		panic("ProcessInstanceServiceMock.QueryProcessInstancesFunc: method is nil but ProcessInstanceService.QueryProcessInstances was just called")
+	}
+	callInfo := struct {
+		Params url.Values
+	}{
+		Params: params,
+	}
+	mock.lockQueryProcessInstances.Lock()
+	mock.calls.QueryProcessInstances = append(mock.calls.QueryProcessInstances, callInfo)
+	mock.lockQueryProcessInstances.Unlock()
+	return mock.QueryProcessInstancesFunc(params)
+}
+
+// QueryProcessInstancesCalls gets all the calls that were made to QueryProcessInstances.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.QueryProcessInstancesCalls())
+func (mock *ProcessInstanceServiceMock) QueryProcessInstancesCalls() []struct {
+	Params url.Values
+} {
+	var calls []struct {
+		Params url.Values
+	}
+	mock.lockQueryProcessInstances.RLock()
+	calls = mock.calls.QueryProcessInstances
+	mock.lockQueryProcessInstances.RUnlock()
+	return calls
+}
+
+// ResumeProcessInstance calls ResumeProcessInstanceFunc.
+func (mock *ProcessInstanceServiceMock) ResumeProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.ResumeProcessInstanceFunc == nil {
+		panic("ProcessInstanceServiceMock.ResumeProcessInstanceFunc: method is nil but ProcessInstanceService.ResumeProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockResumeProcessInstance.Lock()
+	mock.calls.ResumeProcessInstance = append(mock.calls.ResumeProcessInstance, callInfo)
+	mock.lockResumeProcessInstance.Unlock()
+	return mock.ResumeProcessInstanceFunc(pid)
+}
+
+// ResumeProcessInstanceCalls gets all the calls that were made to ResumeProcessInstance.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.ResumeProcessInstanceCalls())
+func (mock *ProcessInstanceServiceMock) ResumeProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockResumeProcessInstance.RLock()
+	calls = mock.calls.ResumeProcessInstance
+	mock.lockResumeProcessInstance.RUnlock()
+	return calls
+}
+
+// SetProcessVariables calls SetProcessVariablesFunc.
+func (mock *ProcessInstanceServiceMock) SetProcessVariables(pid string, variables map[string]interface{}) error {
+	if mock.SetProcessVariablesFunc == nil {
+		panic("ProcessInstanceServiceMock.SetProcessVariablesFunc: method is nil but ProcessInstanceService.SetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid       string
+		Variables map[string]interface{}
+	}{
+		Pid:       pid,
+		Variables: variables,
+	}
+	mock.lockSetProcessVariables.Lock()
+	mock.calls.SetProcessVariables = append(mock.calls.SetProcessVariables, callInfo)
+	mock.lockSetProcessVariables.Unlock()
+	return mock.SetProcessVariablesFunc(pid, variables)
+}
+
+// SetProcessVariablesCalls gets all the calls that were made to SetProcessVariables.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.SetProcessVariablesCalls())
+func (mock *ProcessInstanceServiceMock) SetProcessVariablesCalls() []struct {
+	Pid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Pid       string
+		Variables map[string]interface{}
+	}
+	mock.lockSetProcessVariables.RLock()
+	calls = mock.calls.SetProcessVariables
+	mock.lockSetProcessVariables.RUnlock()
+	return calls
+}
+
+// StartProcessInstance calls StartProcessInstanceFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstance(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error) {
+	if mock.StartProcessInstanceFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceFunc: method is nil but ProcessInstanceService.StartProcessInstance was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Opts activiti.StartOptions
+	}{
+		Ctx:  ctx,
+		Opts: opts,
+	}
+	mock.lockStartProcessInstance.Lock()
+	mock.calls.StartProcessInstance = append(mock.calls.StartProcessInstance, callInfo)
+	mock.lockStartProcessInstance.Unlock()
+	return mock.StartProcessInstanceFunc(ctx, opts)
+}
+
+// StartProcessInstanceCalls gets all the calls that were made to StartProcessInstance.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceCalls() []struct {
+	Ctx  context.Context
+	Opts activiti.StartOptions
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Opts activiti.StartOptions
+	}
+	mock.lockStartProcessInstance.RLock()
+	calls = mock.calls.StartProcessInstance
+	mock.lockStartProcessInstance.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceById calls StartProcessInstanceByIdFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceById(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceByIdFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceByIdFunc: method is nil but ProcessInstanceService.StartProcessInstanceById was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockStartProcessInstanceById.Lock()
+	mock.calls.StartProcessInstanceById = append(mock.calls.StartProcessInstanceById, callInfo)
+	mock.lockStartProcessInstanceById.Unlock()
+	return mock.StartProcessInstanceByIdFunc(pid)
+}
+
+// StartProcessInstanceByIdCalls gets all the calls that were made to StartProcessInstanceById.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceByIdCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceByIdCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockStartProcessInstanceById.RLock()
+	calls = mock.calls.StartProcessInstanceById
+	mock.lockStartProcessInstanceById.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceByKey calls StartProcessInstanceByKeyFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceByKey(key string) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceByKeyFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceByKeyFunc: method is nil but ProcessInstanceService.StartProcessInstanceByKey was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockStartProcessInstanceByKey.Lock()
+	mock.calls.StartProcessInstanceByKey = append(mock.calls.StartProcessInstanceByKey, callInfo)
+	mock.lockStartProcessInstanceByKey.Unlock()
+	return mock.StartProcessInstanceByKeyFunc(key)
+}
+
+// StartProcessInstanceByKeyCalls gets all the calls that were made to StartProcessInstanceByKey.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceByKeyCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceByKeyCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockStartProcessInstanceByKey.RLock()
+	calls = mock.calls.StartProcessInstanceByKey
+	mock.lockStartProcessInstanceByKey.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceOnce calls StartProcessInstanceOnceFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceOnce(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceOnceFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceOnceFunc: method is nil but ProcessInstanceService.StartProcessInstanceOnce was just called")
+	}
+	callInfo := struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+		Policy      activiti.ConflictPolicy
+	}{
+		Key:         key,
+		BusinessKey: businessKey,
+		Variables:   variables,
+		Policy:      policy,
+	}
+	mock.lockStartProcessInstanceOnce.Lock()
+	mock.calls.StartProcessInstanceOnce = append(mock.calls.StartProcessInstanceOnce, callInfo)
+	mock.lockStartProcessInstanceOnce.Unlock()
+	return mock.StartProcessInstanceOnceFunc(key, businessKey, variables, policy)
+}
+
+// StartProcessInstanceOnceCalls gets all the calls that were made to StartProcessInstanceOnce.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceOnceCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceOnceCalls() []struct {
+	Key         string
+	BusinessKey string
+	Variables   map[string]interface{}
+	Policy      activiti.ConflictPolicy
+} {
+	var calls []struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+		Policy      activiti.ConflictPolicy
+	}
+	mock.lockStartProcessInstanceOnce.RLock()
+	calls = mock.calls.StartProcessInstanceOnce
+	mock.lockStartProcessInstanceOnce.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceWithBusinessKeyAndVariables calls StartProcessInstanceWithBusinessKeyAndVariablesFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceWithBusinessKeyAndVariables(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceWithBusinessKeyAndVariablesFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceWithBusinessKeyAndVariablesFunc: method is nil but ProcessInstanceService.StartProcessInstanceWithBusinessKeyAndVariables was just called")
+	}
+	callInfo := struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+	}{
+		Key:         key,
+		BusinessKey: BusinessKey,
+		Variables:   variables,
+	}
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.Lock()
+	mock.calls.StartProcessInstanceWithBusinessKeyAndVariables = append(mock.calls.StartProcessInstanceWithBusinessKeyAndVariables, callInfo)
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.Unlock()
+	return mock.StartProcessInstanceWithBusinessKeyAndVariablesFunc(key, BusinessKey, variables)
+}
+
+// StartProcessInstanceWithBusinessKeyAndVariablesCalls gets all the calls that were made to StartProcessInstanceWithBusinessKeyAndVariables.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceWithBusinessKeyAndVariablesCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceWithBusinessKeyAndVariablesCalls() []struct {
+	Key         string
+	BusinessKey string
+	Variables   map[string]interface{}
+} {
+	var calls []struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+	}
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.RLock()
+	calls = mock.calls.StartProcessInstanceWithBusinessKeyAndVariables
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceWithVariables calls StartProcessInstanceWithVariablesFunc.
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceWithVariables(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceWithVariablesFunc == nil {
+		panic("ProcessInstanceServiceMock.StartProcessInstanceWithVariablesFunc: method is nil but ProcessInstanceService.StartProcessInstanceWithVariables was just called")
+	}
+	callInfo := struct {
+		Key       string
+		Variables map[string]interface{}
+	}{
+		Key:       key,
+		Variables: variables,
+	}
+	mock.lockStartProcessInstanceWithVariables.Lock()
+	mock.calls.StartProcessInstanceWithVariables = append(mock.calls.StartProcessInstanceWithVariables, callInfo)
+	mock.lockStartProcessInstanceWithVariables.Unlock()
+	return mock.StartProcessInstanceWithVariablesFunc(key, variables)
+}
+
+// StartProcessInstanceWithVariablesCalls gets all the calls that were made to StartProcessInstanceWithVariables.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.StartProcessInstanceWithVariablesCalls())
+func (mock *ProcessInstanceServiceMock) StartProcessInstanceWithVariablesCalls() []struct {
+	Key       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Key       string
+		Variables map[string]interface{}
+	}
+	mock.lockStartProcessInstanceWithVariables.RLock()
+	calls = mock.calls.StartProcessInstanceWithVariables
+	mock.lockStartProcessInstanceWithVariables.RUnlock()
+	return calls
+}
+
+// SuspendProcessInstance calls SuspendProcessInstanceFunc.
+func (mock *ProcessInstanceServiceMock) SuspendProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.SuspendProcessInstanceFunc == nil {
+		panic("ProcessInstanceServiceMock.SuspendProcessInstanceFunc: method is nil but ProcessInstanceService.SuspendProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockSuspendProcessInstance.Lock()
+	mock.calls.SuspendProcessInstance = append(mock.calls.SuspendProcessInstance, callInfo)
+	mock.lockSuspendProcessInstance.Unlock()
+	return mock.SuspendProcessInstanceFunc(pid)
+}
+
+// SuspendProcessInstanceCalls gets all the calls that were made to SuspendProcessInstance.
+// Check the length with:
+//
+//	len(mockedProcessInstanceService.SuspendProcessInstanceCalls())
+func (mock *ProcessInstanceServiceMock) SuspendProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockSuspendProcessInstance.RLock()
+	calls = mock.calls.SuspendProcessInstance
+	mock.lockSuspendProcessInstance.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskServiceMock does implement activiti.TaskService.
+// If this is not the case, regenerate this file with moq.
+var _ activiti.TaskService = &TaskServiceMock{}
+
+// TaskServiceMock is a mock implementation of activiti.TaskService.
+//
+//	func TestSomethingThatUsesTaskService(t *testing.T) {
+//
+//		// make and configure a mocked activiti.TaskService
+//		mockedTaskService := &TaskServiceMock{
+//			CompleteTaskFunc: func(tid string, variables map[string]interface{}) error {
+//				panic("mock out the CompleteTask method")
+//			},
+//			GetTaskFunc: func(tid string) (*activiti.ActTask, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			GetTaskCandidateGroupsFunc: func(tid string) ([]string, error) {
+//				panic("mock out the GetTaskCandidateGroups method")
+//			},
+//			GetTaskCandidateUsersFunc: func(tid string) ([]string, error) {
+//				panic("mock out the GetTaskCandidateUsers method")
+//			},
+//			GetTasksFunc: func() (*activiti.ActListTasks, error) {
+//				panic("mock out the GetTasks method")
+//			},
+//			QueryTasksFunc: func(params url.Values) (*activiti.ActListTasks, error) {
+//				panic("mock out the QueryTasks method")
+//			},
+//			TaskActionAssignFunc: func(tid string, assignee string) error {
+//				panic("mock out the TaskActionAssign method")
+//			},
+//			TaskActionClaimFunc: func(tid string, assignee string) error {
+//				panic("mock out the TaskActionClaim method")
+//			},
+//			TaskActionCompleteFunc: func(tid string) error {
+//				panic("mock out the TaskActionComplete method")
+//			},
+//			TaskActionCompleteWithVariablesFunc: func(tid string, v map[string]string) error {
+//				panic("mock out the TaskActionCompleteWithVariables method")
+//			},
+//			UpdateTaskFunc: func(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error) {
+//				panic("mock out the UpdateTask method")
+//			},
+//		}
+//
+//		// use mockedTaskService in code that requires activiti.TaskService
+//		// and then make assertions.
+//
+//	}
+type TaskServiceMock struct {
+	// CompleteTaskFunc mocks the CompleteTask method.
+	CompleteTaskFunc func(tid string, variables map[string]interface{}) error
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(tid string) (*activiti.ActTask, error)
+
+	// GetTaskCandidateGroupsFunc mocks the GetTaskCandidateGroups method.
+	GetTaskCandidateGroupsFunc func(tid string) ([]string, error)
+
+	// GetTaskCandidateUsersFunc mocks the GetTaskCandidateUsers method.
+	GetTaskCandidateUsersFunc func(tid string) ([]string, error)
+
+	// GetTasksFunc mocks the GetTasks method.
+	GetTasksFunc func() (*activiti.ActListTasks, error)
+
+	// QueryTasksFunc mocks the QueryTasks method.
+	QueryTasksFunc func(params url.Values) (*activiti.ActListTasks, error)
+
+	// TaskActionAssignFunc mocks the TaskActionAssign method.
+	TaskActionAssignFunc func(tid string, assignee string) error
+
+	// TaskActionClaimFunc mocks the TaskActionClaim method.
+	TaskActionClaimFunc func(tid string, assignee string) error
+
+	// TaskActionCompleteFunc mocks the TaskActionComplete method.
+	TaskActionCompleteFunc func(tid string) error
+
+	// TaskActionCompleteWithVariablesFunc mocks the TaskActionCompleteWithVariables method.
+	TaskActionCompleteWithVariablesFunc func(tid string, v map[string]string) error
+
+	// UpdateTaskFunc mocks the UpdateTask method.
+	UpdateTaskFunc func(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CompleteTask holds details about calls to the CompleteTask method.
+		CompleteTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTaskCandidateGroups holds details about calls to the GetTaskCandidateGroups method.
+		GetTaskCandidateGroups []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTaskCandidateUsers holds details about calls to the GetTaskCandidateUsers method.
+		GetTaskCandidateUsers []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTasks holds details about calls to the GetTasks method.
+		GetTasks []struct {
+		}
+		// QueryTasks holds details about calls to the QueryTasks method.
+		QueryTasks []struct {
+			// Params is the params argument value.
+			Params url.Values
+		}
+		// TaskActionAssign holds details about calls to the TaskActionAssign method.
+		TaskActionAssign []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Assignee is the assignee argument value.
+			Assignee string
+		}
+		// TaskActionClaim holds details about calls to the TaskActionClaim method.
+		TaskActionClaim []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Assignee is the assignee argument value.
+			Assignee string
+		}
+		// TaskActionComplete holds details about calls to the TaskActionComplete method.
+		TaskActionComplete []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// TaskActionCompleteWithVariables holds details about calls to the TaskActionCompleteWithVariables method.
+		TaskActionCompleteWithVariables []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// V is the v argument value.
+			V map[string]string
+		}
+		// UpdateTask holds details about calls to the UpdateTask method.
+		UpdateTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// U is the u argument value.
+			U activiti.ActUpdateTask
+		}
+	}
+	lockCompleteTask                    sync.RWMutex
+	lockGetTask                         sync.RWMutex
+	lockGetTaskCandidateGroups          sync.RWMutex
+	lockGetTaskCandidateUsers           sync.RWMutex
+	lockGetTasks                        sync.RWMutex
+	lockQueryTasks                      sync.RWMutex
+	lockTaskActionAssign                sync.RWMutex
+	lockTaskActionClaim                 sync.RWMutex
+	lockTaskActionComplete              sync.RWMutex
+	lockTaskActionCompleteWithVariables sync.RWMutex
+	lockUpdateTask                      sync.RWMutex
+}
+
+// CompleteTask calls CompleteTaskFunc.
+func (mock *TaskServiceMock) CompleteTask(tid string, variables map[string]interface{}) error {
+	if mock.CompleteTaskFunc == nil {
+		panic("TaskServiceMock.CompleteTaskFunc: method is nil but TaskService.CompleteTask was just called")
+	}
+	callInfo := struct {
+		Tid       string
+		Variables map[string]interface{}
+	}{
+		Tid:       tid,
+		Variables: variables,
+	}
+	mock.lockCompleteTask.Lock()
+	mock.calls.CompleteTask = append(mock.calls.CompleteTask, callInfo)
+	mock.lockCompleteTask.Unlock()
+	return mock.CompleteTaskFunc(tid, variables)
+}
+
+// CompleteTaskCalls gets all the calls that were made to CompleteTask.
+// Check the length with:
+//
+//	len(mockedTaskService.CompleteTaskCalls())
+func (mock *TaskServiceMock) CompleteTaskCalls() []struct {
+	Tid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Tid       string
+		Variables map[string]interface{}
+	}
+	mock.lockCompleteTask.RLock()
+	calls = mock.calls.CompleteTask
+	mock.lockCompleteTask.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskServiceMock) GetTask(tid string) (*activiti.ActTask, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskServiceMock.GetTaskFunc: method is nil but TaskService.GetTask was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(tid)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskService.GetTaskCalls())
+func (mock *TaskServiceMock) GetTaskCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// GetTaskCandidateGroups calls GetTaskCandidateGroupsFunc.
+func (mock *TaskServiceMock) GetTaskCandidateGroups(tid string) ([]string, error) {
+	if mock.GetTaskCandidateGroupsFunc == nil {
+		panic("TaskServiceMock.GetTaskCandidateGroupsFunc: method is nil but TaskService.GetTaskCandidateGroups was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTaskCandidateGroups.Lock()
+	mock.calls.GetTaskCandidateGroups = append(mock.calls.GetTaskCandidateGroups, callInfo)
+	mock.lockGetTaskCandidateGroups.Unlock()
+	return mock.GetTaskCandidateGroupsFunc(tid)
+}
+
+// GetTaskCandidateGroupsCalls gets all the calls that were made to GetTaskCandidateGroups.
+// Check the length with:
+//
+//	len(mockedTaskService.GetTaskCandidateGroupsCalls())
+func (mock *TaskServiceMock) GetTaskCandidateGroupsCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTaskCandidateGroups.RLock()
+	calls = mock.calls.GetTaskCandidateGroups
+	mock.lockGetTaskCandidateGroups.RUnlock()
+	return calls
+}
+
+// GetTaskCandidateUsers calls GetTaskCandidateUsersFunc.
+func (mock *TaskServiceMock) GetTaskCandidateUsers(tid string) ([]string, error) {
+	if mock.GetTaskCandidateUsersFunc == nil {
+		panic("TaskServiceMock.GetTaskCandidateUsersFunc: method is nil but TaskService.GetTaskCandidateUsers was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTaskCandidateUsers.Lock()
+	mock.calls.GetTaskCandidateUsers = append(mock.calls.GetTaskCandidateUsers, callInfo)
+	mock.lockGetTaskCandidateUsers.Unlock()
+	return mock.GetTaskCandidateUsersFunc(tid)
+}
+
+// GetTaskCandidateUsersCalls gets all the calls that were made to GetTaskCandidateUsers.
+// Check the length with:
+//
+//	len(mockedTaskService.GetTaskCandidateUsersCalls())
+func (mock *TaskServiceMock) GetTaskCandidateUsersCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTaskCandidateUsers.RLock()
+	calls = mock.calls.GetTaskCandidateUsers
+	mock.lockGetTaskCandidateUsers.RUnlock()
+	return calls
+}
+
+// GetTasks calls GetTasksFunc.
+func (mock *TaskServiceMock) GetTasks() (*activiti.ActListTasks, error) {
+	if mock.GetTasksFunc == nil {
+		panic("TaskServiceMock.GetTasksFunc: method is nil but TaskService.GetTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTasks.Lock()
+	mock.calls.GetTasks = append(mock.calls.GetTasks, callInfo)
+	mock.lockGetTasks.Unlock()
+	return mock.GetTasksFunc()
+}
+
+// GetTasksCalls gets all the calls that were made to GetTasks.
+// Check the length with:
+//
+//	len(mockedTaskService.GetTasksCalls())
+func (mock *TaskServiceMock) GetTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTasks.RLock()
+	calls = mock.calls.GetTasks
+	mock.lockGetTasks.RUnlock()
+	return calls
+}
+
+// QueryTasks calls QueryTasksFunc.
+func (mock *TaskServiceMock) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	if mock.QueryTasksFunc == nil {
+		panic("TaskServiceMock.QueryTasksFunc: method is nil but TaskService.QueryTasks was just called")
+	}
+	callInfo := struct {
+		Params url.Values
+	}{
+		Params: params,
+	}
+	mock.lockQueryTasks.Lock()
+	mock.calls.QueryTasks = append(mock.calls.QueryTasks, callInfo)
+	mock.lockQueryTasks.Unlock()
+	return mock.QueryTasksFunc(params)
+}
+
+// QueryTasksCalls gets all the calls that were made to QueryTasks.
+// Check the length with:
+//
+//	len(mockedTaskService.QueryTasksCalls())
+func (mock *TaskServiceMock) QueryTasksCalls() []struct {
+	Params url.Values
+} {
+	var calls []struct {
+		Params url.Values
+	}
+	mock.lockQueryTasks.RLock()
+	calls = mock.calls.QueryTasks
+	mock.lockQueryTasks.RUnlock()
+	return calls
+}
+
+// TaskActionAssign calls TaskActionAssignFunc.
+func (mock *TaskServiceMock) TaskActionAssign(tid string, assignee string) error {
+	if mock.TaskActionAssignFunc == nil {
+		panic("TaskServiceMock.TaskActionAssignFunc: method is nil but TaskService.TaskActionAssign was just called")
+	}
+	callInfo := struct {
+		Tid      string
+		Assignee string
+	}{
+		Tid:      tid,
+		Assignee: assignee,
+	}
+	mock.lockTaskActionAssign.Lock()
+	mock.calls.TaskActionAssign = append(mock.calls.TaskActionAssign, callInfo)
+	mock.lockTaskActionAssign.Unlock()
+	return mock.TaskActionAssignFunc(tid, assignee)
+}
+
+// TaskActionAssignCalls gets all the calls that were made to TaskActionAssign.
+// Check the length with:
+//
+//	len(mockedTaskService.TaskActionAssignCalls())
+func (mock *TaskServiceMock) TaskActionAssignCalls() []struct {
+	Tid      string
+	Assignee string
+} {
+	var calls []struct {
+		Tid      string
+		Assignee string
+	}
+	mock.lockTaskActionAssign.RLock()
+	calls = mock.calls.TaskActionAssign
+	mock.lockTaskActionAssign.RUnlock()
+	return calls
+}
+
+// TaskActionClaim calls TaskActionClaimFunc.
+func (mock *TaskServiceMock) TaskActionClaim(tid string, assignee string) error {
+	if mock.TaskActionClaimFunc == nil {
+		panic("TaskServiceMock.TaskActionClaimFunc: method is nil but TaskService.TaskActionClaim was just called")
+	}
+	callInfo := struct {
+		Tid      string
+		Assignee string
+	}{
+		Tid:      tid,
+		Assignee: assignee,
+	}
+	mock.lockTaskActionClaim.Lock()
+	mock.calls.TaskActionClaim = append(mock.calls.TaskActionClaim, callInfo)
+	mock.lockTaskActionClaim.Unlock()
+	return mock.TaskActionClaimFunc(tid, assignee)
+}
+
+// TaskActionClaimCalls gets all the calls that were made to TaskActionClaim.
+// Check the length with:
+//
+//	len(mockedTaskService.TaskActionClaimCalls())
+func (mock *TaskServiceMock) TaskActionClaimCalls() []struct {
+	Tid      string
+	Assignee string
+} {
+	var calls []struct {
+		Tid      string
+		Assignee string
+	}
+	mock.lockTaskActionClaim.RLock()
+	calls = mock.calls.TaskActionClaim
+	mock.lockTaskActionClaim.RUnlock()
+	return calls
+}
+
+// TaskActionComplete calls TaskActionCompleteFunc.
+func (mock *TaskServiceMock) TaskActionComplete(tid string) error {
+	if mock.TaskActionCompleteFunc == nil {
+		panic("TaskServiceMock.TaskActionCompleteFunc: method is nil but TaskService.TaskActionComplete was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockTaskActionComplete.Lock()
+	mock.calls.TaskActionComplete = append(mock.calls.TaskActionComplete, callInfo)
+	mock.lockTaskActionComplete.Unlock()
+	return mock.TaskActionCompleteFunc(tid)
+}
+
+// TaskActionCompleteCalls gets all the calls that were made to TaskActionComplete.
+// Check the length with:
+//
+//	len(mockedTaskService.TaskActionCompleteCalls())
+func (mock *TaskServiceMock) TaskActionCompleteCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockTaskActionComplete.RLock()
+	calls = mock.calls.TaskActionComplete
+	mock.lockTaskActionComplete.RUnlock()
+	return calls
+}
+
+// TaskActionCompleteWithVariables calls TaskActionCompleteWithVariablesFunc.
+func (mock *TaskServiceMock) TaskActionCompleteWithVariables(tid string, v map[string]string) error {
+	if mock.TaskActionCompleteWithVariablesFunc == nil {
+		panic("TaskServiceMock.TaskActionCompleteWithVariablesFunc: method is nil but TaskService.TaskActionCompleteWithVariables was just called")
+	}
+	callInfo := struct {
+		Tid string
+		V   map[string]string
+	}{
+		Tid: tid,
+		V:   v,
+	}
+	mock.lockTaskActionCompleteWithVariables.Lock()
+	mock.calls.TaskActionCompleteWithVariables = append(mock.calls.TaskActionCompleteWithVariables, callInfo)
+	mock.lockTaskActionCompleteWithVariables.Unlock()
+	return mock.TaskActionCompleteWithVariablesFunc(tid, v)
+}
+
+// TaskActionCompleteWithVariablesCalls gets all the calls that were made to TaskActionCompleteWithVariables.
+// Check the length with:
+//
+//	len(mockedTaskService.TaskActionCompleteWithVariablesCalls())
+func (mock *TaskServiceMock) TaskActionCompleteWithVariablesCalls() []struct {
+	Tid string
+	V   map[string]string
+} {
+	var calls []struct {
+		Tid string
+		V   map[string]string
+	}
+	mock.lockTaskActionCompleteWithVariables.RLock()
+	calls = mock.calls.TaskActionCompleteWithVariables
+	mock.lockTaskActionCompleteWithVariables.RUnlock()
+	return calls
+}
+
+// UpdateTask calls UpdateTaskFunc.
+func (mock *TaskServiceMock) UpdateTask(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error) {
+	if mock.UpdateTaskFunc == nil {
+		panic("TaskServiceMock.UpdateTaskFunc: method is nil but TaskService.UpdateTask was just called")
+	}
+	callInfo := struct {
+		Tid string
+		U   activiti.ActUpdateTask
+	}{
+		Tid: tid,
+		U:   u,
+	}
+	mock.lockUpdateTask.Lock()
+	mock.calls.UpdateTask = append(mock.calls.UpdateTask, callInfo)
+	mock.lockUpdateTask.Unlock()
+	return mock.UpdateTaskFunc(tid, u)
+}
+
+// UpdateTaskCalls gets all the calls that were made to UpdateTask.
+// Check the length with:
+//
+//	len(mockedTaskService.UpdateTaskCalls())
+func (mock *TaskServiceMock) UpdateTaskCalls() []struct {
+	Tid string
+	U   activiti.ActUpdateTask
+} {
+	var calls []struct {
+		Tid string
+		U   activiti.ActUpdateTask
+	}
+	mock.lockUpdateTask.RLock()
+	calls = mock.calls.UpdateTask
+	mock.lockUpdateTask.RUnlock()
+	return calls
+}
+
+// Ensure, that UserServiceMock does implement activiti.UserService.
+// If this is not the case, regenerate this file with moq.
+var _ activiti.UserService = &UserServiceMock{}
+
+// UserServiceMock is a mock implementation of activiti.UserService.
+//
+//	func TestSomethingThatUsesUserService(t *testing.T) {
+//
+//		// make and configure a mocked activiti.UserService
+//		mockedUserService := &UserServiceMock{
+//			CreateUserFunc: func(u activiti.ActUser) (*activiti.ActUser, error) {
+//				panic("mock out the CreateUser method")
+//			},
+//			DeleteUserFunc: func(uid string) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			GetUserFunc: func(uid string) (*activiti.ActUser, error) {
+//				panic("mock out the GetUser method")
+//			},
+//			GetUsersFunc: func() (*activiti.ActUsers, error) {
+//				panic("mock out the GetUsers method")
+//			},
+//			UpdateUserFunc: func(u activiti.ActUser) (*activiti.ActUser, error) {
+//				panic("mock out the UpdateUser method")
+//			},
+//		}
+//
+//		// use mockedUserService in code that requires activiti.UserService
+//		// and then make assertions.
+//
+//	}
+type UserServiceMock struct {
+	// CreateUserFunc mocks the CreateUser method.
+	CreateUserFunc func(u activiti.ActUser) (*activiti.ActUser, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(uid string) error
+
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(uid string) (*activiti.ActUser, error)
+
+	// GetUsersFunc mocks the GetUsers method.
+	GetUsersFunc func() (*activiti.ActUsers, error)
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(u activiti.ActUser) (*activiti.ActUser, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// U is the u argument value.
+			U activiti.ActUser
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// UID is the uid argument value.
+			UID string
+		}
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// UID is the uid argument value.
+			UID string
+		}
+		// GetUsers holds details about calls to the GetUsers method.
+		GetUsers []struct {
+		}
+		// UpdateUser holds details about calls to the UpdateUser method.
+		UpdateUser []struct {
+			// U is the u argument value.
+			U activiti.ActUser
+		}
+	}
+	lockCreateUser sync.RWMutex
+	lockDeleteUser sync.RWMutex
+	lockGetUser    sync.RWMutex
+	lockGetUsers   sync.RWMutex
+	lockUpdateUser sync.RWMutex
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *UserServiceMock) CreateUser(u activiti.ActUser) (*activiti.ActUser, error) {
+	if mock.CreateUserFunc == nil {
+		panic("UserServiceMock.CreateUserFunc: method is nil but UserService.CreateUser was just called")
+	}
+	callInfo := struct {
+		U activiti.ActUser
+	}{
+		U: u,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(u)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedUserService.CreateUserCalls())
+func (mock *UserServiceMock) CreateUserCalls() []struct {
+	U activiti.ActUser
+} {
+	var calls []struct {
+		U activiti.ActUser
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *UserServiceMock) DeleteUser(uid string) error {
+	if mock.DeleteUserFunc == nil {
+		panic("UserServiceMock.DeleteUserFunc: method is nil but UserService.DeleteUser was just called")
+	}
+	callInfo := struct {
+		UID string
+	}{
+		UID: uid,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(uid)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedUserService.DeleteUserCalls())
+func (mock *UserServiceMock) DeleteUserCalls() []struct {
+	UID string
+} {
+	var calls []struct {
+		UID string
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// GetUser calls GetUserFunc.
+func (mock *UserServiceMock) GetUser(uid string) (*activiti.ActUser, error) {
+	if mock.GetUserFunc == nil {
+		panic("UserServiceMock.GetUserFunc: method is nil but UserService.GetUser was just called")
+	}
+	callInfo := struct {
+		UID string
+	}{
+		UID: uid,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(uid)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedUserService.GetUserCalls())
+func (mock *UserServiceMock) GetUserCalls() []struct {
+	UID string
+} {
+	var calls []struct {
+		UID string
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// GetUsers calls GetUsersFunc.
+func (mock *UserServiceMock) GetUsers() (*activiti.ActUsers, error) {
+	if mock.GetUsersFunc == nil {
+		panic("UserServiceMock.GetUsersFunc: method is nil but UserService.GetUsers was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUsers.Lock()
+	mock.calls.GetUsers = append(mock.calls.GetUsers, callInfo)
+	mock.lockGetUsers.Unlock()
+	return mock.GetUsersFunc()
+}
+
+// GetUsersCalls gets all the calls that were made to GetUsers.
+// Check the length with:
+//
+//	len(mockedUserService.GetUsersCalls())
+func (mock *UserServiceMock) GetUsersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUsers.RLock()
+	calls = mock.calls.GetUsers
+	mock.lockGetUsers.RUnlock()
+	return calls
+}
+
+// UpdateUser calls UpdateUserFunc.
+func (mock *UserServiceMock) UpdateUser(u activiti.ActUser) (*activiti.ActUser, error) {
+	if mock.UpdateUserFunc == nil {
+		panic("UserServiceMock.UpdateUserFunc: method is nil but UserService.UpdateUser was just called")
+	}
+	callInfo := struct {
+		U activiti.ActUser
+	}{
+		U: u,
+	}
+	mock.lockUpdateUser.Lock()
+	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
+	mock.lockUpdateUser.Unlock()
+	return mock.UpdateUserFunc(u)
+}
+
+// UpdateUserCalls gets all the calls that were made to UpdateUser.
+// Check the length with:
+//
+//	len(mockedUserService.UpdateUserCalls())
+func (mock *UserServiceMock) UpdateUserCalls() []struct {
+	U activiti.ActUser
+} {
+	var calls []struct {
+		U activiti.ActUser
+	}
+	mock.lockUpdateUser.RLock()
+	calls = mock.calls.UpdateUser
+	mock.lockUpdateUser.RUnlock()
+	return calls
+}
+
+// Ensure, that ClientMock does implement activiti.Client.
+// If this is not the case, regenerate this file with moq.
+var _ activiti.Client = &ClientMock{}
+
+// ClientMock is a mock implementation of activiti.Client.
+//
+//	func TestSomethingThatUsesClient(t *testing.T) {
+//
+//		// make and configure a mocked activiti.Client
+//		mockedClient := &ClientMock{
+//			AdminGetProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the AdminGetProcessInstance method")
+//			},
+//			AdminSetProcessVariablesFunc: func(pid string, variables map[string]interface{}) error {
+//				panic("mock out the AdminSetProcessVariables method")
+//			},
+//			CancelFunc: func(key string) error {
+//				panic("mock out the Cancel method")
+//			},
+//			CompleteTaskFunc: func(tid string, variables map[string]interface{}) error {
+//				panic("mock out the CompleteTask method")
+//			},
+//			CreateUserFunc: func(u activiti.ActUser) (*activiti.ActUser, error) {
+//				panic("mock out the CreateUser method")
+//			},
+//			DeleteUserFunc: func(uid string) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			FindProcessDefinitionsFunc: func(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error) {
+//				panic("mock out the FindProcessDefinitions method")
+//			},
+//			GetLatestProcessDefinitionFunc: func(key string) (*activiti.ProcessDefinition, error) {
+//				panic("mock out the GetLatestProcessDefinition method")
+//			},
+//			GetProcessDefinitionFunc: func(pid string) (*activiti.ActProcessDefinition, error) {
+//				panic("mock out the GetProcessDefinition method")
+//			},
+//			GetProcessDefinitionMetaFunc: func(pid string) (*activiti.ActProcessDefinitionMeta, error) {
+//				panic("mock out the GetProcessDefinitionMeta method")
+//			},
+//			GetProcessDefinitionModelFunc: func(pid string) ([]byte, error) {
+//				panic("mock out the GetProcessDefinitionModel method")
+//			},
+//			GetProcessDefinitionsFunc: func() (activiti.ActListProcessDefinitions, error) {
+//				panic("mock out the GetProcessDefinitions method")
+//			},
+//			GetProcessDiagramFunc: func(pid string) ([]byte, error) {
+//				panic("mock out the GetProcessDiagram method")
+//			},
+//			GetProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the GetProcessInstance method")
+//			},
+//			GetProcessInstancesFunc: func() (*activiti.ActListProcessInstances, error) {
+//				panic("mock out the GetProcessInstances method")
+//			},
+//			GetProcessVariablesFunc: func(pid string) (*activiti.ActListProcessVariables, error) {
+//				panic("mock out the GetProcessVariables method")
+//			},
+//			GetTaskFunc: func(tid string) (*activiti.ActTask, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			GetTaskCandidateGroupsFunc: func(tid string) ([]string, error) {
+//				panic("mock out the GetTaskCandidateGroups method")
+//			},
+//			GetTaskCandidateUsersFunc: func(tid string) ([]string, error) {
+//				panic("mock out the GetTaskCandidateUsers method")
+//			},
+//			GetTasksFunc: func() (*activiti.ActListTasks, error) {
+//				panic("mock out the GetTasks method")
+//			},
+//			GetUserFunc: func(uid string) (*activiti.ActUser, error) {
+//				panic("mock out the GetUser method")
+//			},
+//			GetUsersFunc: func() (*activiti.ActUsers, error) {
+//				panic("mock out the GetUsers method")
+//			},
+//			ProcessInstancesTasksFunc: func(key string) (*activiti.ActListTasks, error) {
+//				panic("mock out the ProcessInstancesTasks method")
+//			},
+//			QueryProcessInstancesFunc: func(params url.Values) (*activiti.ActListProcessInstances, error) {
+//				panic("mock out the QueryProcessInstances method")
+//			},
+//			QueryTasksFunc: func(params url.Values) (*activiti.ActListTasks, error) {
+//				panic("mock out the QueryTasks method")
+//			},
+//			ResumeProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the ResumeProcessInstance method")
+//			},
+//			SetProcessVariablesFunc: func(pid string, variables map[string]interface{}) error {
+//				panic("mock out the SetProcessVariables method")
+//			},
+//			StartProcessInstanceFunc: func(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error) {
+//				panic("mock out the StartProcessInstance method")
+//			},
+//			StartProcessInstanceByIdFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceById method")
+//			},
+//			StartProcessInstanceByKeyFunc: func(key string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceByKey method")
+//			},
+//			StartProcessInstanceOnceFunc: func(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceOnce method")
+//			},
+//			StartProcessInstanceWithBusinessKeyAndVariablesFunc: func(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceWithBusinessKeyAndVariables method")
+//			},
+//			StartProcessInstanceWithVariablesFunc: func(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the StartProcessInstanceWithVariables method")
+//			},
+//			SuspendProcessInstanceFunc: func(pid string) (*activiti.ActProcessInstance, error) {
+//				panic("mock out the SuspendProcessInstance method")
+//			},
+//			TaskActionAssignFunc: func(tid string, assignee string) error {
+//				panic("mock out the TaskActionAssign method")
+//			},
+//			TaskActionClaimFunc: func(tid string, assignee string) error {
+//				panic("mock out the TaskActionClaim method")
+//			},
+//			TaskActionCompleteFunc: func(tid string) error {
+//				panic("mock out the TaskActionComplete method")
+//			},
+//			TaskActionCompleteWithVariablesFunc: func(tid string, v map[string]string) error {
+//				panic("mock out the TaskActionCompleteWithVariables method")
+//			},
+//			UpdateTaskFunc: func(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error) {
+//				panic("mock out the UpdateTask method")
+//			},
+//			UpdateUserFunc: func(u activiti.ActUser) (*activiti.ActUser, error) {
+//				panic("mock out the UpdateUser method")
+//			},
+//		}
+//
+//		// use mockedClient in code that requires activiti.Client
+//		// and then make assertions.
+//
+//	}
+type ClientMock struct {
+	// AdminGetProcessInstanceFunc mocks the AdminGetProcessInstance method.
+	AdminGetProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// AdminSetProcessVariablesFunc mocks the AdminSetProcessVariables method.
+	AdminSetProcessVariablesFunc func(pid string, variables map[string]interface{}) error
+
+	// CancelFunc mocks the Cancel method.
+	CancelFunc func(key string) error
+
+	// CompleteTaskFunc mocks the CompleteTask method.
+	CompleteTaskFunc func(tid string, variables map[string]interface{}) error
+
+	// CreateUserFunc mocks the CreateUser method.
+	CreateUserFunc func(u activiti.ActUser) (*activiti.ActUser, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(uid string) error
+
+	// FindProcessDefinitionsFunc mocks the FindProcessDefinitions method.
+	FindProcessDefinitionsFunc func(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error)
+
+	// GetLatestProcessDefinitionFunc mocks the GetLatestProcessDefinition method.
+	GetLatestProcessDefinitionFunc func(key string) (*activiti.ProcessDefinition, error)
+
+	// GetProcessDefinitionFunc mocks the GetProcessDefinition method.
+	GetProcessDefinitionFunc func(pid string) (*activiti.ActProcessDefinition, error)
+
+	// GetProcessDefinitionMetaFunc mocks the GetProcessDefinitionMeta method.
+	GetProcessDefinitionMetaFunc func(pid string) (*activiti.ActProcessDefinitionMeta, error)
+
+	// GetProcessDefinitionModelFunc mocks the GetProcessDefinitionModel method.
+	GetProcessDefinitionModelFunc func(pid string) ([]byte, error)
+
+	// GetProcessDefinitionsFunc mocks the GetProcessDefinitions method.
+	GetProcessDefinitionsFunc func() (activiti.ActListProcessDefinitions, error)
+
+	// GetProcessDiagramFunc mocks the GetProcessDiagram method.
+	GetProcessDiagramFunc func(pid string) ([]byte, error)
+
+	// GetProcessInstanceFunc mocks the GetProcessInstance method.
+	GetProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// GetProcessInstancesFunc mocks the GetProcessInstances method.
+	GetProcessInstancesFunc func() (*activiti.ActListProcessInstances, error)
+
+	// GetProcessVariablesFunc mocks the GetProcessVariables method.
+	GetProcessVariablesFunc func(pid string) (*activiti.ActListProcessVariables, error)
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(tid string) (*activiti.ActTask, error)
+
+	// GetTaskCandidateGroupsFunc mocks the GetTaskCandidateGroups method.
+	GetTaskCandidateGroupsFunc func(tid string) ([]string, error)
+
+	// GetTaskCandidateUsersFunc mocks the GetTaskCandidateUsers method.
+	GetTaskCandidateUsersFunc func(tid string) ([]string, error)
+
+	// GetTasksFunc mocks the GetTasks method.
+	GetTasksFunc func() (*activiti.ActListTasks, error)
+
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(uid string) (*activiti.ActUser, error)
+
+	// GetUsersFunc mocks the GetUsers method.
+	GetUsersFunc func() (*activiti.ActUsers, error)
+
+	// ProcessInstancesTasksFunc mocks the ProcessInstancesTasks method.
+	ProcessInstancesTasksFunc func(key string) (*activiti.ActListTasks, error)
+
+	// QueryProcessInstancesFunc mocks the QueryProcessInstances method.
+	QueryProcessInstancesFunc func(params url.Values) (*activiti.ActListProcessInstances, error)
+
+	// QueryTasksFunc mocks the QueryTasks method.
+	QueryTasksFunc func(params url.Values) (*activiti.ActListTasks, error)
+
+	// ResumeProcessInstanceFunc mocks the ResumeProcessInstance method.
+	ResumeProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// SetProcessVariablesFunc mocks the SetProcessVariables method.
+	SetProcessVariablesFunc func(pid string, variables map[string]interface{}) error
+
+	// StartProcessInstanceFunc mocks the StartProcessInstance method.
+	StartProcessInstanceFunc func(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error)
+
+	// StartProcessInstanceByIdFunc mocks the StartProcessInstanceById method.
+	StartProcessInstanceByIdFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceByKeyFunc mocks the StartProcessInstanceByKey method.
+	StartProcessInstanceByKeyFunc func(key string) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceOnceFunc mocks the StartProcessInstanceOnce method.
+	StartProcessInstanceOnceFunc func(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceWithBusinessKeyAndVariablesFunc mocks the StartProcessInstanceWithBusinessKeyAndVariables method.
+	StartProcessInstanceWithBusinessKeyAndVariablesFunc func(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error)
+
+	// StartProcessInstanceWithVariablesFunc mocks the StartProcessInstanceWithVariables method.
+	StartProcessInstanceWithVariablesFunc func(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error)
+
+	// SuspendProcessInstanceFunc mocks the SuspendProcessInstance method.
+	SuspendProcessInstanceFunc func(pid string) (*activiti.ActProcessInstance, error)
+
+	// TaskActionAssignFunc mocks the TaskActionAssign method.
+	TaskActionAssignFunc func(tid string, assignee string) error
+
+	// TaskActionClaimFunc mocks the TaskActionClaim method.
+	TaskActionClaimFunc func(tid string, assignee string) error
+
+	// TaskActionCompleteFunc mocks the TaskActionComplete method.
+	TaskActionCompleteFunc func(tid string) error
+
+	// TaskActionCompleteWithVariablesFunc mocks the TaskActionCompleteWithVariables method.
+	TaskActionCompleteWithVariablesFunc func(tid string, v map[string]string) error
+
+	// UpdateTaskFunc mocks the UpdateTask method.
+	UpdateTaskFunc func(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error)
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(u activiti.ActUser) (*activiti.ActUser, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AdminGetProcessInstance holds details about calls to the AdminGetProcessInstance method.
+		AdminGetProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// AdminSetProcessVariables holds details about calls to the AdminSetProcessVariables method.
+		AdminSetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// Cancel holds details about calls to the Cancel method.
+		Cancel []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// CompleteTask holds details about calls to the CompleteTask method.
+		CompleteTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// U is the u argument value.
+			U activiti.ActUser
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// UID is the uid argument value.
+			UID string
+		}
+		// FindProcessDefinitions holds details about calls to the FindProcessDefinitions method.
+		FindProcessDefinitions []struct {
+			// F is the f argument value.
+			F activiti.ProcessDefinitionFilter
+		}
+		// GetLatestProcessDefinition holds details about calls to the GetLatestProcessDefinition method.
+		GetLatestProcessDefinition []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// GetProcessDefinition holds details about calls to the GetProcessDefinition method.
+		GetProcessDefinition []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitionMeta holds details about calls to the GetProcessDefinitionMeta method.
+		GetProcessDefinitionMeta []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitionModel holds details about calls to the GetProcessDefinitionModel method.
+		GetProcessDefinitionModel []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessDefinitions holds details about calls to the GetProcessDefinitions method.
+		GetProcessDefinitions []struct {
+		}
+		// GetProcessDiagram holds details about calls to the GetProcessDiagram method.
+		GetProcessDiagram []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessInstance holds details about calls to the GetProcessInstance method.
+		GetProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetProcessInstances holds details about calls to the GetProcessInstances method.
+		GetProcessInstances []struct {
+		}
+		// GetProcessVariables holds details about calls to the GetProcessVariables method.
+		GetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTaskCandidateGroups holds details about calls to the GetTaskCandidateGroups method.
+		GetTaskCandidateGroups []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTaskCandidateUsers holds details about calls to the GetTaskCandidateUsers method.
+		GetTaskCandidateUsers []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// GetTasks holds details about calls to the GetTasks method.
+		GetTasks []struct {
+		}
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// UID is the uid argument value.
+			UID string
+		}
+		// GetUsers holds details about calls to the GetUsers method.
+		GetUsers []struct {
+		}
+		// ProcessInstancesTasks holds details about calls to the ProcessInstancesTasks method.
+		ProcessInstancesTasks []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// QueryProcessInstances holds details about calls to the QueryProcessInstances method.
+		QueryProcessInstances []struct {
+			// Params is the params argument value.
+			Params url.Values
+		}
+		// QueryTasks holds details about calls to the QueryTasks method.
+		QueryTasks []struct {
+			// Params is the params argument value.
+			Params url.Values
+		}
+		// ResumeProcessInstance holds details about calls to the ResumeProcessInstance method.
+		ResumeProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// SetProcessVariables holds details about calls to the SetProcessVariables method.
+		SetProcessVariables []struct {
+			// Pid is the pid argument value.
+			Pid string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// StartProcessInstance holds details about calls to the StartProcessInstance method.
+		StartProcessInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Opts is the opts argument value.
+			Opts activiti.StartOptions
+		}
+		// StartProcessInstanceById holds details about calls to the StartProcessInstanceById method.
+		StartProcessInstanceById []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// StartProcessInstanceByKey holds details about calls to the StartProcessInstanceByKey method.
+		StartProcessInstanceByKey []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// StartProcessInstanceOnce holds details about calls to the StartProcessInstanceOnce method.
+		StartProcessInstanceOnce []struct {
+			// Key is the key argument value.
+			Key string
+			// BusinessKey is the businessKey argument value.
+			BusinessKey string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+			// Policy is the policy argument value.
+			Policy activiti.ConflictPolicy
+		}
+		// StartProcessInstanceWithBusinessKeyAndVariables holds details about calls to the StartProcessInstanceWithBusinessKeyAndVariables method.
+		StartProcessInstanceWithBusinessKeyAndVariables []struct {
+			// Key is the key argument value.
+			Key string
+			// BusinessKey is the BusinessKey argument value.
+			BusinessKey string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// StartProcessInstanceWithVariables holds details about calls to the StartProcessInstanceWithVariables method.
+		StartProcessInstanceWithVariables []struct {
+			// Key is the key argument value.
+			Key string
+			// Variables is the variables argument value.
+			Variables map[string]interface{}
+		}
+		// SuspendProcessInstance holds details about calls to the SuspendProcessInstance method.
+		SuspendProcessInstance []struct {
+			// Pid is the pid argument value.
+			Pid string
+		}
+		// TaskActionAssign holds details about calls to the TaskActionAssign method.
+		TaskActionAssign []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Assignee is the assignee argument value.
+			Assignee string
+		}
+		// TaskActionClaim holds details about calls to the TaskActionClaim method.
+		TaskActionClaim []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// Assignee is the assignee argument value.
+			Assignee string
+		}
+		// TaskActionComplete holds details about calls to the TaskActionComplete method.
+		TaskActionComplete []struct {
+			// Tid is the tid argument value.
+			Tid string
+		}
+		// TaskActionCompleteWithVariables holds details about calls to the TaskActionCompleteWithVariables method.
+		TaskActionCompleteWithVariables []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// V is the v argument value.
+			V map[string]string
+		}
+		// UpdateTask holds details about calls to the UpdateTask method.
+		UpdateTask []struct {
+			// Tid is the tid argument value.
+			Tid string
+			// U is the u argument value.
+			U activiti.ActUpdateTask
+		}
+		// UpdateUser holds details about calls to the UpdateUser method.
+		UpdateUser []struct {
+			// U is the u argument value.
+			U activiti.ActUser
+		}
+	}
+	lockAdminGetProcessInstance                         sync.RWMutex
+	lockAdminSetProcessVariables                        sync.RWMutex
+	lockCancel                                          sync.RWMutex
+	lockCompleteTask                                    sync.RWMutex
+	lockCreateUser                                      sync.RWMutex
+	lockDeleteUser                                      sync.RWMutex
+	lockFindProcessDefinitions                          sync.RWMutex
+	lockGetLatestProcessDefinition                      sync.RWMutex
+	lockGetProcessDefinition                            sync.RWMutex
+	lockGetProcessDefinitionMeta                        sync.RWMutex
+	lockGetProcessDefinitionModel                       sync.RWMutex
+	lockGetProcessDefinitions                           sync.RWMutex
+	lockGetProcessDiagram                               sync.RWMutex
+	lockGetProcessInstance                              sync.RWMutex
+	lockGetProcessInstances                             sync.RWMutex
+	lockGetProcessVariables                             sync.RWMutex
+	lockGetTask                                         sync.RWMutex
+	lockGetTaskCandidateGroups                          sync.RWMutex
+	lockGetTaskCandidateUsers                           sync.RWMutex
+	lockGetTasks                                        sync.RWMutex
+	lockGetUser                                         sync.RWMutex
+	lockGetUsers                                        sync.RWMutex
+	lockProcessInstancesTasks                           sync.RWMutex
+	lockQueryProcessInstances                           sync.RWMutex
+	lockQueryTasks                                      sync.RWMutex
+	lockResumeProcessInstance                           sync.RWMutex
+	lockSetProcessVariables                             sync.RWMutex
+	lockStartProcessInstance                            sync.RWMutex
+	lockStartProcessInstanceById                        sync.RWMutex
+	lockStartProcessInstanceByKey                       sync.RWMutex
+	lockStartProcessInstanceOnce                        sync.RWMutex
+	lockStartProcessInstanceWithBusinessKeyAndVariables sync.RWMutex
+	lockStartProcessInstanceWithVariables               sync.RWMutex
+	lockSuspendProcessInstance                          sync.RWMutex
+	lockTaskActionAssign                                sync.RWMutex
+	lockTaskActionClaim                                 sync.RWMutex
+	lockTaskActionComplete                              sync.RWMutex
+	lockTaskActionCompleteWithVariables                 sync.RWMutex
+	lockUpdateTask                                      sync.RWMutex
+	lockUpdateUser                                      sync.RWMutex
+}
+
+// AdminGetProcessInstance calls AdminGetProcessInstanceFunc.
+func (mock *ClientMock) AdminGetProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.AdminGetProcessInstanceFunc == nil {
+		panic("ClientMock.AdminGetProcessInstanceFunc: method is nil but Client.AdminGetProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockAdminGetProcessInstance.Lock()
+	mock.calls.AdminGetProcessInstance = append(mock.calls.AdminGetProcessInstance, callInfo)
+	mock.lockAdminGetProcessInstance.Unlock()
+	return mock.AdminGetProcessInstanceFunc(pid)
+}
+
+// AdminGetProcessInstanceCalls gets all the calls that were made to AdminGetProcessInstance.
+// Check the length with:
+//
+//	len(mockedClient.AdminGetProcessInstanceCalls())
+func (mock *ClientMock) AdminGetProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockAdminGetProcessInstance.RLock()
+	calls = mock.calls.AdminGetProcessInstance
+	mock.lockAdminGetProcessInstance.RUnlock()
+	return calls
+}
+
+// AdminSetProcessVariables calls AdminSetProcessVariablesFunc.
+func (mock *ClientMock) AdminSetProcessVariables(pid string, variables map[string]interface{}) error {
+	if mock.AdminSetProcessVariablesFunc == nil {
+		panic("ClientMock.AdminSetProcessVariablesFunc: method is nil but Client.AdminSetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid       string
+		Variables map[string]interface{}
+	}{
+		Pid:       pid,
+		Variables: variables,
+	}
+	mock.lockAdminSetProcessVariables.Lock()
+	mock.calls.AdminSetProcessVariables = append(mock.calls.AdminSetProcessVariables, callInfo)
+	mock.lockAdminSetProcessVariables.Unlock()
+	return mock.AdminSetProcessVariablesFunc(pid, variables)
+}
+
+// AdminSetProcessVariablesCalls gets all the calls that were made to AdminSetProcessVariables.
+// Check the length with:
+//
+//	len(mockedClient.AdminSetProcessVariablesCalls())
+func (mock *ClientMock) AdminSetProcessVariablesCalls() []struct {
+	Pid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Pid       string
+		Variables map[string]interface{}
+	}
+	mock.lockAdminSetProcessVariables.RLock()
+	calls = mock.calls.AdminSetProcessVariables
+	mock.lockAdminSetProcessVariables.RUnlock()
+	return calls
+}
+
+// Cancel calls CancelFunc.
+func (mock *ClientMock) Cancel(key string) error {
+	if mock.CancelFunc == nil {
+		panic("ClientMock.CancelFunc: method is nil but Client.Cancel was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockCancel.Lock()
+	mock.calls.Cancel = append(mock.calls.Cancel, callInfo)
+	mock.lockCancel.Unlock()
+	return mock.CancelFunc(key)
+}
+
+// CancelCalls gets all the calls that were made to Cancel.
+// Check the length with:
+//
+//	len(mockedClient.CancelCalls())
+func (mock *ClientMock) CancelCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockCancel.RLock()
+	calls = mock.calls.Cancel
+	mock.lockCancel.RUnlock()
+	return calls
+}
+
+// CompleteTask calls CompleteTaskFunc.
+func (mock *ClientMock) CompleteTask(tid string, variables map[string]interface{}) error {
+	if mock.CompleteTaskFunc == nil {
+		panic("ClientMock.CompleteTaskFunc: method is nil but Client.CompleteTask was just called")
+	}
+	callInfo := struct {
+		Tid       string
+		Variables map[string]interface{}
+	}{
+		Tid:       tid,
+		Variables: variables,
+	}
+	mock.lockCompleteTask.Lock()
+	mock.calls.CompleteTask = append(mock.calls.CompleteTask, callInfo)
+	mock.lockCompleteTask.Unlock()
+	return mock.CompleteTaskFunc(tid, variables)
+}
+
+// CompleteTaskCalls gets all the calls that were made to CompleteTask.
+// Check the length with:
+//
+//	len(mockedClient.CompleteTaskCalls())
+func (mock *ClientMock) CompleteTaskCalls() []struct {
+	Tid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Tid       string
+		Variables map[string]interface{}
+	}
+	mock.lockCompleteTask.RLock()
+	calls = mock.calls.CompleteTask
+	mock.lockCompleteTask.RUnlock()
+	return calls
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *ClientMock) CreateUser(u activiti.ActUser) (*activiti.ActUser, error) {
+	if mock.CreateUserFunc == nil {
+		panic("ClientMock.CreateUserFunc: method is nil but Client.CreateUser was just called")
+	}
+	callInfo := struct {
+		U activiti.ActUser
+	}{
+		U: u,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(u)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedClient.CreateUserCalls())
+func (mock *ClientMock) CreateUserCalls() []struct {
+	U activiti.ActUser
+} {
+	var calls []struct {
+		U activiti.ActUser
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *ClientMock) DeleteUser(uid string) error {
+	if mock.DeleteUserFunc == nil {
+		panic("ClientMock.DeleteUserFunc: method is nil but Client.DeleteUser was just called")
+	}
+	callInfo := struct {
+		UID string
+	}{
+		UID: uid,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(uid)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedClient.DeleteUserCalls())
+func (mock *ClientMock) DeleteUserCalls() []struct {
+	UID string
+} {
+	var calls []struct {
+		UID string
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// FindProcessDefinitions calls FindProcessDefinitionsFunc.
+func (mock *ClientMock) FindProcessDefinitions(f activiti.ProcessDefinitionFilter) ([]activiti.ProcessDefinition, error) {
+	if mock.FindProcessDefinitionsFunc == nil {
+		panic("ClientMock.FindProcessDefinitionsFunc: method is nil but Client.FindProcessDefinitions was just called")
+	}
+	callInfo := struct {
+		F activiti.ProcessDefinitionFilter
+	}{
+		F: f,
+	}
+	mock.lockFindProcessDefinitions.Lock()
+	mock.calls.FindProcessDefinitions = append(mock.calls.FindProcessDefinitions, callInfo)
+	mock.lockFindProcessDefinitions.Unlock()
+	return mock.FindProcessDefinitionsFunc(f)
+}
+
+// FindProcessDefinitionsCalls gets all the calls that were made to FindProcessDefinitions.
+// Check the length with:
+//
+//	len(mockedClient.FindProcessDefinitionsCalls())
+func (mock *ClientMock) FindProcessDefinitionsCalls() []struct {
+	F activiti.ProcessDefinitionFilter
+} {
+	var calls []struct {
+		F activiti.ProcessDefinitionFilter
+	}
+	mock.lockFindProcessDefinitions.RLock()
+	calls = mock.calls.FindProcessDefinitions
+	mock.lockFindProcessDefinitions.RUnlock()
+	return calls
+}
+
+// GetLatestProcessDefinition calls GetLatestProcessDefinitionFunc.
+func (mock *ClientMock) GetLatestProcessDefinition(key string) (*activiti.ProcessDefinition, error) {
+	if mock.GetLatestProcessDefinitionFunc == nil {
+		panic("ClientMock.GetLatestProcessDefinitionFunc: method is nil but Client.GetLatestProcessDefinition was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockGetLatestProcessDefinition.Lock()
+	mock.calls.GetLatestProcessDefinition = append(mock.calls.GetLatestProcessDefinition, callInfo)
+	mock.lockGetLatestProcessDefinition.Unlock()
+	return mock.GetLatestProcessDefinitionFunc(key)
+}
+
+// GetLatestProcessDefinitionCalls gets all the calls that were made to GetLatestProcessDefinition.
+// Check the length with:
+//
+//	len(mockedClient.GetLatestProcessDefinitionCalls())
+func (mock *ClientMock) GetLatestProcessDefinitionCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockGetLatestProcessDefinition.RLock()
+	calls = mock.calls.GetLatestProcessDefinition
+	mock.lockGetLatestProcessDefinition.RUnlock()
+	return calls
+}
+
+// GetProcessDefinition calls GetProcessDefinitionFunc.
+func (mock *ClientMock) GetProcessDefinition(pid string) (*activiti.ActProcessDefinition, error) {
+	if mock.GetProcessDefinitionFunc == nil {
+		panic("ClientMock.GetProcessDefinitionFunc: method is nil but Client.GetProcessDefinition was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinition.Lock()
+	mock.calls.GetProcessDefinition = append(mock.calls.GetProcessDefinition, callInfo)
+	mock.lockGetProcessDefinition.Unlock()
+	return mock.GetProcessDefinitionFunc(pid)
+}
+
+// GetProcessDefinitionCalls gets all the calls that were made to GetProcessDefinition.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessDefinitionCalls())
+func (mock *ClientMock) GetProcessDefinitionCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinition.RLock()
+	calls = mock.calls.GetProcessDefinition
+	mock.lockGetProcessDefinition.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitionMeta calls GetProcessDefinitionMetaFunc.
+func (mock *ClientMock) GetProcessDefinitionMeta(pid string) (*activiti.ActProcessDefinitionMeta, error) {
+	if mock.GetProcessDefinitionMetaFunc == nil {
+		panic("ClientMock.GetProcessDefinitionMetaFunc: method is nil but Client.GetProcessDefinitionMeta was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinitionMeta.Lock()
+	mock.calls.GetProcessDefinitionMeta = append(mock.calls.GetProcessDefinitionMeta, callInfo)
+	mock.lockGetProcessDefinitionMeta.Unlock()
+	return mock.GetProcessDefinitionMetaFunc(pid)
+}
+
+// GetProcessDefinitionMetaCalls gets all the calls that were made to GetProcessDefinitionMeta.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessDefinitionMetaCalls())
+func (mock *ClientMock) GetProcessDefinitionMetaCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinitionMeta.RLock()
+	calls = mock.calls.GetProcessDefinitionMeta
+	mock.lockGetProcessDefinitionMeta.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitionModel calls GetProcessDefinitionModelFunc.
+func (mock *ClientMock) GetProcessDefinitionModel(pid string) ([]byte, error) {
+	if mock.GetProcessDefinitionModelFunc == nil {
+		panic("ClientMock.GetProcessDefinitionModelFunc: method is nil but Client.GetProcessDefinitionModel was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDefinitionModel.Lock()
+	mock.calls.GetProcessDefinitionModel = append(mock.calls.GetProcessDefinitionModel, callInfo)
+	mock.lockGetProcessDefinitionModel.Unlock()
+	return mock.GetProcessDefinitionModelFunc(pid)
+}
+
+// GetProcessDefinitionModelCalls gets all the calls that were made to GetProcessDefinitionModel.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessDefinitionModelCalls())
+func (mock *ClientMock) GetProcessDefinitionModelCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDefinitionModel.RLock()
+	calls = mock.calls.GetProcessDefinitionModel
+	mock.lockGetProcessDefinitionModel.RUnlock()
+	return calls
+}
+
+// GetProcessDefinitions calls GetProcessDefinitionsFunc.
+func (mock *ClientMock) GetProcessDefinitions() (activiti.ActListProcessDefinitions, error) {
+	if mock.GetProcessDefinitionsFunc == nil {
+		panic("ClientMock.GetProcessDefinitionsFunc: method is nil but Client.GetProcessDefinitions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProcessDefinitions.Lock()
+	mock.calls.GetProcessDefinitions = append(mock.calls.GetProcessDefinitions, callInfo)
+	mock.lockGetProcessDefinitions.Unlock()
+	return mock.GetProcessDefinitionsFunc()
+}
+
+// GetProcessDefinitionsCalls gets all the calls that were made to GetProcessDefinitions.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessDefinitionsCalls())
+func (mock *ClientMock) GetProcessDefinitionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProcessDefinitions.RLock()
+	calls = mock.calls.GetProcessDefinitions
+	mock.lockGetProcessDefinitions.RUnlock()
+	return calls
+}
+
+// GetProcessDiagram calls GetProcessDiagramFunc.
+func (mock *ClientMock) GetProcessDiagram(pid string) ([]byte, error) {
+	if mock.GetProcessDiagramFunc == nil {
+		panic("ClientMock.GetProcessDiagramFunc: method is nil but Client.GetProcessDiagram was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessDiagram.Lock()
+	mock.calls.GetProcessDiagram = append(mock.calls.GetProcessDiagram, callInfo)
+	mock.lockGetProcessDiagram.Unlock()
+	return mock.GetProcessDiagramFunc(pid)
+}
+
+// GetProcessDiagramCalls gets all the calls that were made to GetProcessDiagram.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessDiagramCalls())
+func (mock *ClientMock) GetProcessDiagramCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessDiagram.RLock()
+	calls = mock.calls.GetProcessDiagram
+	mock.lockGetProcessDiagram.RUnlock()
+	return calls
+}
+
+// GetProcessInstance calls GetProcessInstanceFunc.
+func (mock *ClientMock) GetProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.GetProcessInstanceFunc == nil {
+		panic("ClientMock.GetProcessInstanceFunc: method is nil but Client.GetProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessInstance.Lock()
+	mock.calls.GetProcessInstance = append(mock.calls.GetProcessInstance, callInfo)
+	mock.lockGetProcessInstance.Unlock()
+	return mock.GetProcessInstanceFunc(pid)
+}
+
+// GetProcessInstanceCalls gets all the calls that were made to GetProcessInstance.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessInstanceCalls())
+func (mock *ClientMock) GetProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessInstance.RLock()
+	calls = mock.calls.GetProcessInstance
+	mock.lockGetProcessInstance.RUnlock()
+	return calls
+}
+
+// GetProcessInstances calls GetProcessInstancesFunc.
+func (mock *ClientMock) GetProcessInstances() (*activiti.ActListProcessInstances, error) {
+	if mock.GetProcessInstancesFunc == nil {
+		panic("ClientMock.GetProcessInstancesFunc: method is nil but Client.GetProcessInstances was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetProcessInstances.Lock()
+	mock.calls.GetProcessInstances = append(mock.calls.GetProcessInstances, callInfo)
+	mock.lockGetProcessInstances.Unlock()
+	return mock.GetProcessInstancesFunc()
+}
+
+// GetProcessInstancesCalls gets all the calls that were made to GetProcessInstances.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessInstancesCalls())
+func (mock *ClientMock) GetProcessInstancesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetProcessInstances.RLock()
+	calls = mock.calls.GetProcessInstances
+	mock.lockGetProcessInstances.RUnlock()
+	return calls
+}
+
+// GetProcessVariables calls GetProcessVariablesFunc.
+func (mock *ClientMock) GetProcessVariables(pid string) (*activiti.ActListProcessVariables, error) {
+	if mock.GetProcessVariablesFunc == nil {
+		panic("ClientMock.GetProcessVariablesFunc: method is nil but Client.GetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockGetProcessVariables.Lock()
+	mock.calls.GetProcessVariables = append(mock.calls.GetProcessVariables, callInfo)
+	mock.lockGetProcessVariables.Unlock()
+	return mock.GetProcessVariablesFunc(pid)
+}
+
+// GetProcessVariablesCalls gets all the calls that were made to GetProcessVariables.
+// Check the length with:
+//
+//	len(mockedClient.GetProcessVariablesCalls())
+func (mock *ClientMock) GetProcessVariablesCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockGetProcessVariables.RLock()
+	calls = mock.calls.GetProcessVariables
+	mock.lockGetProcessVariables.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *ClientMock) GetTask(tid string) (*activiti.ActTask, error) {
+	if mock.GetTaskFunc == nil {
+		panic("ClientMock.GetTaskFunc: method is nil but Client.GetTask was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(tid)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedClient.GetTaskCalls())
+func (mock *ClientMock) GetTaskCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// GetTaskCandidateGroups calls GetTaskCandidateGroupsFunc.
+func (mock *ClientMock) GetTaskCandidateGroups(tid string) ([]string, error) {
+	if mock.GetTaskCandidateGroupsFunc == nil {
+		panic("ClientMock.GetTaskCandidateGroupsFunc: method is nil but Client.GetTaskCandidateGroups was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTaskCandidateGroups.Lock()
+	mock.calls.GetTaskCandidateGroups = append(mock.calls.GetTaskCandidateGroups, callInfo)
+	mock.lockGetTaskCandidateGroups.Unlock()
+	return mock.GetTaskCandidateGroupsFunc(tid)
+}
+
+// GetTaskCandidateGroupsCalls gets all the calls that were made to GetTaskCandidateGroups.
+// Check the length with:
+//
+//	len(mockedClient.GetTaskCandidateGroupsCalls())
+func (mock *ClientMock) GetTaskCandidateGroupsCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTaskCandidateGroups.RLock()
+	calls = mock.calls.GetTaskCandidateGroups
+	mock.lockGetTaskCandidateGroups.RUnlock()
+	return calls
+}
+
+// GetTaskCandidateUsers calls GetTaskCandidateUsersFunc.
+func (mock *ClientMock) GetTaskCandidateUsers(tid string) ([]string, error) {
+	if mock.GetTaskCandidateUsersFunc == nil {
+		panic("ClientMock.GetTaskCandidateUsersFunc: method is nil but Client.GetTaskCandidateUsers was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockGetTaskCandidateUsers.Lock()
+	mock.calls.GetTaskCandidateUsers = append(mock.calls.GetTaskCandidateUsers, callInfo)
+	mock.lockGetTaskCandidateUsers.Unlock()
+	return mock.GetTaskCandidateUsersFunc(tid)
+}
+
+// GetTaskCandidateUsersCalls gets all the calls that were made to GetTaskCandidateUsers.
+// Check the length with:
+//
+//	len(mockedClient.GetTaskCandidateUsersCalls())
+func (mock *ClientMock) GetTaskCandidateUsersCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockGetTaskCandidateUsers.RLock()
+	calls = mock.calls.GetTaskCandidateUsers
+	mock.lockGetTaskCandidateUsers.RUnlock()
+	return calls
+}
+
+// GetTasks calls GetTasksFunc.
+func (mock *ClientMock) GetTasks() (*activiti.ActListTasks, error) {
+	if mock.GetTasksFunc == nil {
+		panic("ClientMock.GetTasksFunc: method is nil but Client.GetTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTasks.Lock()
+	mock.calls.GetTasks = append(mock.calls.GetTasks, callInfo)
+	mock.lockGetTasks.Unlock()
+	return mock.GetTasksFunc()
+}
+
+// GetTasksCalls gets all the calls that were made to GetTasks.
+// Check the length with:
+//
+//	len(mockedClient.GetTasksCalls())
+func (mock *ClientMock) GetTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTasks.RLock()
+	calls = mock.calls.GetTasks
+	mock.lockGetTasks.RUnlock()
+	return calls
+}
+
+// GetUser calls GetUserFunc.
+func (mock *ClientMock) GetUser(uid string) (*activiti.ActUser, error) {
+	if mock.GetUserFunc == nil {
+		panic("ClientMock.GetUserFunc: method is nil but Client.GetUser was just called")
+	}
+	callInfo := struct {
+		UID string
+	}{
+		UID: uid,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(uid)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedClient.GetUserCalls())
+func (mock *ClientMock) GetUserCalls() []struct {
+	UID string
+} {
+	var calls []struct {
+		UID string
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// GetUsers calls GetUsersFunc.
+func (mock *ClientMock) GetUsers() (*activiti.ActUsers, error) {
+	if mock.GetUsersFunc == nil {
+		panic("ClientMock.GetUsersFunc: method is nil but Client.GetUsers was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetUsers.Lock()
+	mock.calls.GetUsers = append(mock.calls.GetUsers, callInfo)
+	mock.lockGetUsers.Unlock()
+	return mock.GetUsersFunc()
+}
+
+// GetUsersCalls gets all the calls that were made to GetUsers.
+// Check the length with:
+//
+//	len(mockedClient.GetUsersCalls())
+func (mock *ClientMock) GetUsersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetUsers.RLock()
+	calls = mock.calls.GetUsers
+	mock.lockGetUsers.RUnlock()
+	return calls
+}
+
+// ProcessInstancesTasks calls ProcessInstancesTasksFunc.
+func (mock *ClientMock) ProcessInstancesTasks(key string) (*activiti.ActListTasks, error) {
+	if mock.ProcessInstancesTasksFunc == nil {
+		panic("ClientMock.ProcessInstancesTasksFunc: method is nil but Client.ProcessInstancesTasks was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockProcessInstancesTasks.Lock()
+	mock.calls.ProcessInstancesTasks = append(mock.calls.ProcessInstancesTasks, callInfo)
+	mock.lockProcessInstancesTasks.Unlock()
+	return mock.ProcessInstancesTasksFunc(key)
+}
+
+// ProcessInstancesTasksCalls gets all the calls that were made to ProcessInstancesTasks.
+// Check the length with:
+//
+//	len(mockedClient.ProcessInstancesTasksCalls())
+func (mock *ClientMock) ProcessInstancesTasksCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockProcessInstancesTasks.RLock()
+	calls = mock.calls.ProcessInstancesTasks
+	mock.lockProcessInstancesTasks.RUnlock()
+	return calls
+}
+
+// QueryProcessInstances calls QueryProcessInstancesFunc.
+func (mock *ClientMock) QueryProcessInstances(params url.Values) (*activiti.ActListProcessInstances, error) {
+	if mock.QueryProcessInstancesFunc == nil {
+		panic("ClientMock.QueryProcessInstancesFunc: method is nil but Client.QueryProcessInstances was just called")
+	}
+	callInfo := struct {
+		Params url.Values
+	}{
+		Params: params,
+	}
+	mock.lockQueryProcessInstances.Lock()
+	mock.calls.QueryProcessInstances = append(mock.calls.QueryProcessInstances, callInfo)
+	mock.lockQueryProcessInstances.Unlock()
+	return mock.QueryProcessInstancesFunc(params)
+}
+
+// QueryProcessInstancesCalls gets all the calls that were made to QueryProcessInstances.
+// Check the length with:
+//
+//	len(mockedClient.QueryProcessInstancesCalls())
+func (mock *ClientMock) QueryProcessInstancesCalls() []struct {
+	Params url.Values
+} {
+	var calls []struct {
+		Params url.Values
+	}
+	mock.lockQueryProcessInstances.RLock()
+	calls = mock.calls.QueryProcessInstances
+	mock.lockQueryProcessInstances.RUnlock()
+	return calls
+}
+
+// QueryTasks calls QueryTasksFunc.
+func (mock *ClientMock) QueryTasks(params url.Values) (*activiti.ActListTasks, error) {
+	if mock.QueryTasksFunc == nil {
+		panic("ClientMock.QueryTasksFunc: method is nil but Client.QueryTasks was just called")
+	}
+	callInfo := struct {
+		Params url.Values
+	}{
+		Params: params,
+	}
+	mock.lockQueryTasks.Lock()
+	mock.calls.QueryTasks = append(mock.calls.QueryTasks, callInfo)
+	mock.lockQueryTasks.Unlock()
+	return mock.QueryTasksFunc(params)
+}
+
+// QueryTasksCalls gets all the calls that were made to QueryTasks.
+// Check the length with:
+//
+//	len(mockedClient.QueryTasksCalls())
+func (mock *ClientMock) QueryTasksCalls() []struct {
+	Params url.Values
+} {
+	var calls []struct {
+		Params url.Values
+	}
+	mock.lockQueryTasks.RLock()
+	calls = mock.calls.QueryTasks
+	mock.lockQueryTasks.RUnlock()
+	return calls
+}
+
+// ResumeProcessInstance calls ResumeProcessInstanceFunc.
+func (mock *ClientMock) ResumeProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.ResumeProcessInstanceFunc == nil {
+		panic("ClientMock.ResumeProcessInstanceFunc: method is nil but Client.ResumeProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockResumeProcessInstance.Lock()
+	mock.calls.ResumeProcessInstance = append(mock.calls.ResumeProcessInstance, callInfo)
+	mock.lockResumeProcessInstance.Unlock()
+	return mock.ResumeProcessInstanceFunc(pid)
+}
+
+// ResumeProcessInstanceCalls gets all the calls that were made to ResumeProcessInstance.
+// Check the length with:
+//
+//	len(mockedClient.ResumeProcessInstanceCalls())
+func (mock *ClientMock) ResumeProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockResumeProcessInstance.RLock()
+	calls = mock.calls.ResumeProcessInstance
+	mock.lockResumeProcessInstance.RUnlock()
+	return calls
+}
+
+// SetProcessVariables calls SetProcessVariablesFunc.
+func (mock *ClientMock) SetProcessVariables(pid string, variables map[string]interface{}) error {
+	if mock.SetProcessVariablesFunc == nil {
+		panic("ClientMock.SetProcessVariablesFunc: method is nil but Client.SetProcessVariables was just called")
+	}
+	callInfo := struct {
+		Pid       string
+		Variables map[string]interface{}
+	}{
+		Pid:       pid,
+		Variables: variables,
+	}
+	mock.lockSetProcessVariables.Lock()
+	mock.calls.SetProcessVariables = append(mock.calls.SetProcessVariables, callInfo)
+	mock.lockSetProcessVariables.Unlock()
+	return mock.SetProcessVariablesFunc(pid, variables)
+}
+
+// SetProcessVariablesCalls gets all the calls that were made to SetProcessVariables.
+// Check the length with:
+//
+//	len(mockedClient.SetProcessVariablesCalls())
+func (mock *ClientMock) SetProcessVariablesCalls() []struct {
+	Pid       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Pid       string
+		Variables map[string]interface{}
+	}
+	mock.lockSetProcessVariables.RLock()
+	calls = mock.calls.SetProcessVariables
+	mock.lockSetProcessVariables.RUnlock()
+	return calls
+}
+
+// StartProcessInstance calls StartProcessInstanceFunc.
+func (mock *ClientMock) StartProcessInstance(ctx context.Context, opts activiti.StartOptions) (*activiti.StartResult, error) {
+	if mock.StartProcessInstanceFunc == nil {
+		panic("ClientMock.StartProcessInstanceFunc: method is nil but Client.StartProcessInstance was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Opts activiti.StartOptions
+	}{
+		Ctx:  ctx,
+		Opts: opts,
+	}
+	mock.lockStartProcessInstance.Lock()
+	mock.calls.StartProcessInstance = append(mock.calls.StartProcessInstance, callInfo)
+	mock.lockStartProcessInstance.Unlock()
+	return mock.StartProcessInstanceFunc(ctx, opts)
+}
+
+// StartProcessInstanceCalls gets all the calls that were made to StartProcessInstance.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceCalls())
+func (mock *ClientMock) StartProcessInstanceCalls() []struct {
+	Ctx  context.Context
+	Opts activiti.StartOptions
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Opts activiti.StartOptions
+	}
+	mock.lockStartProcessInstance.RLock()
+	calls = mock.calls.StartProcessInstance
+	mock.lockStartProcessInstance.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceById calls StartProcessInstanceByIdFunc.
+func (mock *ClientMock) StartProcessInstanceById(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceByIdFunc == nil {
+		panic("ClientMock.StartProcessInstanceByIdFunc: method is nil but Client.StartProcessInstanceById was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockStartProcessInstanceById.Lock()
+	mock.calls.StartProcessInstanceById = append(mock.calls.StartProcessInstanceById, callInfo)
+	mock.lockStartProcessInstanceById.Unlock()
+	return mock.StartProcessInstanceByIdFunc(pid)
+}
+
+// StartProcessInstanceByIdCalls gets all the calls that were made to StartProcessInstanceById.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceByIdCalls())
+func (mock *ClientMock) StartProcessInstanceByIdCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockStartProcessInstanceById.RLock()
+	calls = mock.calls.StartProcessInstanceById
+	mock.lockStartProcessInstanceById.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceByKey calls StartProcessInstanceByKeyFunc.
+func (mock *ClientMock) StartProcessInstanceByKey(key string) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceByKeyFunc == nil {
+		panic("ClientMock.StartProcessInstanceByKeyFunc: method is nil but Client.StartProcessInstanceByKey was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockStartProcessInstanceByKey.Lock()
+	mock.calls.StartProcessInstanceByKey = append(mock.calls.StartProcessInstanceByKey, callInfo)
+	mock.lockStartProcessInstanceByKey.Unlock()
+	return mock.StartProcessInstanceByKeyFunc(key)
+}
+
+// StartProcessInstanceByKeyCalls gets all the calls that were made to StartProcessInstanceByKey.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceByKeyCalls())
+func (mock *ClientMock) StartProcessInstanceByKeyCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockStartProcessInstanceByKey.RLock()
+	calls = mock.calls.StartProcessInstanceByKey
+	mock.lockStartProcessInstanceByKey.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceOnce calls StartProcessInstanceOnceFunc.
+func (mock *ClientMock) StartProcessInstanceOnce(key string, businessKey string, variables map[string]interface{}, policy activiti.ConflictPolicy) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceOnceFunc == nil {
+		panic("ClientMock.StartProcessInstanceOnceFunc: method is nil but Client.StartProcessInstanceOnce was just called")
+	}
+	callInfo := struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+		Policy      activiti.ConflictPolicy
+	}{
+		Key:         key,
+		BusinessKey: businessKey,
+		Variables:   variables,
+		Policy:      policy,
+	}
+	mock.lockStartProcessInstanceOnce.Lock()
+	mock.calls.StartProcessInstanceOnce = append(mock.calls.StartProcessInstanceOnce, callInfo)
+	mock.lockStartProcessInstanceOnce.Unlock()
+	return mock.StartProcessInstanceOnceFunc(key, businessKey, variables, policy)
+}
+
+// StartProcessInstanceOnceCalls gets all the calls that were made to StartProcessInstanceOnce.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceOnceCalls())
+func (mock *ClientMock) StartProcessInstanceOnceCalls() []struct {
+	Key         string
+	BusinessKey string
+	Variables   map[string]interface{}
+	Policy      activiti.ConflictPolicy
+} {
+	var calls []struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+		Policy      activiti.ConflictPolicy
+	}
+	mock.lockStartProcessInstanceOnce.RLock()
+	calls = mock.calls.StartProcessInstanceOnce
+	mock.lockStartProcessInstanceOnce.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceWithBusinessKeyAndVariables calls StartProcessInstanceWithBusinessKeyAndVariablesFunc.
+func (mock *ClientMock) StartProcessInstanceWithBusinessKeyAndVariables(key string, BusinessKey string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceWithBusinessKeyAndVariablesFunc == nil {
+		panic("ClientMock.StartProcessInstanceWithBusinessKeyAndVariablesFunc: method is nil but Client.StartProcessInstanceWithBusinessKeyAndVariables was just called")
+	}
+	callInfo := struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+	}{
+		Key:         key,
+		BusinessKey: BusinessKey,
+		Variables:   variables,
+	}
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.Lock()
+	mock.calls.StartProcessInstanceWithBusinessKeyAndVariables = append(mock.calls.StartProcessInstanceWithBusinessKeyAndVariables, callInfo)
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.Unlock()
+	return mock.StartProcessInstanceWithBusinessKeyAndVariablesFunc(key, BusinessKey, variables)
+}
+
+// StartProcessInstanceWithBusinessKeyAndVariablesCalls gets all the calls that were made to StartProcessInstanceWithBusinessKeyAndVariables.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceWithBusinessKeyAndVariablesCalls())
+func (mock *ClientMock) StartProcessInstanceWithBusinessKeyAndVariablesCalls() []struct {
+	Key         string
+	BusinessKey string
+	Variables   map[string]interface{}
+} {
+	var calls []struct {
+		Key         string
+		BusinessKey string
+		Variables   map[string]interface{}
+	}
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.RLock()
+	calls = mock.calls.StartProcessInstanceWithBusinessKeyAndVariables
+	mock.lockStartProcessInstanceWithBusinessKeyAndVariables.RUnlock()
+	return calls
+}
+
+// StartProcessInstanceWithVariables calls StartProcessInstanceWithVariablesFunc.
+func (mock *ClientMock) StartProcessInstanceWithVariables(key string, variables map[string]interface{}) (*activiti.ActProcessInstance, error) {
+	if mock.StartProcessInstanceWithVariablesFunc == nil {
+		panic("ClientMock.StartProcessInstanceWithVariablesFunc: method is nil but Client.StartProcessInstanceWithVariables was just called")
+	}
+	callInfo := struct {
+		Key       string
+		Variables map[string]interface{}
+	}{
+		Key:       key,
+		Variables: variables,
+	}
+	mock.lockStartProcessInstanceWithVariables.Lock()
+	mock.calls.StartProcessInstanceWithVariables = append(mock.calls.StartProcessInstanceWithVariables, callInfo)
+	mock.lockStartProcessInstanceWithVariables.Unlock()
+	return mock.StartProcessInstanceWithVariablesFunc(key, variables)
+}
+
+// StartProcessInstanceWithVariablesCalls gets all the calls that were made to StartProcessInstanceWithVariables.
+// Check the length with:
+//
+//	len(mockedClient.StartProcessInstanceWithVariablesCalls())
+func (mock *ClientMock) StartProcessInstanceWithVariablesCalls() []struct {
+	Key       string
+	Variables map[string]interface{}
+} {
+	var calls []struct {
+		Key       string
+		Variables map[string]interface{}
+	}
+	mock.lockStartProcessInstanceWithVariables.RLock()
+	calls = mock.calls.StartProcessInstanceWithVariables
+	mock.lockStartProcessInstanceWithVariables.RUnlock()
+	return calls
+}
+
+// SuspendProcessInstance calls SuspendProcessInstanceFunc.
+func (mock *ClientMock) SuspendProcessInstance(pid string) (*activiti.ActProcessInstance, error) {
+	if mock.SuspendProcessInstanceFunc == nil {
+		panic("ClientMock.SuspendProcessInstanceFunc: method is nil but Client.SuspendProcessInstance was just called")
+	}
+	callInfo := struct {
+		Pid string
+	}{
+		Pid: pid,
+	}
+	mock.lockSuspendProcessInstance.Lock()
+	mock.calls.SuspendProcessInstance = append(mock.calls.SuspendProcessInstance, callInfo)
+	mock.lockSuspendProcessInstance.Unlock()
+	return mock.SuspendProcessInstanceFunc(pid)
+}
+
+// SuspendProcessInstanceCalls gets all the calls that were made to SuspendProcessInstance.
+// Check the length with:
+//
+//	len(mockedClient.SuspendProcessInstanceCalls())
+func (mock *ClientMock) SuspendProcessInstanceCalls() []struct {
+	Pid string
+} {
+	var calls []struct {
+		Pid string
+	}
+	mock.lockSuspendProcessInstance.RLock()
+	calls = mock.calls.SuspendProcessInstance
+	mock.lockSuspendProcessInstance.RUnlock()
+	return calls
+}
+
+// TaskActionAssign calls TaskActionAssignFunc.
+func (mock *ClientMock) TaskActionAssign(tid string, assignee string) error {
+	if mock.TaskActionAssignFunc == nil {
+		panic("ClientMock.TaskActionAssignFunc: method is nil but Client.TaskActionAssign was just called")
+	}
+	callInfo := struct {
+		Tid      string
+		Assignee string
+	}{
+		Tid:      tid,
+		Assignee: assignee,
+	}
+	mock.lockTaskActionAssign.Lock()
+	mock.calls.TaskActionAssign = append(mock.calls.TaskActionAssign, callInfo)
+	mock.lockTaskActionAssign.Unlock()
+	return mock.TaskActionAssignFunc(tid, assignee)
+}
+
+// TaskActionAssignCalls gets all the calls that were made to TaskActionAssign.
+// Check the length with:
+//
+//	len(mockedClient.TaskActionAssignCalls())
+func (mock *ClientMock) TaskActionAssignCalls() []struct {
+	Tid      string
+	Assignee string
+} {
+	var calls []struct {
+		Tid      string
+		Assignee string
+	}
+	mock.lockTaskActionAssign.RLock()
+	calls = mock.calls.TaskActionAssign
+	mock.lockTaskActionAssign.RUnlock()
+	return calls
+}
+
+// TaskActionClaim calls TaskActionClaimFunc.
+func (mock *ClientMock) TaskActionClaim(tid string, assignee string) error {
+	if mock.TaskActionClaimFunc == nil {
+		panic("ClientMock.TaskActionClaimFunc: method is nil but Client.TaskActionClaim was just called")
+	}
+	callInfo := struct {
+		Tid      string
+		Assignee string
+	}{
+		Tid:      tid,
+		Assignee: assignee,
+	}
+	mock.lockTaskActionClaim.Lock()
+	mock.calls.TaskActionClaim = append(mock.calls.TaskActionClaim, callInfo)
+	mock.lockTaskActionClaim.Unlock()
+	return mock.TaskActionClaimFunc(tid, assignee)
+}
+
+// TaskActionClaimCalls gets all the calls that were made to TaskActionClaim.
+// Check the length with:
+//
+//	len(mockedClient.TaskActionClaimCalls())
+func (mock *ClientMock) TaskActionClaimCalls() []struct {
+	Tid      string
+	Assignee string
+} {
+	var calls []struct {
+		Tid      string
+		Assignee string
+	}
+	mock.lockTaskActionClaim.RLock()
+	calls = mock.calls.TaskActionClaim
+	mock.lockTaskActionClaim.RUnlock()
+	return calls
+}
+
+// TaskActionComplete calls TaskActionCompleteFunc.
+func (mock *ClientMock) TaskActionComplete(tid string) error {
+	if mock.TaskActionCompleteFunc == nil {
+		panic("ClientMock.TaskActionCompleteFunc: method is nil but Client.TaskActionComplete was just called")
+	}
+	callInfo := struct {
+		Tid string
+	}{
+		Tid: tid,
+	}
+	mock.lockTaskActionComplete.Lock()
+	mock.calls.TaskActionComplete = append(mock.calls.TaskActionComplete, callInfo)
+	mock.lockTaskActionComplete.Unlock()
+	return mock.TaskActionCompleteFunc(tid)
+}
+
+// TaskActionCompleteCalls gets all the calls that were made to TaskActionComplete.
+// Check the length with:
+//
+//	len(mockedClient.TaskActionCompleteCalls())
+func (mock *ClientMock) TaskActionCompleteCalls() []struct {
+	Tid string
+} {
+	var calls []struct {
+		Tid string
+	}
+	mock.lockTaskActionComplete.RLock()
+	calls = mock.calls.TaskActionComplete
+	mock.lockTaskActionComplete.RUnlock()
+	return calls
+}
+
+// TaskActionCompleteWithVariables calls TaskActionCompleteWithVariablesFunc.
+func (mock *ClientMock) TaskActionCompleteWithVariables(tid string, v map[string]string) error {
+	if mock.TaskActionCompleteWithVariablesFunc == nil {
+		panic("ClientMock.TaskActionCompleteWithVariablesFunc: method is nil but Client.TaskActionCompleteWithVariables was just called")
+	}
+	callInfo := struct {
+		Tid string
+		V   map[string]string
+	}{
+		Tid: tid,
+		V:   v,
+	}
+	mock.lockTaskActionCompleteWithVariables.Lock()
+	mock.calls.TaskActionCompleteWithVariables = append(mock.calls.TaskActionCompleteWithVariables, callInfo)
+	mock.lockTaskActionCompleteWithVariables.Unlock()
+	return mock.TaskActionCompleteWithVariablesFunc(tid, v)
+}
+
+// TaskActionCompleteWithVariablesCalls gets all the calls that were made to TaskActionCompleteWithVariables.
+// Check the length with:
+//
+//	len(mockedClient.TaskActionCompleteWithVariablesCalls())
+func (mock *ClientMock) TaskActionCompleteWithVariablesCalls() []struct {
+	Tid string
+	V   map[string]string
+} {
+	var calls []struct {
+		Tid string
+		V   map[string]string
+	}
+	mock.lockTaskActionCompleteWithVariables.RLock()
+	calls = mock.calls.TaskActionCompleteWithVariables
+	mock.lockTaskActionCompleteWithVariables.RUnlock()
+	return calls
+}
+
+// UpdateTask calls UpdateTaskFunc.
+func (mock *ClientMock) UpdateTask(tid string, u activiti.ActUpdateTask) (*activiti.ActTask, error) {
+	if mock.UpdateTaskFunc == nil {
+		panic("ClientMock.UpdateTaskFunc: method is nil but Client.UpdateTask was just called")
+	}
+	callInfo := struct {
+		Tid string
+		U   activiti.ActUpdateTask
+	}{
+		Tid: tid,
+		U:   u,
+	}
+	mock.lockUpdateTask.Lock()
+	mock.calls.UpdateTask = append(mock.calls.UpdateTask, callInfo)
+	mock.lockUpdateTask.Unlock()
+	return mock.UpdateTaskFunc(tid, u)
+}
+
+// UpdateTaskCalls gets all the calls that were made to UpdateTask.
+// Check the length with:
+//
+//	len(mockedClient.UpdateTaskCalls())
+func (mock *ClientMock) UpdateTaskCalls() []struct {
+	Tid string
+	U   activiti.ActUpdateTask
+} {
+	var calls []struct {
+		Tid string
+		U   activiti.ActUpdateTask
+	}
+	mock.lockUpdateTask.RLock()
+	calls = mock.calls.UpdateTask
+	mock.lockUpdateTask.RUnlock()
+	return calls
+}
+
+// UpdateUser calls UpdateUserFunc.
+func (mock *ClientMock) UpdateUser(u activiti.ActUser) (*activiti.ActUser, error) {
+	if mock.UpdateUserFunc == nil {
+		panic("ClientMock.UpdateUserFunc: method is nil but Client.UpdateUser was just called")
+	}
+	callInfo := struct {
+		U activiti.ActUser
+	}{
+		U: u,
+	}
+	mock.lockUpdateUser.Lock()
+	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
+	mock.lockUpdateUser.Unlock()
+	return mock.UpdateUserFunc(u)
+}
+
+// UpdateUserCalls gets all the calls that were made to UpdateUser.
+// Check the length with:
+//
+//	len(mockedClient.UpdateUserCalls())
+func (mock *ClientMock) UpdateUserCalls() []struct {
+	U activiti.ActUser
+} {
+	var calls []struct {
+		U activiti.ActUser
+	}
+	mock.lockUpdateUser.RLock()
+	calls = mock.calls.UpdateUser
+	mock.lockUpdateUser.RUnlock()
+	return calls
+}
@@ -0,0 +1,142 @@
+// Package otelactiviti instruments ActClient with OpenTelemetry.
+//
+//	c.Use(otelactiviti.Middleware())
+//
+// Every API call gets a client span named after the operation, for example
+// "activiti.StartProcessInstance", the trace context is propagated in the request
+// headers, and request count and latency are recorded per operation.
+//
+// Spans are children of the span of the request context. Make calls with a copy of
+// the client bound to the caller context, otherwise every span is a root span:
+//
+//	c.WithContext(ctx).CompleteTask(taskID, variables)
+package otelactiviti
+
+import (
+	"net/http"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter
+const ScopeName = "github.com/lihongchen/go-activiti-rest/otelactiviti"
+
+type (
+	// Option configures the instrumentation
+	Option func(*config)
+
+	config struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagators    propagation.TextMapPropagator
+	}
+)
+
+// WithTracerProvider sets the TracerProvider, the global one is used by default
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider, the global one is used by default
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators injecting trace context headers,
+// the global ones are used by default
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Middleware returns an activiti.Middleware creating a span and recording metrics for every request
+func Middleware(opts ...Option) activiti.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	// Instrument creation only fails for invalid names, the no-op instruments
+	// returned along with the error are still safe to use
+	requests, err := meter.Int64Counter("activiti.client.requests",
+		metric.WithDescription("Number of Activiti API requests"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("activiti.client.duration",
+		metric.WithDescription("Duration of Activiti API requests"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next activiti.RoundTripFunc) activiti.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			op := activiti.Operation(req)
+			if op == "" {
+				op = "Request"
+			}
+
+			ctx, span := tracer.Start(req.Context(), "activiti."+op,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("activiti.operation", op),
+					attribute.String("http.request.method", req.Method),
+					attribute.String("url.full", req.URL.String()),
+					attribute.String("server.address", req.URL.Hostname()),
+				))
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start).Seconds()
+
+			attrs := []attribute.KeyValue{
+				attribute.String("activiti.operation", op),
+				attribute.String("http.request.method", req.Method),
+			}
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, attribute.String("error.type", "transport"))
+			default:
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					span.SetStatus(codes.Error, resp.Status)
+					attrs = append(attrs, attribute.String("error.type", http.StatusText(resp.StatusCode)))
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+			requests.Add(ctx, 1, set)
+			duration.Record(ctx, elapsed, set)
+
+			return resp, err
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package activiti
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"net/url"
 )
 
 // GetProcessDefinition retrieves process definition by ID
@@ -9,15 +12,17 @@ import (
 func (c *ActClient) GetProcessDefinition(pid string) (*ActProcessDefinition, error) {
 	pd := &ActProcessDefinition{}
 	url := fmt.Sprintf("%s%s%s", c.BaseURL, "/process-definitions/", pid)
-
-	fmt.Println(url)
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/process-definitions/", pid), nil)
+	if c.definitionCache.get(url, pd) {
+		return pd, nil
+	}
+	req, err := c.newRequest("GetProcessDefinition", "GET", url, nil)
 	if err != nil {
 		return pd, err
 	}
 	if err = c.SendWithBasicAuth(req, &pd); err != nil {
 		return pd, err
 	}
+	c.definitionCache.put(url, pd)
 
 	return pd, nil
 }
@@ -27,8 +32,10 @@ func (c *ActClient) GetProcessDefinition(pid string) (*ActProcessDefinition, err
 func (c *ActClient) GetProcessDefinitions() (ActListProcessDefinitions, error) {
 	pds := ActListProcessDefinitions{}
 	url := fmt.Sprintf("%s%s", c.BaseURL, "/process-definitions")
-	fmt.Println(url)
-	req, err := c.NewRequest("GET", url, nil)
+	if c.definitionCache.get(url, &pds) {
+		return pds, nil
+	}
+	req, err := c.newRequest("GetProcessDefinitions", "GET", url, nil)
 	if err != nil {
 		return pds, err
 	}
@@ -36,21 +43,126 @@ func (c *ActClient) GetProcessDefinitions() (ActListProcessDefinitions, error) {
 	if err = c.SendWithBasicAuth(req, &pds); err != nil {
 		return pds, err
 	}
+	c.definitionCache.put(url, pds)
 	return pds, nil
 }
 
+// GetProcessDefinitionModel retrieves the BPMN XML of a process definition
+// Endpoint: GET runtime/process-definitions/{processDefinitionId}/model
+func (c *ActClient) GetProcessDefinitionModel(pid string) ([]byte, error) {
+	if pid == "" {
+		return nil, errors.New("Process definition id is required ")
+	}
+	var model []byte
+	url := fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-definitions/", pid, "/model")
+	if c.definitionCache.get(url, &model) {
+		return model, nil
+	}
+	req, err := c.newRequest("GetProcessDefinitionModel", "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	buf := &bytes.Buffer{}
+	if err = c.SendWithBasicAuth(req, buf); err != nil {
+		return nil, err
+	}
+	model = buf.Bytes()
+	c.definitionCache.put(url, model)
+	return model, nil
+}
+
 //GetProcessDefinitionMeta 获取process definition 元数据
 func (c *ActClient) GetProcessDefinitionMeta(pid string) (*ActProcessDefinitionMeta, error) {
 	pd := &ActProcessDefinitionMeta{}
 	url := fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-definitions/", pid, "/meta")
-
-	fmt.Println(url)
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-definitions/", pid, "/meta"), nil)
+	if c.definitionCache.get(url, pd) {
+		return pd, nil
+	}
+	req, err := c.newRequest("GetProcessDefinitionMeta", "GET", url, nil)
 	if err != nil {
 		return pd, err
 	}
 	if err = c.SendWithBasicAuth(req, &pd); err != nil {
 		return pd, err
 	}
+	c.definitionCache.put(url, pd)
 	return pd, nil
 }
+
+// FindProcessDefinitions returns the process definitions matching every non empty field of the filter,
+// paging through all process definitions of the runtime bundle
+func (c *ActClient) FindProcessDefinitions(f ProcessDefinitionFilter) ([]ProcessDefinition, error) {
+	var found []ProcessDefinition
+	err := queryPages(url.Values{}, func(p url.Values) (Pagination, int, error) {
+		pds, err := c.getProcessDefinitionsPage(p)
+		if err != nil {
+			return Pagination{}, 0, err
+		}
+		for _, pd := range pds.List.ProcessDefinitions {
+			if f.matches(pd.ProcessDefinition) {
+				found = append(found, pd.ProcessDefinition)
+			}
+		}
+		return pds.List.Pagination, len(pds.List.ProcessDefinitions), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// getProcessDefinitionsPage retrieves one page of process definitions, params holds skipCount and maxItems
+// Endpoint: GET runtime/process-definitions
+func (c *ActClient) getProcessDefinitionsPage(params url.Values) (*ActListProcessDefinitions, error) {
+	pds := &ActListProcessDefinitions{}
+	u := fmt.Sprintf("%s%s?%s", c.BaseURL, "/process-definitions", params.Encode())
+	if c.definitionCache.get(u, pds) {
+		return pds, nil
+	}
+	req, err := c.newRequest("GetProcessDefinitions", "GET", u, nil)
+	if err != nil {
+		return pds, err
+	}
+	if err = c.SendWithBasicAuth(req, pds); err != nil {
+		return pds, err
+	}
+	c.definitionCache.put(u, pds)
+	return pds, nil
+}
+
+// GetLatestProcessDefinition returns the highest version of the process definition key
+func (c *ActClient) GetLatestProcessDefinition(key string) (*ProcessDefinition, error) {
+	if key == "" {
+		return nil, errors.New("Process definition key is required ")
+	}
+	return c.findLatestProcessDefinition(ProcessDefinitionFilter{Key: key})
+}
+
+// findLatestProcessDefinition returns the highest version of the definitions matching the filter
+func (c *ActClient) findLatestProcessDefinition(f ProcessDefinitionFilter) (*ProcessDefinition, error) {
+	found, err := c.FindProcessDefinitions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *ProcessDefinition
+	for i := range found {
+		if latest == nil || found[i].Version > latest.Version {
+			latest = &found[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no process definition matches %+v", f)
+	}
+	return latest, nil
+}
+
+// matches reports whether the definition has every non empty field of the filter
+func (f ProcessDefinitionFilter) matches(pd ProcessDefinition) bool {
+	return (f.Key == "" || pd.Key == f.Key) &&
+		(f.Name == "" || pd.Name == f.Name) &&
+		(f.AppName == "" || pd.AppName == f.AppName) &&
+		(f.AppVersion == "" || pd.AppVersion == f.AppVersion)
+}
@@ -0,0 +1,102 @@
+package activiti
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// definitionServer serves the process definitions two per page and counts the requests
+func definitionServer(t *testing.T, pds []ProcessDefinition, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definitions" {
+			http.NotFound(w, r)
+			return
+		}
+		*requests++
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skipCount"))
+		max, _ := strconv.Atoi(r.URL.Query().Get("maxItems"))
+		if max == 0 || max > 2 {
+			max = 2
+		}
+		page := ActListProcessDefinitions{}
+		for i := skip; i < skip+max && i < len(pds); i++ {
+			page.List.ProcessDefinitions = append(page.List.ProcessDefinitions, ActProcessDefinition{ProcessDefinition: pds[i]})
+		}
+		page.List.Pagination.HasMoreItems = skip+max < len(pds)
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestFindProcessDefinitions(t *testing.T) {
+	pds := []ProcessDefinition{
+		{ID: "leave:1", Key: "leave", Version: 1, AppName: "hr"},
+		{ID: "expenses:1", Key: "expenses", Version: 1},
+		{ID: "leave:2", Key: "leave", Version: 2, AppName: "hr"},
+		{ID: "expenses:2", Key: "expenses", Version: 2},
+		{ID: "leave:3", Key: "leave", Version: 3, AppName: "other"},
+	}
+	tests := []struct {
+		name   string
+		filter ProcessDefinitionFilter
+		found  int
+		latest string
+	}{
+		{"all", ProcessDefinitionFilter{}, 5, "leave:3"},
+		{"key", ProcessDefinitionFilter{Key: "leave"}, 3, "leave:3"},
+		{"key and app", ProcessDefinitionFilter{Key: "leave", AppName: "hr"}, 2, "leave:2"},
+		{"unknown", ProcessDefinitionFilter{Key: "travel"}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := definitionServer(t, pds, &requests)
+			defer srv.Close()
+			c, _ := NewClient("token", srv.URL)
+
+			found, err := c.FindProcessDefinitions(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(found) != tt.found {
+				t.Errorf("found %d definitions, want %d", len(found), tt.found)
+			}
+			if requests != 3 {
+				t.Errorf("%d requests, want 3 pages", requests)
+			}
+
+			latest, err := c.findLatestProcessDefinition(tt.filter)
+			if tt.latest == "" {
+				if err == nil {
+					t.Errorf("latest = %s, want an error", latest.ID)
+				}
+				return
+			}
+			if err != nil || latest.ID != tt.latest {
+				t.Errorf("latest = %v, %v, want %s", latest, err, tt.latest)
+			}
+		})
+	}
+}
+
+func TestFindProcessDefinitionsCached(t *testing.T) {
+	requests := 0
+	srv := definitionServer(t, []ProcessDefinition{{ID: "a:1", Key: "a"}, {ID: "b:1", Key: "b"}, {ID: "c:1", Key: "c", Version: 1}}, &requests)
+	defer srv.Close()
+	c, _ := NewClient("token", srv.URL)
+	c.EnableDefinitionCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+		pd, err := c.GetLatestProcessDefinition("c")
+		if err != nil || pd.ID != "c:1" {
+			t.Fatalf("GetLatestProcessDefinition = %v, %v", pd, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("%d requests, want 2 pages once", requests)
+	}
+}
@@ -1,9 +1,9 @@
 package activiti
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strings"
 )
 
 // GetProcessInstance retrieves process instance by ID
@@ -11,7 +11,7 @@ import (
 func (c *ActClient) GetProcessInstance(pid string) (*ActProcessInstance, error) {
 	pi := &ActProcessInstance{}
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/process-instances/", pid), nil)
+	req, err := c.newRequest("GetProcessInstance", "GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/process-instances/", pid), nil)
 	if err != nil {
 		return pi, err
 	}
@@ -23,18 +23,52 @@ func (c *ActClient) GetProcessInstance(pid string) (*ActProcessInstance, error)
 	return pi, nil
 }
 
+// AdminGetProcessInstance retrieves process instance by ID, also those the user cannot see
+// Endpoint: GET admin/v1/process-instances/{processInstanceId}
+func (c *ActClient) AdminGetProcessInstance(pid string) (*ActProcessInstance, error) {
+	pi := &ActProcessInstance{}
+
+	req, err := c.newRequest("AdminGetProcessInstance", "GET", fmt.Sprintf("%s%s%s", c.adminURL(), "/process-instances/", pid), nil)
+	if err != nil {
+		return pi, err
+	}
+
+	if err = c.SendWithBasicAuth(req, pi); err != nil {
+		return pi, err
+	}
+
+	return pi, nil
+}
+
+// GetProcessVariables retrieves the variables of a process instance
+// Endpoint: GET runtime/process-instances/{processInstanceId}/variables
+func (c *ActClient) GetProcessVariables(pid string) (*ActListProcessVariables, error) {
+	vars := &ActListProcessVariables{}
+
+	req, err := c.newRequest("GetProcessVariables", "GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/variables"), nil)
+	if err != nil {
+		return vars, err
+	}
+
+	if err = c.SendWithBasicAuth(req, vars); err != nil {
+		return vars, err
+	}
+
+	return vars, nil
+}
+
 // AdminSetProcessVariables admin设置流程全局变量
 func (c *ActClient) AdminSetProcessVariables(pid string, variables map[string]interface{}) error {
+	if err := c.validateProcessVariables(pid, variables, c.AdminGetProcessInstance); err != nil {
+		return err
+	}
 	var pis interface{}
-	c.BaseURL = strings.ReplaceAll(c.BaseURL, "/v1", "/admin/v1")
-	url := fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/variables")
+	url := fmt.Sprintf("%s%s%s%s", c.adminURL(), "/process-instances/", pid, "/variables")
 	params := struct {
 		PayloadType string                 `json:"payloadType"`
 		Variables   map[string]interface{} `json:"variables"`
 	}{PayloadType: "SetProcessVariablesPayload", Variables: variables}
-
-	fmt.Println(url)
-	req, err := c.NewRequest("PUT", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/variables"), params)
+	req, err := c.newRequest("AdminSetProcessVariables", "PUT", url, params)
 	if err != nil {
 		return err
 	}
@@ -46,15 +80,16 @@ func (c *ActClient) AdminSetProcessVariables(pid string, variables map[string]in
 
 // SetProcessVariables 设置流程全局变量
 func (c *ActClient) SetProcessVariables(pid string, variables map[string]interface{}) error {
+	if err := c.validateProcessVariables(pid, variables, c.GetProcessInstance); err != nil {
+		return err
+	}
 	var pis interface{}
 	url := fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/variables")
 	params := struct {
 		PayloadType string                 `json:"payloadType"`
 		Variables   map[string]interface{} `json:"variables"`
 	}{PayloadType: "SetProcessVariablesPayload", Variables: variables}
-
-	fmt.Println(url)
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/variables"), params)
+	req, err := c.newRequest("SetProcessVariables", "POST", url, params)
 	if err != nil {
 		return err
 	}
@@ -69,7 +104,7 @@ func (c *ActClient) SetProcessVariables(pid string, variables map[string]interfa
 func (c *ActClient) GetProcessDiagram(pid string) ([]byte, error) {
 	var pDiagram []byte
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/model"), nil)
+	req, err := c.newRequest("GetProcessDiagram", "GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/model"), nil)
 	req.Header.Set("content-type", "image/svg+xml;charset=UTF-8")
 
 	if err != nil {
@@ -87,9 +122,7 @@ func (c *ActClient) GetProcessDiagram(pid string) ([]byte, error) {
 func (c *ActClient) GetProcessInstances() (*ActListProcessInstances, error) {
 	pis := &ActListProcessInstances{}
 	url := fmt.Sprintf("%s%s", c.BaseURL, "/process-instances")
-
-	fmt.Println(url)
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s", c.BaseURL, "/process-instances"), nil)
+	req, err := c.newRequest("GetProcessInstances", "GET", url, nil)
 	if err != nil {
 		return pis, err
 	}
@@ -102,10 +135,10 @@ func (c *ActClient) GetProcessInstances() (*ActListProcessInstances, error) {
 
 // startProcessInstance start a process instance in activiti
 // Endpoint: POST runtime/process-instances
-func (c *ActClient) startProcessInstance(s ActStartProcessInstance) (*ActProcessInstance, error) {
+func (c *ActClient) startProcessInstance(ctx context.Context, s ActStartProcessInstance) (*ActProcessInstance, error) {
 	pi := &ActProcessInstance{}
 	s.PayloadType = "StartProcessPayload"
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s", c.BaseURL, "/process-instances"), s)
+	req, err := c.newRequestContext(ctx, "StartProcessInstance", "POST", fmt.Sprintf("%s%s", c.BaseURL, "/process-instances"), s)
 	if err != nil {
 		return pi, err
 	}
@@ -123,7 +156,7 @@ func (c *ActClient) StartProcessInstanceById(pid string) (*ActProcessInstance, e
 		return nil, errors.New("Process definition id is required to start a process instance ")
 	}
 
-	return c.startProcessInstance(ActStartProcessInstance{ProcessDefinitionId: pid})
+	return c.startWithOptions(StartOptions{ProcessDefinitionId: pid})
 }
 
 // Start a process instance by process definition key
@@ -132,7 +165,7 @@ func (c *ActClient) StartProcessInstanceByKey(key string) (*ActProcessInstance,
 		return nil, errors.New("Process definition key is required to start a process instance ")
 	}
 
-	return c.startProcessInstance(ActStartProcessInstance{ProcessDefinitionKey: key})
+	return c.startWithOptions(StartOptions{ProcessDefinitionKey: key})
 }
 
 // Start a process instance by process definition key and variables
@@ -141,7 +174,7 @@ func (c *ActClient) StartProcessInstanceWithVariables(key string, variables map[
 		return nil, errors.New("key is required to start a process instance ")
 	}
 
-	return c.startProcessInstance(ActStartProcessInstance{ProcessDefinitionKey: key, Variables: variables})
+	return c.startWithOptions(StartOptions{ProcessDefinitionKey: key, Variables: variables})
 }
 
 // Start a process instance by process definition key and variables
@@ -150,7 +183,16 @@ func (c *ActClient) StartProcessInstanceWithBusinessKeyAndVariables(key, Busines
 		return nil, errors.New("key is required to start a process instance ")
 	}
 
-	return c.startProcessInstance(ActStartProcessInstance{ProcessDefinitionKey: key, BusinessKey: BusinessKey, Variables: variables})
+	return c.startWithOptions(StartOptions{ProcessDefinitionKey: key, BusinessKey: BusinessKey, Variables: variables})
+}
+
+// startWithOptions calls StartProcessInstance with the client context for the functions returning only the instance
+func (c *ActClient) startWithOptions(opts StartOptions) (*ActProcessInstance, error) {
+	res, err := c.StartProcessInstance(c.context(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ActProcessInstance{ProcessInstance: res.ProcessInstance}, nil
 }
 
 // Cancel a process instance by process instance key
@@ -158,9 +200,8 @@ func (c *ActClient) Cancel(key string) error {
 	if key == "" {
 		return errors.New("key is required to start a process instance ")
 	}
-	c.BaseURL = strings.ReplaceAll(c.BaseURL, "/v1", "/admin/v1")
 	pi := &ActProcessInstance{}
-	req, err := c.NewRequest("DELETE", fmt.Sprintf("%s%s%s", c.BaseURL, "/process-instances/", key), nil)
+	req, err := c.newRequest("Cancel", "DELETE", fmt.Sprintf("%s%s%s", c.adminURL(), "/process-instances/", key), nil)
 	if err != nil {
 		return err
 	}
@@ -172,14 +213,51 @@ func (c *ActClient) Cancel(key string) error {
 	return nil
 }
 
+// SuspendProcessInstance suspends a running process instance
+// Endpoint: POST runtime/process-instances/{processInstanceId}/suspend
+func (c *ActClient) SuspendProcessInstance(pid string) (*ActProcessInstance, error) {
+	if pid == "" {
+		return nil, errors.New("Process instance id is required to suspend a process instance ")
+	}
+	pi := &ActProcessInstance{}
+	req, err := c.newRequest("SuspendProcessInstance", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/suspend"), nil)
+	if err != nil {
+		return pi, err
+	}
+
+	if err = c.SendWithBasicAuth(req, pi); err != nil {
+		return pi, err
+	}
+
+	return pi, nil
+}
+
+// ResumeProcessInstance resumes a suspended process instance
+// Endpoint: POST runtime/process-instances/{processInstanceId}/resume
+func (c *ActClient) ResumeProcessInstance(pid string) (*ActProcessInstance, error) {
+	if pid == "" {
+		return nil, errors.New("Process instance id is required to resume a process instance ")
+	}
+	pi := &ActProcessInstance{}
+	req, err := c.newRequest("ResumeProcessInstance", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/resume"), nil)
+	if err != nil {
+		return pi, err
+	}
+
+	if err = c.SendWithBasicAuth(req, pi); err != nil {
+		return pi, err
+	}
+
+	return pi, nil
+}
+
 // 获取流程使用已完成和未完成的所有任务
 func (c *ActClient) ProcessInstancesTasks(key string) (*ActListTasks, error) {
 	if key == "" {
 		return nil, errors.New("processkey is required to find tasks ")
 	}
 	pi := &ActListTasks{}
-	c.BaseURL = strings.ReplaceAll(c.BaseURL, "rb", "query/admin")
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", key, "/tasks"), nil)
+	req, err := c.newRequest("ProcessInstancesTasks", "GET", fmt.Sprintf("%s%s%s%s", c.queryAdminURL(), "/process-instances/", key, "/tasks"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,127 @@
+package activiti
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// queryURL returns the query service url, by default BaseURL with the
+// runtime bundle path replaced, for example 'http://gateway/rb/v1' becomes 'http://gateway/query/v1'
+func (c *ActClient) queryURL() string {
+	if c.QueryURL != "" {
+		return c.QueryURL
+	}
+	return strings.Replace(c.BaseURL, "/rb", "/query", 1)
+}
+
+// adminURL returns the runtime bundle admin url, for example 'http://gateway/rb/admin/v1'
+func (c *ActClient) adminURL() string {
+	return toAdminURL(c.BaseURL)
+}
+
+// queryAdminURL returns the query service admin url, for example 'http://gateway/query/admin/v1'
+func (c *ActClient) queryAdminURL() string {
+	return toAdminURL(c.queryURL())
+}
+
+// toAdminURL inserts the admin path before the api version of a service url
+func toAdminURL(u string) string {
+	if strings.Contains(u, "/admin/v1") {
+		return u
+	}
+	return strings.Replace(u, "/v1", "/admin/v1", 1)
+}
+
+// QueryTasks retrieves tasks from the query service, params are passed as filters,
+// for example url.Values{"status": {"ASSIGNED"}, "skipCount": {"0"}, "maxItems": {"100"}}
+// Endpoint: GET query/v1/tasks
+func (c *ActClient) QueryTasks(params url.Values) (*ActListTasks, error) {
+	tks := &ActListTasks{}
+
+	req, err := c.newRequest("QueryTasks", "GET", fmt.Sprintf("%s%s?%s", c.queryURL(), "/tasks", params.Encode()), nil)
+	if err != nil {
+		return tks, err
+	}
+	if err = c.SendWithBasicAuth(req, tks); err != nil {
+		return tks, err
+	}
+
+	return tks, nil
+}
+
+// QueryProcessInstances retrieves process instances from the query service, params are passed as filters,
+// for example url.Values{"status": {"RUNNING"}, "processDefinitionKey": {"leave"}}
+// Endpoint: GET query/v1/process-instances
+func (c *ActClient) QueryProcessInstances(params url.Values) (*ActListProcessInstances, error) {
+	pis := &ActListProcessInstances{}
+
+	req, err := c.newRequest("QueryProcessInstances", "GET", fmt.Sprintf("%s%s?%s", c.queryURL(), "/process-instances", params.Encode()), nil)
+	if err != nil {
+		return pis, err
+	}
+	if err = c.SendWithBasicAuth(req, pis); err != nil {
+		return pis, err
+	}
+
+	return pis, nil
+}
+
+// QueryTaskIDs pages through the query service and returns the ids of all tasks matching params
+func (c *ActClient) QueryTaskIDs(params url.Values) ([]string, error) {
+	var ids []string
+	err := queryPages(params, func(p url.Values) (Pagination, int, error) {
+		tks, err := c.QueryTasks(p)
+		if err != nil {
+			return Pagination{}, 0, err
+		}
+		for _, t := range tks.List.Tasks {
+			ids = append(ids, t.Task.ID)
+		}
+		return tks.List.Pagination, len(tks.List.Tasks), nil
+	})
+	return ids, err
+}
+
+// QueryProcessInstanceIDs pages through the query service and returns the ids of all process instances matching params
+func (c *ActClient) QueryProcessInstanceIDs(params url.Values) ([]string, error) {
+	var ids []string
+	err := queryPages(params, func(p url.Values) (Pagination, int, error) {
+		pis, err := c.QueryProcessInstances(p)
+		if err != nil {
+			return Pagination{}, 0, err
+		}
+		for _, pi := range pis.List.ProcessInstances {
+			ids = append(ids, pi.ProcessInstance.ID)
+		}
+		return pis.List.Pagination, len(pis.List.ProcessInstances), nil
+	})
+	return ids, err
+}
+
+// queryPageSize is the maxItems of each page fetched by queryPages
+const queryPageSize = 100
+
+// queryPages calls fetch for every page of a query until the last one
+func queryPages(params url.Values, fetch func(url.Values) (Pagination, int, error)) error {
+	p := url.Values{}
+	for k, v := range params {
+		p[k] = v
+	}
+	if p.Get("maxItems") == "" {
+		p.Set("maxItems", strconv.Itoa(queryPageSize))
+	}
+
+	for skip := 0; ; {
+		p.Set("skipCount", strconv.Itoa(skip))
+		page, n, err := fetch(p)
+		if err != nil {
+			return err
+		}
+		if !page.HasMoreItems || n == 0 {
+			return nil
+		}
+		skip += n
+	}
+}
@@ -0,0 +1,182 @@
+package activiti
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// EndpointGroup is a set of Activiti Cloud endpoints limited together
+type EndpointGroup string
+
+const (
+	EndpointGroupRuntime EndpointGroup = "runtime" // Runtime bundle, BaseURL
+	EndpointGroupQuery   EndpointGroup = "query"   // Query service, QueryURL
+	EndpointGroupAdmin   EndpointGroup = "admin"   // Admin endpoints of both services
+)
+
+type (
+	// Limits caps the requests sent by a client
+	Limits struct {
+		Rate        float64 // Requests per second, unlimited when 0
+		Burst       int     // Requests allowed at once above Rate, 1 when 0
+		MaxInFlight int     // Requests waiting for a response, unlimited when 0
+	}
+
+	// limiters holds the global and per group limits of a client,
+	// it is shared by the copies made with WithHeader and WithContext
+	limiters struct {
+		mu     sync.RWMutex
+		global *limiter
+		groups map[EndpointGroup]*limiter
+	}
+
+	limiter struct {
+		rate     *rate.Limiter
+		inFlight chan struct{}
+	}
+
+	// releaseBody releases an in-flight slot once the response body is closed
+	releaseBody struct {
+		io.ReadCloser
+		once    sync.Once
+		release func()
+	}
+)
+
+// SetLimits sets limits applied to all requests of the client, zero Limits removes them
+func (c *ActClient) SetLimits(l Limits) {
+	lim := c.limits()
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.global = newLimiter(l)
+}
+
+// SetGroupLimits sets limits applied to the requests of one endpoint group,
+// in addition to the limits set with SetLimits. Zero Limits removes them
+func (c *ActClient) SetGroupLimits(group EndpointGroup, l Limits) {
+	lim := c.limits()
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	if lim.groups == nil {
+		lim.groups = map[EndpointGroup]*limiter{}
+	}
+	lim.groups[group] = newLimiter(l)
+}
+
+// lazyInit guards the creation of the shared state of clients not made by NewClient
+var lazyInit sync.Mutex
+
+// limits returns the limiters of the client. NewClient creates them so requests never
+// take lazyInit, clients built as struct literals get them on first use and only share
+// them with later copies, so their limits must be set before they are used concurrently
+func (c *ActClient) limits() *limiters {
+	if lim := c.limiters; lim != nil {
+		return lim
+	}
+	lazyInit.Lock()
+	defer lazyInit.Unlock()
+	if c.limiters == nil {
+		c.limiters = &limiters{}
+	}
+	return c.limiters
+}
+
+// endpointGroup returns the endpoint group of a request
+func (c *ActClient) endpointGroup(req *http.Request) EndpointGroup {
+	if strings.Contains(req.URL.Path, "/admin/") {
+		return EndpointGroupAdmin
+	}
+	u, query := req.URL.String(), c.queryURL()
+	// Without a distinct query service url every request goes to the runtime bundle
+	if query == c.BaseURL || !strings.HasPrefix(u, query) {
+		return EndpointGroupRuntime
+	}
+	// The longer url wins when one service is below the other
+	if len(c.BaseURL) > len(query) && strings.HasPrefix(u, c.BaseURL) {
+		return EndpointGroupRuntime
+	}
+	return EndpointGroupQuery
+}
+
+// acquire waits until the request is allowed by the global and group limits,
+// or its context is done, see WithContext.
+// The returned function frees the in-flight slots, it is never nil
+func (c *ActClient) acquire(req *http.Request) (func(), error) {
+	lim := c.limits()
+	lim.mu.RLock()
+	global, group := lim.global, lim.groups[c.endpointGroup(req)]
+	lim.mu.RUnlock()
+	if global == nil && group == nil {
+		return func() {}, nil
+	}
+
+	ctx := req.Context()
+	releaseGlobal, err := global.wait(ctx)
+	if err != nil {
+		return func() {}, err
+	}
+	releaseGroup, err := group.wait(ctx)
+	if err != nil {
+		releaseGlobal()
+		return func() {}, err
+	}
+	return func() {
+		releaseGroup()
+		releaseGlobal()
+	}, nil
+}
+
+// newLimiter returns nil for zero Limits
+func newLimiter(l Limits) *limiter {
+	if l.Rate <= 0 && l.MaxInFlight <= 0 {
+		return nil
+	}
+	lim := &limiter{}
+	if l.Rate > 0 {
+		burst := l.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		lim.rate = rate.NewLimiter(rate.Limit(l.Rate), burst)
+	}
+	if l.MaxInFlight > 0 {
+		lim.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+	return lim
+}
+
+// wait takes an in-flight slot and a rate token, or returns the context error
+func (l *limiter) wait(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			release = func() { <-l.inFlight }
+		case <-ctx.Done():
+			return func() {}, ctx.Err()
+		}
+	}
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return func() {}, err
+		}
+	}
+	return release, nil
+}
+
+// Close closes the body and releases the in-flight slot
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
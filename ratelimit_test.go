@@ -0,0 +1,110 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		queryURL string
+		url      string
+		want     EndpointGroup
+	}{
+		{"http://gw/rb/v1", "", "http://gw/rb/v1/tasks", EndpointGroupRuntime},
+		{"http://gw/rb/v1", "", "http://gw/query/v1/tasks", EndpointGroupQuery},
+		{"http://gw/rb/v1", "", "http://gw/rb/admin/v1/tasks", EndpointGroupAdmin},
+		{"http://gw/v1", "", "http://gw/v1/tasks", EndpointGroupRuntime},
+		{"http://rb/v1", "http://query/v1", "http://rb/v1/tasks", EndpointGroupRuntime},
+		{"http://rb/v1", "http://query/v1", "http://query/v1/tasks", EndpointGroupQuery},
+		{"http://gw/v1", "http://gw/v1", "http://gw/v1/tasks", EndpointGroupRuntime},
+		{"http://gw/v1/rb", "http://gw/v1", "http://gw/v1/rb/tasks", EndpointGroupRuntime},
+		{"http://gw/v1/rb", "http://gw/v1", "http://gw/v1/tasks", EndpointGroupQuery},
+	}
+
+	for _, tt := range tests {
+		c := &ActClient{BaseURL: tt.baseURL, QueryURL: tt.queryURL}
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		if got := c.endpointGroup(req); got != tt.want {
+			t.Errorf("endpointGroup(%s) with %s/%s = %s, want %s", tt.url, tt.baseURL, tt.queryURL, got, tt.want)
+		}
+	}
+}
+
+func TestLimitsSharedByCopies(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte(`{"entry":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewClient("token", srv.URL)
+	// The copy is made before the limits are set
+	cc := c.WithHeader("Accept-Language", "en")
+	c.SetLimits(Limits{MaxInFlight: 1})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.GetTask("t1")
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := cc.WithContext(ctx).GetTask("t1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetTask on the copy = %v, want %v while the slot is taken", err, context.DeadlineExceeded)
+	}
+	close(release)
+	wg.Wait()
+
+	if _, err := cc.GetTask("t1"); err != nil {
+		t.Errorf("GetTask after release: %v", err)
+	}
+}
+
+func TestLimitsWithoutLazyInit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entry":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+	c, _ := NewClient("token", srv.URL)
+	c.SetLimits(Limits{Rate: 1000})
+
+	// Requests of clients made by NewClient must not wait for lazyInit
+	lazyInit.Lock()
+	defer lazyInit.Unlock()
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.WithHeader("Accept-Language", "en").GetTask("t1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("request blocked on lazyInit")
+	}
+}
+
+func TestLimitsLazyInit(t *testing.T) {
+	c := &ActClient{}
+	c.SetGroupLimits(EndpointGroupQuery, Limits{Rate: 10})
+	cc := c.WithHeader("Accept-Language", "en")
+	cc.SetGroupLimits(EndpointGroupAdmin, Limits{Rate: 10})
+	if c.limiters != cc.limiters || len(c.limiters.groups) != 2 {
+		t.Errorf("limiters not shared with the copy: %d group limits", len(c.limiters.groups))
+	}
+}
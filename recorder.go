@@ -0,0 +1,308 @@
+package activiti
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in recorded fixtures
+const redacted = "REDACTED"
+
+// redactedHeaders are never written to a fixture file
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are JSON body fields whose values are never written to a fixture file
+var redactedFields = map[string]bool{
+	"password":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+}
+
+type (
+	// Fixture is a recorded request/response pair, stored one per line in a JSONL file
+	Fixture struct {
+		Request  FixtureRequest  `json:"request"`
+		Response FixtureResponse `json:"response"`
+	}
+
+	FixtureRequest struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	}
+
+	FixtureResponse struct {
+		StatusCode int         `json:"statusCode"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+
+	// RecordingTransport is a http.RoundTripper which writes every request made
+	// through it, and the response received, as a Fixture to a JSONL writer.
+	// The zero value records nothing, create it with NewRecordingTransport
+	RecordingTransport struct {
+		Transport http.RoundTripper // If nil http.DefaultTransport is used
+
+		mu sync.Mutex
+		w  io.Writer
+	}
+
+	// ReplayTransport is a http.RoundTripper which serves responses previously
+	// captured by a RecordingTransport without touching the network
+	ReplayTransport struct {
+		mu       sync.Mutex
+		fixtures []Fixture
+		used     []bool
+	}
+)
+
+// NewRecordingTransport returns a RecordingTransport writing fixtures to w
+// Usage: c.SetHTTPClient(&http.Client{Transport: activiti.NewRecordingTransport(f, nil)})
+func NewRecordingTransport(w io.Writer, next http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{Transport: next, w: w}
+}
+
+// RoundTrip sends the request with the underlying transport and records it
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	fx := Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: redactHeader(req.Header),
+			Body:   string(redactBody(reqBody)),
+		},
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       string(redactBody(respBody)),
+		},
+	}
+	line, err := json.Marshal(fx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w := t.w
+	if w == nil {
+		w = io.Discard
+	}
+	if _, err = w.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// NewReplayTransport reads JSONL fixtures from r
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var fx Fixture
+		if err := json.Unmarshal(line, &fx); err != nil {
+			return nil, fmt.Errorf("invalid fixture on line %d: %v", len(t.fixtures)+1, err)
+		}
+		t.fixtures = append(t.fixtures, fx)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	t.used = make([]bool, len(t.fixtures))
+
+	return t, nil
+}
+
+// LoadReplayTransport reads JSONL fixtures from the file at path
+func LoadReplayTransport(path string) (*ReplayTransport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewReplayTransport(f)
+}
+
+// RoundTrip serves the first unused fixture matching the request method and URL.
+// Fixtures whose recorded body equals the request body are preferred, so the same
+// endpoint may be replayed with different payloads in any order
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	body = redactBody(body)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	match := -1
+	for i, fx := range t.fixtures {
+		if t.used[i] || fx.Request.Method != req.Method || fx.Request.URL != req.URL.String() {
+			continue
+		}
+		if jsonEqual([]byte(fx.Request.Body), body) {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+	}
+	t.used[match] = true
+
+	fx := t.fixtures[match].Response
+	header := fx.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fx.StatusCode, http.StatusText(fx.StatusCode)),
+		StatusCode:    fx.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(fx.Body)),
+		ContentLength: int64(len(fx.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Remaining returns the number of fixtures which have not been replayed yet
+func (t *ReplayTransport) Remaining() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := 0
+	for _, used := range t.used {
+		if !used {
+			n++
+		}
+	}
+	return n
+}
+
+// redactHeader returns a copy of h with credentials replaced
+func redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, k := range redactedHeaders {
+		if _, ok := h[k]; ok {
+			h.Set(k, redacted)
+		}
+	}
+	return h
+}
+
+// redactBody replaces secret fields in a JSON body, other bodies are returned as is
+func redactBody(b []byte) []byte {
+	if len(b) == 0 {
+		return b
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return b
+	}
+	if !redactValue(v) {
+		return b
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+// redactValue walks a decoded JSON value and reports whether anything was replaced
+func redactValue(v interface{}) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if redactedFields[strings.ToLower(k)] {
+				t[k] = redacted
+				changed = true
+				continue
+			}
+			if redactValue(fv) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, ev := range t {
+			if redactValue(ev) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// jsonEqual compares two bodies, ignoring JSON formatting and key order
+func jsonEqual(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
@@ -0,0 +1,108 @@
+package activiti
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func replayClient(t *testing.T, path string) (*ActClient, *ReplayTransport) {
+	t.Helper()
+	rt, err := LoadReplayTransport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient("token", "http://localhost:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetHTTPClient(&http.Client{Transport: rt})
+	return c, rt
+}
+
+func TestReplayTransport(t *testing.T) {
+	tests := []struct {
+		tid      string
+		name     string
+		assignee string
+		err      string
+	}{
+		{tid: "t2", name: "Review"},
+		{tid: "t1", name: "Approve", assignee: "alice"},
+		{tid: "missing", err: "404 Unable to find task for the given id: missing"},
+		{tid: "t1", err: "no recorded response"},
+	}
+
+	c, rt := replayClient(t, "testdata/tasks.jsonl")
+	for _, tt := range tests {
+		tk, err := c.GetTask(tt.tid)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("GetTask(%s) error = %v, want %q", tt.tid, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GetTask(%s): %v", tt.tid, err)
+			continue
+		}
+		if tk.Task.Name != tt.name || tk.Task.Assignee != tt.assignee {
+			t.Errorf("GetTask(%s) = %s/%s, want %s/%s", tt.tid, tk.Task.Name, tk.Task.Assignee, tt.name, tt.assignee)
+		}
+	}
+	if n := rt.Remaining(); n != 0 {
+		t.Errorf("Remaining() = %d, want 0", n)
+	}
+}
+
+func TestRecordingTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"secret","entry":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	client := &http.Client{Transport: NewRecordingTransport(buf, nil)}
+	req, _ := http.NewRequest("POST", srv.URL+"/tasks", strings.NewReader(`{"password":"pw","name":"n"}`))
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	var fx Fixture
+	if err = json.Unmarshal(buf.Bytes(), &fx); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, got, want string
+	}{
+		{"authorization", fx.Request.Header.Get("Authorization"), redacted},
+		{"request body", fx.Request.Body, `{"name":"n","password":"REDACTED"}`},
+		{"response body", fx.Response.Body, `{"access_token":"REDACTED","entry":{"id":"t1"}}`},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRecordingTransportZeroValue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &RecordingTransport{}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,351 @@
+package relay
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a compiled variables expression, for example
+//
+//	amount > 1000 && (approved == true || region != "EU") && !escalated
+//
+// Identifiers name process variables, dots select fields of object variables and
+// missing variables are null. Comparisons of numbers, strings and booleans, &&, ||,
+// ! and parentheses are supported
+type Expr struct {
+	src  string
+	root node
+}
+
+type (
+	node interface {
+		eval(vars map[string]interface{}) interface{}
+	}
+
+	literal struct{ v interface{} }
+	ident   struct{ path []string }
+	not     struct{ x node }
+	binary  struct {
+		op   string
+		l, r node
+	}
+
+	token struct {
+		kind string // ident, number, string, op, eof
+		text string
+		pos  int
+	}
+
+	parser struct {
+		src    string
+		tokens []token
+		i      int
+	}
+)
+
+// Compile parses an expression
+func Compile(src string) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != "eof" {
+		return nil, fmt.Errorf("unexpected %q at %d in %q", t.text, t.pos, src)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// Match evaluates the expression against variables and reports whether it is true
+func (e *Expr) Match(vars map[string]interface{}) bool {
+	return truthy(e.root.eval(vars))
+}
+
+// String returns the source of the expression
+func (e *Expr) String() string {
+	return e.src
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(src) && rune(src[j]) != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+					switch src[j] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(src[j])
+					}
+					continue
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string at %d in %q", i, src)
+			}
+			tokens = append(tokens, token{kind: "string", text: sb.String(), pos: i})
+			i = j + 1
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1])) && prevAllowsNumber(tokens)):
+			j := i + 1
+			for j < len(src) && (unicode.IsDigit(rune(src[j])) || src[j] == '.' || src[j] == 'e' || src[j] == 'E') {
+				j++
+			}
+			tokens = append(tokens, token{kind: "number", text: src[i:j], pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i + 1
+			for j < len(src) && (unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j])) || src[j] == '_' || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: "ident", text: src[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, o := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"} {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at %d in %q", string(c), i, src)
+			}
+			tokens = append(tokens, token{kind: "op", text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: "eof", pos: len(src)}), nil
+}
+
+// prevAllowsNumber reports whether a '-' starts a negative number rather than being misplaced
+func prevAllowsNumber(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	return tokens[len(tokens)-1].kind == "op" && tokens[len(tokens)-1].text != ")"
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != "eof" {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) or() (node, error) {
+	l, err := p.and()
+	for err == nil && p.peek().text == "||" && p.peek().kind == "op" {
+		p.next()
+		var r node
+		if r, err = p.and(); err == nil {
+			l = binary{op: "||", l: l, r: r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) and() (node, error) {
+	l, err := p.comparison()
+	for err == nil && p.peek().text == "&&" && p.peek().kind == "op" {
+		p.next()
+		var r node
+		if r, err = p.comparison(); err == nil {
+			l = binary{op: "&&", l: l, r: r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) comparison() (node, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == "op" {
+		switch t.text {
+		case "==", "!=", "<", "<=", ">", ">=":
+			p.next()
+			r, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			return binary{op: t.text, l: l, r: r}, nil
+		}
+	}
+	return l, nil
+}
+
+func (p *parser) unary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case "op":
+		switch t.text {
+		case "!":
+			x, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			return not{x: x}, nil
+		case "(":
+			x, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if p.next().text != ")" {
+				return nil, fmt.Errorf("missing ) in %q", p.src)
+			}
+			return x, nil
+		}
+	case "number":
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q in %q", t.text, p.src)
+		}
+		return literal{v: n}, nil
+	case "string":
+		return literal{v: t.text}, nil
+	case "ident":
+		switch t.text {
+		case "true":
+			return literal{v: true}, nil
+		case "false":
+			return literal{v: false}, nil
+		case "null":
+			return literal{v: nil}, nil
+		}
+		return ident{path: strings.Split(t.text, ".")}, nil
+	}
+	if t.kind == "eof" {
+		return nil, fmt.Errorf("unexpected end of %q", p.src)
+	}
+	return nil, fmt.Errorf("unexpected %q at %d in %q", t.text, t.pos, p.src)
+}
+
+func (n literal) eval(map[string]interface{}) interface{} { return n.v }
+
+func (n ident) eval(vars map[string]interface{}) interface{} {
+	var v interface{} = vars
+	for _, name := range n.path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+func (n not) eval(vars map[string]interface{}) interface{} { return !truthy(n.x.eval(vars)) }
+
+func (n binary) eval(vars map[string]interface{}) interface{} {
+	switch n.op {
+	case "&&":
+		return truthy(n.l.eval(vars)) && truthy(n.r.eval(vars))
+	case "||":
+		return truthy(n.l.eval(vars)) || truthy(n.r.eval(vars))
+	}
+
+	l, r := n.l.eval(vars), n.r.eval(vars)
+	if ln, ok := number(l); ok {
+		if rn, ok := number(r); ok {
+			return compare(n.op, cmp(ln < rn, ln > rn))
+		}
+	}
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			return compare(n.op, strings.Compare(ls, rs))
+		}
+	}
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(l, r)
+	case "!=":
+		return !reflect.DeepEqual(l, r)
+	}
+	// Ordering of values of different types
+	return false
+}
+
+func cmp(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func compare(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// number converts the numeric types of decoded JSON and Go callers
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// truthy is false for null, false, 0 and the empty string
+func truthy(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case string:
+		return x != ""
+	}
+	if n, ok := number(v); ok {
+		return n != 0
+	}
+	return true
+}
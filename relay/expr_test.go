@@ -0,0 +1,76 @@
+package relay
+
+import "testing"
+
+func TestExprMatch(t *testing.T) {
+	vars := map[string]interface{}{
+		"amount":    1500.0,
+		"count":     3,
+		"region":    "EU",
+		"approved":  false,
+		"escalated": true,
+		"empty":     "",
+		"customer":  map[string]interface{}{"tier": "gold", "age": 42.0},
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`amount > 1000`, true},
+		{`amount >= 1500 && amount <= 1500`, true},
+		{`amount < -1`, false},
+		{`count == 3`, true},
+		{`count != 3.0`, false},
+		{`region == "EU"`, true},
+		{`region == 'US'`, false},
+		{`region < "FR"`, true},
+		{`approved == false`, true},
+		{`!approved && escalated`, true},
+		{`!(approved || escalated)`, false},
+		{`amount > 1000 && (approved == true || region != "EU") && !escalated`, false},
+		{`customer.tier == "gold" && customer.age > 40`, true},
+		{`customer.missing == null`, true},
+		{`region.tier == null`, true},
+		{`missing`, false},
+		{`missing == null`, true},
+		{`empty`, false},
+		{`amount`, true},
+		{`region > 1`, false},
+		{`region == 1`, false},
+		{`"a\"b" == 'a"b'`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.Match(vars); got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+			if e.String() != tt.expr {
+				t.Errorf("String = %q", e.String())
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []string{
+		``,
+		`amount >`,
+		`(amount > 1`,
+		`amount > 1)`,
+		`region == "EU`,
+		`amount # 1`,
+		`amount > 1 2`,
+		`1.2.3 > 0`,
+	}
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			if _, err := Compile(src); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
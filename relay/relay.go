@@ -0,0 +1,459 @@
+// Package relay forwards Activiti Cloud runtime events to HTTP callbacks.
+//
+// Events are matched against rules by event type, process definition key and a
+// variables expression, and POSTed as signed JSON to the URL of every matching rule:
+//
+//	r, err := relay.New(rules, relay.Options{Variables: relay.ProcessVariables(client), DeadLetter: f})
+//	...
+//	d := events.NewDispatcher()
+//	d.Handle(events.AllEvents, r)
+//	go r.Run(ctx)
+//	err = d.Run(ctx, source)
+//
+// Receivers verify the X-Activiti-Signature header, 'sha256=' followed by the hex
+// HMAC-SHA256 of the body keyed with the rule secret. Deliveries failing after
+// MaxAttempts are appended to the dead letter log as JSON lines.
+package relay
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+const (
+	// DefaultMaxAttempts is the number of POSTs of a payload when Options.MaxAttempts is 0
+	DefaultMaxAttempts = 5
+	// DefaultMinBackoff is the delay before the first retry when Options.MinBackoff is 0
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff is the longest delay between retries when Options.MaxBackoff is 0
+	DefaultMaxBackoff = time.Minute
+	// DefaultWorkers is the number of concurrent deliveries when Options.Workers is 0
+	DefaultWorkers = 4
+	// DefaultQueueSize is the number of pending deliveries when Options.QueueSize is 0
+	DefaultQueueSize = 1000
+
+	// SignatureHeader carries the HMAC of the body
+	SignatureHeader = "X-Activiti-Signature"
+	// EventHeader carries the event type
+	EventHeader = "X-Activiti-Event"
+	// DeliveryHeader carries the delivery id, identical for the retries of a payload
+	DeliveryHeader = "X-Activiti-Delivery"
+)
+
+type (
+	// Rule sends the events matching every non empty field to URL
+	Rule struct {
+		Name                  string             `json:"name" yaml:"name"`
+		URL                   string             `json:"url" yaml:"url"`
+		Secret                string             `json:"secret,omitempty" yaml:"secret"`
+		EventTypes            []events.EventType `json:"eventTypes,omitempty" yaml:"eventTypes"`
+		ProcessDefinitionKeys []string           `json:"processDefinitionKeys,omitempty" yaml:"processDefinitionKeys"`
+		// Condition is an expression over the process variables, see Expr. Instances which
+		// ended are gone from the runtime bundle with their variables, so a rule with a
+		// condition never matches PROCESS_COMPLETED, PROCESS_CANCELLED or PROCESS_DELETED
+		// events, and New rejects it when its EventTypes name one of them
+		Condition string `json:"condition,omitempty" yaml:"condition"`
+	}
+
+	// VariablesFunc returns the variables of a process instance
+	VariablesFunc func(ctx context.Context, processInstanceID string) (map[string]interface{}, error)
+
+	// Options configures a Relay
+	Options struct {
+		HTTPClient  *http.Client  // http.DefaultClient when nil
+		MaxAttempts int           // DefaultMaxAttempts when 0
+		MinBackoff  time.Duration // DefaultMinBackoff when 0
+		MaxBackoff  time.Duration // DefaultMaxBackoff when 0
+		Workers     int           // DefaultWorkers when 0
+		QueueSize   int           // DefaultQueueSize when 0
+
+		// Variables loads the variables rule conditions are evaluated against,
+		// required when a rule has a Condition
+		Variables VariablesFunc
+		// DeadLetter receives a JSON line per undeliverable payload, they are dropped when nil
+		DeadLetter io.Writer
+		// Logger receives a record per failed attempt, none when nil
+		Logger *slog.Logger
+	}
+
+	// Payload is the JSON body POSTed to the rule URLs
+	Payload struct {
+		ID        string                 `json:"id"`
+		Rule      string                 `json:"rule"`
+		SentAt    time.Time              `json:"sentAt"`
+		Event     *events.Event          `json:"event"`
+		Variables map[string]interface{} `json:"variables,omitempty"` // When a rule condition loaded them
+	}
+
+	// DeadLetter is a line of the dead letter log
+	DeadLetter struct {
+		Time     time.Time       `json:"time"`
+		Rule     string          `json:"rule"`
+		URL      string          `json:"url"`
+		Attempts int             `json:"attempts"`
+		Error    string          `json:"error"`
+		Payload  json.RawMessage `json:"payload"`
+	}
+
+	// Relay matches events against rules and delivers them
+	Relay struct {
+		rules []compiledRule
+		opts  Options
+		queue chan delivery
+
+		dlMu sync.Mutex
+	}
+
+	compiledRule struct {
+		Rule
+		types     map[events.EventType]bool
+		keys      map[string]bool
+		condition *Expr
+	}
+
+	delivery struct {
+		rule      *compiledRule
+		eventType events.EventType
+		id        string
+		body      []byte
+	}
+
+	// permanentError is a response which retrying cannot fix
+	permanentError struct {
+		err error
+	}
+)
+
+// ProcessVariables loads variables from the runtime bundle through the client.
+// Instances which ended are gone from the runtime bundle, they have no variables
+func ProcessVariables(c *activiti.ActClient) VariablesFunc {
+	return func(ctx context.Context, pid string) (map[string]interface{}, error) {
+		vars, err := c.WithContext(ctx).GetProcessVariables(pid)
+		var errResp *activiti.ActErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+			return map[string]interface{}{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return vars.Map(), nil
+	}
+}
+
+// New compiles the rules
+func New(rules []Rule, opts Options) (*Relay, error) {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+
+	r := &Relay{opts: opts, queue: make(chan delivery, opts.QueueSize)}
+	for i, rule := range rules {
+		if rule.URL == "" {
+			return nil, fmt.Errorf("relay rule %d %q: url is required", i, rule.Name)
+		}
+		cr := compiledRule{Rule: rule, types: map[events.EventType]bool{}, keys: map[string]bool{}}
+		for _, t := range rule.EventTypes {
+			cr.types[t] = true
+		}
+		for _, k := range rule.ProcessDefinitionKeys {
+			cr.keys[k] = true
+		}
+		if rule.Condition != "" {
+			if opts.Variables == nil {
+				return nil, fmt.Errorf("relay rule %d %q: a condition needs Options.Variables", i, rule.Name)
+			}
+			for _, t := range rule.EventTypes {
+				if ended(t) {
+					return nil, fmt.Errorf("relay rule %d %q: a condition never matches %s events, the variables of ended instances are gone", i, rule.Name, t)
+				}
+			}
+			expr, err := Compile(rule.Condition)
+			if err != nil {
+				return nil, fmt.Errorf("relay rule %d %q: %w", i, rule.Name, err)
+			}
+			cr.condition = expr
+		}
+		r.rules = append(r.rules, cr)
+	}
+	return r, nil
+}
+
+// HandleEvent implements events.Handler: the event is queued for every matching rule.
+// An error, when the variables cannot be loaded, lets the event source redeliver it.
+// The event is acknowledged once queued, not once delivered: payloads queued when the
+// process stops are dead-lettered by Run, not redelivered by the event source
+func (r *Relay) HandleEvent(ctx context.Context, ev events.RuntimeEvent) error {
+	e := withEntity(ev)
+
+	var (
+		vars   map[string]interface{}
+		loaded bool
+	)
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if len(rule.types) > 0 && !rule.types[e.EventType] {
+			continue
+		}
+		if len(rule.keys) > 0 && !rule.keys[e.ProcessDefinitionKey] {
+			continue
+		}
+		if rule.condition != nil {
+			if ended(e.EventType) {
+				continue
+			}
+			if !loaded {
+				var err error
+				if vars, err = r.variables(ctx, ev); err != nil {
+					return err
+				}
+				loaded = true
+			}
+			if !rule.condition.Match(vars) {
+				continue
+			}
+		}
+
+		p := Payload{ID: newID(), Rule: rule.Name, SentAt: time.Now().UTC(), Event: e, Variables: vars}
+		body, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		select {
+		case r.queue <- delivery{rule: rule, eventType: e.EventType, id: p.ID, body: body}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// variables returns the process variables of the event, with the variable of a variable event applied
+func (r *Relay) variables(ctx context.Context, ev events.RuntimeEvent) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	if pid := ev.Envelope().ProcessInstanceID; pid != "" {
+		loaded, err := r.opts.Variables(ctx, pid)
+		if err != nil {
+			return nil, fmt.Errorf("relay: variables of %s: %w", pid, err)
+		}
+		for k, v := range loaded {
+			vars[k] = v
+		}
+	}
+	if ve, ok := ev.(*events.VariableEvent); ok && ve.Variable.Name != "" {
+		if ve.EventType == events.VariableDeleted {
+			delete(vars, ve.Variable.Name)
+		} else {
+			vars[ve.Variable.Name] = ve.Variable.Value
+		}
+	}
+	return vars, nil
+}
+
+// ended reports whether the event type ends its process instance, which is then gone from the runtime bundle
+// with its variables
+func ended(t events.EventType) bool {
+	return t == events.ProcessCompleted || t == events.ProcessCancelled || t == events.ProcessDeleted
+}
+
+// Run delivers queued payloads until ctx is done. Payloads still queued then are dead-lettered
+func (r *Relay) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < r.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case d := <-r.queue:
+					r.deliver(ctx, d)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for {
+		select {
+		case d := <-r.queue:
+			r.deadLetter(d, 0, errors.New("relay stopped"))
+		default:
+			return ctx.Err()
+		}
+	}
+}
+
+// deliver POSTs a payload until it succeeds, fails permanently or runs out of attempts
+func (r *Relay) deliver(ctx context.Context, d delivery) {
+	backoff := r.opts.MinBackoff
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		if err = r.post(ctx, d); err == nil {
+			return
+		}
+		if r.opts.Logger != nil {
+			r.opts.Logger.LogAttrs(ctx, slog.LevelWarn, "webhook delivery failed",
+				slog.String("rule", d.rule.Name),
+				slog.String("delivery", d.id),
+				slog.Int("attempt", attempt),
+				slog.String("error", err.Error()),
+			)
+		}
+		var pe *permanentError
+		if errors.As(err, &pe) || attempt >= r.opts.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			r.deadLetter(d, attempt, fmt.Errorf("relay stopped after: %w", err))
+			return
+		}
+		if backoff *= 2; backoff > r.opts.MaxBackoff {
+			backoff = r.opts.MaxBackoff
+		}
+	}
+	r.deadLetter(d, attempt, err)
+}
+
+// post sends one attempt
+func (r *Relay) post(ctx context.Context, d delivery) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", d.rule.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(d.eventType))
+	req.Header.Set(DeliveryHeader, d.id)
+	if d.rule.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.rule.Secret, d.body))
+	}
+
+	resp, err := r.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	err = fmt.Errorf("POST %s: %s", d.rule.URL, resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return &permanentError{err: err}
+	}
+	return err
+}
+
+// deadLetter appends an undeliverable payload to the dead letter log
+func (r *Relay) deadLetter(d delivery, attempts int, err error) {
+	if r.opts.DeadLetter == nil {
+		return
+	}
+	line, merr := json.Marshal(DeadLetter{
+		Time:     time.Now().UTC(),
+		Rule:     d.rule.Name,
+		URL:      d.rule.URL,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Payload:  d.body,
+	})
+	if merr != nil {
+		return
+	}
+
+	r.dlMu.Lock()
+	defer r.dlMu.Unlock()
+	if _, werr := r.opts.DeadLetter.Write(append(line, '\n')); werr != nil && r.opts.Logger != nil {
+		r.opts.Logger.Error("dead letter write failed", slog.String("delivery", d.id), slog.String("error", werr.Error()))
+	}
+}
+
+// withEntity returns the envelope of the event with its entity encoded, events built
+// by events.FromChange only have the typed entity
+func withEntity(ev events.RuntimeEvent) *events.Event {
+	e := *ev.Envelope()
+	if len(e.Entity) > 0 {
+		return &e
+	}
+
+	var entity interface{}
+	switch te := ev.(type) {
+	case *events.ProcessEvent:
+		entity = te.ProcessInstance
+	case *events.TaskEvent:
+		entity = te.Task
+	case *events.VariableEvent:
+		entity = te.Variable
+	default:
+		return &e
+	}
+	if data, err := json.Marshal(entity); err == nil {
+		e.Entity = data
+	}
+	return &e
+}
+
+// Sign returns the signature header value of a body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body, for receivers
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Error implements error
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the response error
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// newID returns a random delivery id
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
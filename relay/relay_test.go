@@ -0,0 +1,393 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/lihongchen/go-activiti-rest/events"
+)
+
+func TestHandleEventVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     events.RuntimeEvent
+		wantLoads int
+		wantQueue int
+	}{
+		{"running instance", &events.TaskEvent{Event: events.Event{EventType: events.TaskCreated, ProcessInstanceID: "pi-1"}}, 1, 1},
+		{"completed", &events.ProcessEvent{Event: events.Event{EventType: events.ProcessCompleted, ProcessInstanceID: "pi-1"}}, 0, 0},
+		{"cancelled", &events.ProcessEvent{Event: events.Event{EventType: events.ProcessCancelled, ProcessInstanceID: "pi-1"}}, 0, 0},
+		{"deleted", &events.ProcessEvent{Event: events.Event{EventType: events.ProcessDeleted, ProcessInstanceID: "pi-1"}}, 0, 0},
+		{
+			"variable event applied",
+			&events.VariableEvent{
+				Event:    events.Event{EventType: events.VariableUpdated, ProcessInstanceID: "pi-1"},
+				Variable: events.Variable{Name: "amount", Value: 5.0},
+			},
+			1, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loads := 0
+			r, err := New([]Rule{{Name: "big", URL: "http://hook", Condition: "amount > 100"}}, Options{
+				Variables: func(ctx context.Context, pid string) (map[string]interface{}, error) {
+					loads++
+					return map[string]interface{}{"amount": 500.0}, nil
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.HandleEvent(context.Background(), tt.event); err != nil {
+				t.Fatal(err)
+			}
+			if loads != tt.wantLoads {
+				t.Errorf("loads = %d, want %d", loads, tt.wantLoads)
+			}
+			if len(r.queue) != tt.wantQueue {
+				t.Errorf("queued = %d, want %d", len(r.queue), tt.wantQueue)
+			}
+		})
+	}
+}
+
+func TestProcessVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{"running", http.StatusOK, `{"list":{"entries":[{"entry":{"name":"amount","value":5}}]}}`, 1, false},
+		{"ended", http.StatusNotFound, `{"errorMessage":"not found"}`, 0, false},
+		{"server error", http.StatusInternalServerError, `{}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/process-instances/pi-1/variables" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			c, _ := activiti.NewClient("token", srv.URL)
+
+			vars, err := ProcessVariables(c)(context.Background(), "pi-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var errResp *activiti.ActErrorResponse
+			if tt.wantErr && !errors.As(err, &errResp) {
+				t.Errorf("err = %T, want *ActErrorResponse", err)
+			}
+			if !tt.wantErr && len(vars) != tt.want {
+				t.Errorf("got %v, want %d variables", vars, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	vars := func(context.Context, string) (map[string]interface{}, error) { return nil, nil }
+	tests := []struct {
+		name    string
+		rule    Rule
+		vars    VariablesFunc
+		wantErr string
+	}{
+		{"valid", Rule{Name: "r", URL: "http://hook", EventTypes: []events.EventType{events.TaskCreated}, Condition: "amount > 1"}, vars, ""},
+		{"no url", Rule{Name: "r"}, nil, "url is required"},
+		{"condition without variables", Rule{Name: "r", URL: "http://hook", Condition: "amount > 1"}, nil, "a condition needs Options.Variables"},
+		{"invalid condition", Rule{Name: "r", URL: "http://hook", Condition: "amount >"}, vars, `relay rule 0 "r"`},
+		{"condition on ended events", Rule{Name: "r", URL: "http://hook", EventTypes: []events.EventType{events.TaskCreated, events.ProcessCompleted}, Condition: "amount > 1"}, vars, "never matches PROCESS_COMPLETED events"},
+		{"ended events without condition", Rule{Name: "r", URL: "http://hook", EventTypes: []events.EventType{events.ProcessCancelled}}, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New([]Rule{tt.rule}, Options{Variables: tt.vars})
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte("The quick brown fox jumps over the lazy dog")
+	sig := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got := Sign("key", body); got != sig {
+		t.Fatalf("Sign = %s, want %s", got, sig)
+	}
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", "key", body, sig, true},
+		{"other secret", "other", body, sig, false},
+		{"tampered body", "key", append([]byte("x"), body...), sig, false},
+		{"without prefix", "key", body, strings.TrimPrefix(sig, "sha256="), false},
+		{"upper case", "key", body, strings.ToUpper(sig), false},
+		{"empty", "key", body, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.body, tt.signature); got != tt.want {
+				t.Errorf("Verify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// hook is a callback answering with statuses in turn, then 200
+type hook struct {
+	t        *testing.T
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+	times    []time.Time
+}
+
+func (h *hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests = append(h.requests, r)
+	h.bodies = append(h.bodies, body)
+	h.times = append(h.times, time.Now())
+	status := http.StatusOK
+	if n := len(h.requests); n <= len(h.statuses) {
+		status = h.statuses[n-1]
+	}
+	w.WriteHeader(status)
+}
+
+// queued returns a relay with one delivery of a task event queued for a rule posting to url
+func queued(t *testing.T, url string, opts Options) (*Relay, delivery) {
+	r, err := New([]Rule{{Name: "hr", URL: url, Secret: "s3cret"}}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &events.TaskEvent{Event: events.Event{EventType: events.TaskCreated, ProcessInstanceID: "pi-1", EntityID: "t1"}}
+	if err := r.HandleEvent(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+	return r, <-r.queue
+}
+
+func TestDeliver(t *testing.T) {
+	const minBackoff, maxBackoff = 10 * time.Millisecond, 15 * time.Millisecond
+	tests := []struct {
+		name       string
+		statuses   []int
+		attempts   int
+		deadLetter string // Error of the dead letter, none when empty
+	}{
+		{"delivered", nil, 1, ""},
+		{"5xx retried", []int{503, 502}, 3, ""},
+		{"408 retried", []int{408}, 2, ""},
+		{"429 retried", []int{429}, 2, ""},
+		{"400 permanent", []int{400}, 1, "400 Bad Request"},
+		{"404 permanent", []int{404}, 1, "404 Not Found"},
+		{"out of attempts", []int{500, 500, 500, 500}, 3, "500 Internal Server Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hook{t: t, statuses: tt.statuses}
+			srv := httptest.NewServer(h)
+			defer srv.Close()
+			dl := &bytes.Buffer{}
+			r, d := queued(t, srv.URL, Options{MaxAttempts: 3, MinBackoff: minBackoff, MaxBackoff: maxBackoff, DeadLetter: dl})
+
+			r.deliver(context.Background(), d)
+
+			if len(h.requests) != tt.attempts {
+				t.Fatalf("%d attempts, want %d", len(h.requests), tt.attempts)
+			}
+			for i, req := range h.requests {
+				if req.Method != "POST" || req.Header.Get("Content-Type") != "application/json" ||
+					req.Header.Get(EventHeader) != "TASK_CREATED" || req.Header.Get(DeliveryHeader) != d.id {
+					t.Errorf("attempt %d: %s %v", i+1, req.Method, req.Header)
+				}
+				if sig := req.Header.Get(SignatureHeader); !Verify("s3cret", h.bodies[i], sig) {
+					t.Errorf("attempt %d: signature %q does not verify", i+1, sig)
+				}
+			}
+			// The delay doubles from MinBackoff up to MaxBackoff
+			for i := 1; i < len(h.times); i++ {
+				want := minBackoff << (i - 1)
+				if want > maxBackoff {
+					want = maxBackoff
+				}
+				if gap := h.times[i].Sub(h.times[i-1]); gap < want {
+					t.Errorf("delay before attempt %d = %v, want at least %v", i+1, gap, want)
+				}
+			}
+
+			if tt.deadLetter == "" {
+				if dl.Len() != 0 {
+					t.Errorf("dead letter %s", dl)
+				}
+				return
+			}
+			var got DeadLetter
+			if err := json.Unmarshal(dl.Bytes(), &got); err != nil {
+				t.Fatalf("dead letter %q: %v", dl, err)
+			}
+			if got.Rule != "hr" || got.URL != srv.URL || got.Attempts != tt.attempts || !strings.Contains(got.Error, tt.deadLetter) {
+				t.Errorf("dead letter = %+v", got)
+			}
+		})
+	}
+}
+
+func TestDeadLetterFormat(t *testing.T) {
+	dl := &bytes.Buffer{}
+	r, d := queued(t, "http://hook", Options{DeadLetter: dl})
+	r.deadLetter(d, 2, errors.New("POST http://hook: 410 Gone"))
+	r.deadLetter(d, 3, errors.New("again"))
+
+	lines := strings.SplitAfter(dl.String(), "\n")
+	if len(lines) != 3 || lines[2] != "" {
+		t.Fatalf("got %q, want two JSON lines", dl)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(lines[0]), &raw); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if want := []string{"attempts", "error", "payload", "rule", "time", "url"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %q, want %q", keys, want)
+	}
+
+	var line DeadLetter
+	json.Unmarshal([]byte(lines[0]), &line)
+	if line.Rule != "hr" || line.URL != "http://hook" || line.Attempts != 2 || line.Error != "POST http://hook: 410 Gone" || time.Since(line.Time) > time.Minute {
+		t.Errorf("line = %+v", line)
+	}
+	// The payload is the JSON object which was POSTed, not a string
+	var p Payload
+	if err := json.Unmarshal(line.Payload, &p); err != nil {
+		t.Fatalf("payload %s: %v", line.Payload, err)
+	}
+	if p.ID != d.id || p.Rule != "hr" || p.Event.EventType != events.TaskCreated || p.Event.EntityID != "t1" {
+		t.Errorf("payload = %+v", p)
+	}
+}
+
+func TestRunDeadLettersOnShutdown(t *testing.T) {
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	dl := &bytes.Buffer{}
+	r, err := New([]Rule{{Name: "hr", URL: srv.URL}}, Options{Workers: 1, MinBackoff: time.Hour, DeadLetter: dl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := r.HandleEvent(context.Background(), &events.TaskEvent{Event: events.Event{EventType: events.TaskCreated}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	<-received
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return")
+	}
+
+	// The payload in flight and those still queued are all dead-lettered
+	lines := strings.Split(strings.TrimSpace(dl.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("dead letters %q, want 3", lines)
+	}
+	for _, l := range lines {
+		var got DeadLetter
+		if err := json.Unmarshal([]byte(l), &got); err != nil || !strings.HasPrefix(got.Error, "relay stopped") {
+			t.Errorf("dead letter %s: %v", l, err)
+		}
+	}
+}
+
+func TestHandleEventMatching(t *testing.T) {
+	rules := []Rule{
+		{Name: "all", URL: "http://all"},
+		{Name: "tasks", URL: "http://tasks", EventTypes: []events.EventType{events.TaskCreated, events.TaskCompleted}},
+		{Name: "leave", URL: "http://leave", ProcessDefinitionKeys: []string{"leave"}},
+		{Name: "big", URL: "http://big", Condition: "amount > 100"},
+	}
+	tests := []struct {
+		name  string
+		event events.RuntimeEvent
+		want  []string
+	}{
+		{"task of leave", &events.TaskEvent{Event: events.Event{EventType: events.TaskCreated, ProcessDefinitionKey: "leave", ProcessInstanceID: "big"}}, []string{"all", "tasks", "leave", "big"}},
+		{"other process", &events.TaskEvent{Event: events.Event{EventType: events.TaskCompleted, ProcessDefinitionKey: "expense", ProcessInstanceID: "small"}}, []string{"all", "tasks"}},
+		{"process event", &events.ProcessEvent{Event: events.Event{EventType: events.ProcessStarted, ProcessDefinitionKey: "leave", ProcessInstanceID: "small"}}, []string{"all", "leave"}},
+		{"ended", &events.ProcessEvent{Event: events.Event{EventType: events.ProcessCompleted, ProcessDefinitionKey: "leave", ProcessInstanceID: "big"}}, []string{"all", "leave"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(rules, Options{Variables: func(ctx context.Context, pid string) (map[string]interface{}, error) {
+				if pid == "big" {
+					return map[string]interface{}{"amount": 500.0}, nil
+				}
+				return map[string]interface{}{"amount": 5.0}, nil
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.HandleEvent(context.Background(), tt.event); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for len(r.queue) > 0 {
+				d := <-r.queue
+				got = append(got, d.rule.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
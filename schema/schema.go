@@ -0,0 +1,131 @@
+// Package schema validates process and task variables with JSON Schema.
+//
+//	v, err := schema.Load("schemas/leave.schema.json")
+//	if err != nil {
+//		panic(err)
+//	}
+//	client.SetProcessValidator("leave", v)
+//
+//	_, err = client.StartProcessInstanceWithVariables("leave", map[string]interface{}{"days": "three"})
+//	var invalid *activiti.ValidationError
+//	if errors.As(err, &invalid) {
+//		for _, fe := range invalid.Errors {
+//			fmt.Println(fe.Field, fe.Message) // days: got string, want integer
+//		}
+//	}
+//
+// The schema describes the variables as the properties of an object. When variables
+// are set on a running process instance only the given variables are checked, the
+// required keyword of the root object is ignored.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// resourceURL identifies the compiled schema, $ref to other documents is not supported
+const resourceURL = "variables.schema.json"
+
+var printer = message.NewPrinter(language.English)
+
+// Validator is a compiled variables schema, it implements activiti.VariableValidator
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// Compile compiles a JSON Schema document
+func Compile(data []byte) (*Validator, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	c := jsonschema.NewCompiler()
+	if err = c.AddResource(resourceURL, doc); err != nil {
+		return nil, err
+	}
+	sch, err := c.Compile(resourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{schema: sch}, nil
+}
+
+// Load compiles a JSON Schema file
+func Load(path string) (*Validator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := Compile(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %v", path, err)
+	}
+	return v, nil
+}
+
+// ValidateVariables returns the schema violations of variables, sorted by field
+func (v *Validator) ValidateVariables(variables map[string]interface{}, partial bool) []activiti.VariableError {
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+	// Validate the JSON sent to Activiti rather than the Go values
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return []activiti.VariableError{{Message: err.Error()}}
+	}
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []activiti.VariableError{{Message: err.Error()}}
+	}
+
+	err = v.schema.Validate(inst)
+	if err == nil {
+		return nil
+	}
+	ve, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []activiti.VariableError{{Message: err.Error()}}
+	}
+
+	var errs []activiti.VariableError
+	collect(ve, partial, &errs)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// collect appends the leaf errors of ve, which are the violated keywords
+func collect(ve *jsonschema.ValidationError, partial bool, errs *[]activiti.VariableError) {
+	if len(ve.Causes) > 0 {
+		for _, c := range ve.Causes {
+			collect(c, partial, errs)
+		}
+		return
+	}
+
+	if req, ok := ve.ErrorKind.(*kind.Required); ok {
+		if partial && len(ve.InstanceLocation) == 0 {
+			return
+		}
+		for _, name := range req.Missing {
+			*errs = append(*errs, activiti.VariableError{Field: field(ve.InstanceLocation, name), Message: "is required"})
+		}
+		return
+	}
+	*errs = append(*errs, activiti.VariableError{Field: field(ve.InstanceLocation), Message: ve.ErrorKind.LocalizedString(printer)})
+}
+
+// field returns the dotted path of an instance location and names below it
+func field(location []string, names ...string) string {
+	return strings.Join(append(append([]string(nil), location...), names...), ".")
+}
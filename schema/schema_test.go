@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	activiti "github.com/lihongchen/go-activiti-rest"
+)
+
+const leaveSchema = `{
+	"type": "object",
+	"required": ["days", "employee"],
+	"properties": {
+		"days": {"type": "integer", "minimum": 1},
+		"reason": {"type": "string", "maxLength": 5},
+		"employee": {
+			"type": "object",
+			"required": ["id"],
+			"properties": {"id": {"type": "string"}, "grade": {"enum": ["A", "B"]}}
+		}
+	}
+}`
+
+func TestValidateVariables(t *testing.T) {
+	v, err := Compile([]byte(leaveSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		variables map[string]interface{}
+		partial   bool
+		want      []activiti.VariableError
+	}{
+		{
+			name:      "valid",
+			variables: map[string]interface{}{"days": 3, "employee": map[string]interface{}{"id": "e1", "grade": "A"}},
+		},
+		{
+			name: "missing required",
+			want: []activiti.VariableError{{Field: "days", Message: "is required"}, {Field: "employee", Message: "is required"}},
+		},
+		{
+			name:    "partial ignores root required",
+			partial: true,
+		},
+		{
+			name:      "partial keeps nested required",
+			variables: map[string]interface{}{"employee": map[string]interface{}{}},
+			partial:   true,
+			want:      []activiti.VariableError{{Field: "employee.id", Message: "is required"}},
+		},
+		{
+			name:      "wrong type",
+			variables: map[string]interface{}{"days": "three"},
+			partial:   true,
+			want:      []activiti.VariableError{{Field: "days", Message: "got string, want integer"}},
+		},
+		{
+			name:      "keywords sorted by field",
+			variables: map[string]interface{}{"reason": "too long", "days": 0, "employee": map[string]interface{}{"id": "e1", "grade": "C"}},
+			want: []activiti.VariableError{
+				{Field: "days", Message: "minimum: got 0, want 1"},
+				{Field: "employee.grade", Message: "value must be one of 'A', 'B'"},
+				{Field: "reason", Message: "maxLength: got 8, want 5"},
+			},
+		},
+		{
+			name:      "unmarshalable",
+			variables: map[string]interface{}{"days": func() {}},
+			want:      []activiti.VariableError{{Message: "json: unsupported type: func()"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.ValidateVariables(tt.variables, tt.partial)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not json", `{`},
+		{"invalid keyword value", `{"type": 1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile([]byte(tt.schema)); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load("testdata/missing.schema.json"); err == nil {
+		t.Error("got no error")
+	}
+}
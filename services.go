@@ -0,0 +1,78 @@
+package activiti
+
+//go:generate go run github.com/matryer/moq@v0.6.0 -out mocks/mocks.go -pkg mocks . ProcessDefinitionService ProcessInstanceService TaskService UserService Client
+
+import (
+	"context"
+	"net/url"
+)
+
+type (
+	// ProcessDefinitionService is the process definition part of the API
+	ProcessDefinitionService interface {
+		GetProcessDefinition(pid string) (*ActProcessDefinition, error)
+		GetProcessDefinitions() (ActListProcessDefinitions, error)
+		GetProcessDefinitionMeta(pid string) (*ActProcessDefinitionMeta, error)
+		GetProcessDefinitionModel(pid string) ([]byte, error)
+		FindProcessDefinitions(f ProcessDefinitionFilter) ([]ProcessDefinition, error)
+		GetLatestProcessDefinition(key string) (*ProcessDefinition, error)
+	}
+
+	// ProcessInstanceService is the process instance part of the API
+	ProcessInstanceService interface {
+		GetProcessInstance(pid string) (*ActProcessInstance, error)
+		AdminGetProcessInstance(pid string) (*ActProcessInstance, error)
+		GetProcessInstances() (*ActListProcessInstances, error)
+		QueryProcessInstances(params url.Values) (*ActListProcessInstances, error)
+		GetProcessDiagram(pid string) ([]byte, error)
+		StartProcessInstance(ctx context.Context, opts StartOptions) (*StartResult, error)
+		StartProcessInstanceById(pid string) (*ActProcessInstance, error)
+		StartProcessInstanceByKey(key string) (*ActProcessInstance, error)
+		StartProcessInstanceWithVariables(key string, variables map[string]interface{}) (*ActProcessInstance, error)
+		StartProcessInstanceWithBusinessKeyAndVariables(key, BusinessKey string, variables map[string]interface{}) (*ActProcessInstance, error)
+		StartProcessInstanceOnce(key, businessKey string, variables map[string]interface{}, policy ConflictPolicy) (*ActProcessInstance, error)
+		GetProcessVariables(pid string) (*ActListProcessVariables, error)
+		SetProcessVariables(pid string, variables map[string]interface{}) error
+		AdminSetProcessVariables(pid string, variables map[string]interface{}) error
+		Cancel(key string) error
+		SuspendProcessInstance(pid string) (*ActProcessInstance, error)
+		ResumeProcessInstance(pid string) (*ActProcessInstance, error)
+		ProcessInstancesTasks(key string) (*ActListTasks, error)
+	}
+
+	// TaskService is the task part of the API
+	TaskService interface {
+		GetTask(tid string) (*ActTask, error)
+		GetTasks() (*ActListTasks, error)
+		QueryTasks(params url.Values) (*ActListTasks, error)
+		TaskActionComplete(tid string) error
+		TaskActionCompleteWithVariables(tid string, v map[string]string) error
+		CompleteTask(tid string, variables map[string]interface{}) error
+		TaskActionClaim(tid string, assignee string) error
+		TaskActionAssign(tid string, assignee string) error
+		UpdateTask(tid string, u ActUpdateTask) (*ActTask, error)
+		GetTaskCandidateUsers(tid string) ([]string, error)
+		GetTaskCandidateGroups(tid string) ([]string, error)
+	}
+
+	// UserService is the identity part of the API
+	UserService interface {
+		GetUser(uid string) (*ActUser, error)
+		GetUsers() (*ActUsers, error)
+		CreateUser(u ActUser) (*ActUser, error)
+		UpdateUser(u ActUser) (*ActUser, error)
+		DeleteUser(uid string) error
+	}
+
+	// Client is the whole API implemented by ActClient.
+	// Depend on it, or on one of the smaller services, instead of *ActClient
+	// so fakes from the mocks package can be injected in tests
+	Client interface {
+		ProcessDefinitionService
+		ProcessInstanceService
+		TaskService
+		UserService
+	}
+)
+
+var _ Client = (*ActClient)(nil)
@@ -0,0 +1,181 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultWaitTimeout is the wait for the first user task when StartOptions.WaitTimeout is 0
+	DefaultWaitTimeout = 10 * time.Second
+	// DefaultPollInterval is the time between task polls when StartOptions.PollInterval is 0
+	DefaultPollInterval = 500 * time.Millisecond
+)
+
+// ErrUserTaskTimeout is returned with the started instance when no user task was created within StartOptions.WaitTimeout
+var ErrUserTaskTimeout = errors.New("timed out waiting for the first user task")
+
+type (
+	// StartOptions describes the process instance to start, by definition id or key
+	StartOptions struct {
+		ProcessDefinitionId  string
+		ProcessDefinitionKey string
+		BusinessKey          string
+		Name                 string
+		Variables            map[string]interface{}
+
+		// AppName and AppVersion select the latest definition of ProcessDefinitionKey deployed with an application
+		AppName    string
+		AppVersion string
+
+		// ServiceURL is the runtime bundle to start the instance in, BaseURL when empty
+		ServiceURL string
+
+		// Once returns or rejects, according to OnConflict, a running or suspended instance with
+		// the same ProcessDefinitionKey and BusinessKey instead of starting a new one
+		Once       bool
+		OnConflict ConflictPolicy
+
+		// WaitForUserTask polls the tasks of the instance until one exists or WaitTimeout elapses,
+		// also for an existing instance returned with Once
+		WaitForUserTask bool
+		WaitTimeout     time.Duration
+		PollInterval    time.Duration
+	}
+
+	// StartResult is the outcome of StartProcessInstance
+	StartResult struct {
+		ProcessInstance ProcessInstance
+		Tasks           []Task // The first user tasks, or the open ones of an existing instance, with StartOptions.WaitForUserTask
+		Existing        bool   // The instance already existed, with StartOptions.Once
+	}
+)
+
+// StartProcessInstance starts a process instance
+// Endpoint: POST runtime/process-instances
+func (c *ActClient) StartProcessInstance(ctx context.Context, opts StartOptions) (*StartResult, error) {
+	if opts.ProcessDefinitionId == "" && opts.ProcessDefinitionKey == "" {
+		return nil, errors.New("Process definition id or key is required to start a process instance ")
+	}
+	if opts.Once && (opts.ProcessDefinitionKey == "" || opts.BusinessKey == "") {
+		return nil, errors.New("key and businessKey are required to start a process instance once ")
+	}
+	if err := c.validateStart(opts.ProcessDefinitionKey, opts.ProcessDefinitionId, opts.Variables); err != nil {
+		return nil, err
+	}
+
+	cc := c.WithContext(ctx)
+	if opts.ServiceURL != "" {
+		cc = cc.withBaseURL(opts.ServiceURL)
+	}
+
+	if opts.Once && opts.OnConflict != ConflictStartAnyway {
+		existing, err := cc.findUnfinishedProcessInstance(opts.ProcessDefinitionKey, opts.BusinessKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			if opts.OnConflict == ConflictError {
+				return nil, &ProcessInstanceExistsError{Existing: existing.ProcessInstance}
+			}
+			res := &StartResult{ProcessInstance: existing.ProcessInstance, Existing: true}
+			if opts.WaitForUserTask {
+				if res.Tasks, err = cc.waitForUserTasks(ctx, res.ProcessInstance.ID, opts); err != nil {
+					return res, err
+				}
+			}
+			return res, nil
+		}
+	}
+
+	s := ActStartProcessInstance{
+		ProcessDefinitionId:  opts.ProcessDefinitionId,
+		ProcessDefinitionKey: opts.ProcessDefinitionKey,
+		BusinessKey:          opts.BusinessKey,
+		Name:                 opts.Name,
+		Variables:            opts.Variables,
+	}
+	if s.ProcessDefinitionId == "" && (opts.AppName != "" || opts.AppVersion != "") {
+		pd, err := cc.findLatestProcessDefinition(ProcessDefinitionFilter{
+			Key:        opts.ProcessDefinitionKey,
+			AppName:    opts.AppName,
+			AppVersion: opts.AppVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.ProcessDefinitionId, s.ProcessDefinitionKey = pd.ID, ""
+	}
+
+	pi, err := cc.startProcessInstance(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	res := &StartResult{ProcessInstance: pi.ProcessInstance}
+
+	if opts.WaitForUserTask {
+		if res.Tasks, err = cc.waitForUserTasks(ctx, pi.ProcessInstance.ID, opts); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// withBaseURL returns a copy of the client sending runtime requests to baseURL
+func (c *ActClient) withBaseURL(baseURL string) *ActClient {
+	cc := *c
+	cc.BaseURL = baseURL
+	cc.middleware = append([]Middleware(nil), c.middleware...)
+	return &cc
+}
+
+// waitForUserTasks polls the tasks of a process instance until there is one
+func (c *ActClient) waitForUserTasks(ctx context.Context, pid string, opts StartOptions) ([]Task, error) {
+	timeout, interval := opts.WaitTimeout, opts.PollInterval
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		tks, err := c.processInstanceTasks(ctx, pid)
+		if err != nil {
+			return nil, err
+		}
+		if len(tks.List.Tasks) > 0 {
+			tasks := make([]Task, len(tks.List.Tasks))
+			for i, t := range tks.List.Tasks {
+				tasks[i] = t.Task
+			}
+			return tasks, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, ErrUserTaskTimeout
+		case <-time.After(interval):
+		}
+	}
+}
+
+// processInstanceTasks retrieves the open tasks of a process instance from the runtime bundle
+// Endpoint: GET runtime/process-instances/{processInstanceId}/tasks
+func (c *ActClient) processInstanceTasks(ctx context.Context, pid string) (*ActListTasks, error) {
+	tks := &ActListTasks{}
+	req, err := c.newRequestContext(ctx, "GetProcessInstanceTasks", "GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/process-instances/", pid, "/tasks"), nil)
+	if err != nil {
+		return tks, err
+	}
+	if err = c.SendWithBasicAuth(req, tks); err != nil {
+		return tks, err
+	}
+	return tks, nil
+}
@@ -0,0 +1,101 @@
+package activiti
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestStartProcessInstanceWaitForUserTask(t *testing.T) {
+	existing := []ProcessInstance{{ID: "pi1", ProcessDefinitionKey: "leave", BusinessKey: "order-1", Status: "RUNNING"}}
+	tests := []struct {
+		name      string
+		instances []ProcessInstance
+		once      bool
+		want      string
+		task      string
+		existing  bool
+	}{
+		{"new", nil, false, "new", "task-new", false},
+		{"once new", nil, true, "new", "task-new", false},
+		{"once existing", existing, true, "pi1", "task-pi1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := 0
+			srv := queryServer(t, tt.instances, &started)
+			defer srv.Close()
+
+			c, _ := NewClient("token", srv.URL+"/rb/v1")
+			res, err := c.StartProcessInstance(context.Background(), StartOptions{
+				ProcessDefinitionKey: "leave",
+				BusinessKey:          "order-1",
+				Once:                 tt.once,
+				WaitForUserTask:      true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.ProcessInstance.ID != tt.want || res.Existing != tt.existing {
+				t.Errorf("instance = %s existing %v, want %s existing %v", res.ProcessInstance.ID, res.Existing, tt.want, tt.existing)
+			}
+			if len(res.Tasks) != 1 || res.Tasks[0].ID != tt.task {
+				t.Errorf("tasks = %+v, want %s", res.Tasks, tt.task)
+			}
+		})
+	}
+}
+
+func TestLegacyStartUsesClientContext(t *testing.T) {
+	type ctxKey struct{}
+	tests := []struct {
+		name  string
+		start func(c *ActClient) (*ActProcessInstance, error)
+	}{
+		{"by key", func(c *ActClient) (*ActProcessInstance, error) { return c.StartProcessInstanceByKey("leave") }},
+		{"by id", func(c *ActClient) (*ActProcessInstance, error) { return c.StartProcessInstanceById("leave:1:abc") }},
+		{"with variables", func(c *ActClient) (*ActProcessInstance, error) {
+			return c.StartProcessInstanceWithVariables("leave", map[string]interface{}{"days": 1})
+		}},
+		{"with business key", func(c *ActClient) (*ActProcessInstance, error) {
+			return c.StartProcessInstanceWithBusinessKeyAndVariables("leave", "order-1", nil)
+		}},
+		{"once", func(c *ActClient) (*ActProcessInstance, error) {
+			return c.StartProcessInstanceOnce("leave", "order-1", nil, ConflictReturnExisting)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := 0
+			srv := queryServer(t, nil, &started)
+			defer srv.Close()
+
+			c, _ := NewClient("token", srv.URL+"/rb/v1")
+			var seen []interface{}
+			c.Use(BeforeRequest(func(req *http.Request) error {
+				seen = append(seen, req.Context().Value(ctxKey{}))
+				return nil
+			}))
+			ctx := context.WithValue(context.Background(), ctxKey{}, "caller")
+
+			if _, err := tt.start(c.WithContext(ctx)); err != nil {
+				t.Fatal(err)
+			}
+			if len(seen) == 0 || seen[len(seen)-1] != "caller" {
+				t.Errorf("requests saw context values %v, want caller", seen)
+			}
+
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			started = 0
+			if _, err := tt.start(c.WithContext(cancelled)); !errors.Is(err, context.Canceled) {
+				t.Errorf("err = %v, want context.Canceled", err)
+			}
+			if started != 0 {
+				t.Errorf("started %d instances with a cancelled context", started)
+			}
+		})
+	}
+}
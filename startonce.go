@@ -0,0 +1,87 @@
+package activiti
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ConflictPolicy decides what StartProcessInstanceOnce does when an unfinished
+// process instance with the same definition key and business key exists
+type ConflictPolicy int
+
+const (
+	ConflictReturnExisting ConflictPolicy = iota // Return the existing instance
+	ConflictError                                // Return a ProcessInstanceExistsError
+	ConflictStartAnyway                          // Start a new instance, as StartProcessInstanceWithBusinessKeyAndVariables
+)
+
+// ErrProcessInstanceExists matches every ProcessInstanceExistsError with errors.Is
+var ErrProcessInstanceExists = errors.New("process instance exists")
+
+// unfinishedStatuses are the process instance statuses which count as a conflict
+var unfinishedStatuses = []string{"RUNNING", "SUSPENDED"}
+
+// ProcessInstanceExistsError is returned by StartProcessInstanceOnce with ConflictError
+type ProcessInstanceExistsError struct {
+	Existing ProcessInstance
+}
+
+// Error implements error
+func (e *ProcessInstanceExistsError) Error() string {
+	return fmt.Sprintf("%v: %s with business key %s is %s", ErrProcessInstanceExists, e.Existing.ID, e.Existing.BusinessKey, e.Existing.Status)
+}
+
+// Unwrap makes errors.Is(err, ErrProcessInstanceExists) true
+func (e *ProcessInstanceExistsError) Unwrap() error {
+	return ErrProcessInstanceExists
+}
+
+// StartProcessInstanceOnce starts a process instance unless a running or suspended instance
+// of the definition key with the same business key exists, so callers may safely retry.
+// The check uses the query service, which is updated asynchronously, so two concurrent
+// calls may still both start an instance
+func (c *ActClient) StartProcessInstanceOnce(key, businessKey string, variables map[string]interface{}, policy ConflictPolicy) (*ActProcessInstance, error) {
+	if key == "" || businessKey == "" {
+		return nil, errors.New("key and businessKey are required to start a process instance once ")
+	}
+
+	return c.startWithOptions(StartOptions{
+		ProcessDefinitionKey: key,
+		BusinessKey:          businessKey,
+		Variables:            variables,
+		Once:                 true,
+		OnConflict:           policy,
+	})
+}
+
+// findUnfinishedProcessInstance returns the first running or suspended instance with the keys, or nil.
+// It pages through the query results until it finds one
+func (c *ActClient) findUnfinishedProcessInstance(key, businessKey string) (*ActProcessInstance, error) {
+	var found *ActProcessInstance
+	for _, status := range unfinishedStatuses {
+		params := url.Values{
+			"processDefinitionKey": {key},
+			"businessKey":          {businessKey},
+			"status":               {status},
+		}
+		err := queryPages(params, func(p url.Values) (Pagination, int, error) {
+			pis, err := c.QueryProcessInstances(p)
+			if err != nil {
+				return Pagination{}, 0, err
+			}
+			for _, pi := range pis.List.ProcessInstances {
+				// Guard against query services ignoring unknown filters
+				if pi.ProcessInstance.BusinessKey == businessKey && pi.ProcessInstance.ProcessDefinitionKey == key {
+					found = &pi
+					return Pagination{}, 0, nil
+				}
+			}
+			return pis.List.Pagination, len(pis.List.ProcessInstances), nil
+		})
+		if err != nil || found != nil {
+			return found, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,88 @@
+package activiti
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// queryServer serves instances from the query service one per page, starts new
+// instances and returns one task per instance
+func queryServer(t *testing.T, instances []ProcessInstance, started *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/query/v1/process-instances":
+			q := r.URL.Query()
+			if q.Get("maxItems") == "" {
+				t.Errorf("query without maxItems: %s", r.URL)
+			}
+			var matching []ProcessInstance
+			for _, pi := range instances {
+				if pi.Status == q.Get("status") {
+					matching = append(matching, pi)
+				}
+			}
+			skip, _ := strconv.Atoi(q.Get("skipCount"))
+			page := ActListProcessInstances{}
+			if skip < len(matching) {
+				page.List.ProcessInstances = []ActProcessInstance{{ProcessInstance: matching[skip]}}
+			}
+			page.List.Pagination.HasMoreItems = skip+1 < len(matching)
+			json.NewEncoder(w).Encode(page)
+		case "/rb/v1/process-instances":
+			*started++
+			w.Write([]byte(`{"entry":{"id":"new","status":"RUNNING"}}`))
+		default:
+			pid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/rb/v1/process-instances/"), "/tasks")
+			if !strings.HasSuffix(r.URL.Path, "/tasks") || strings.Contains(pid, "/") {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintf(w, `{"list":{"entries":[{"entry":{"id":"task-%s","processInstanceId":"%s"}}]}}`, pid, pid)
+		}
+	}))
+}
+
+func TestStartProcessInstanceOnce(t *testing.T) {
+	other := ProcessInstance{ID: "other", ProcessDefinitionKey: "leave", BusinessKey: "order-2", Status: "RUNNING"}
+	tests := []struct {
+		name      string
+		instances []ProcessInstance
+		policy    ConflictPolicy
+		want      string
+		started   int
+		err       error
+	}{
+		{"none", nil, ConflictReturnExisting, "new", 1, nil},
+		{"other business key", []ProcessInstance{other}, ConflictReturnExisting, "new", 1, nil},
+		{"running on a later page", []ProcessInstance{other, other, {ID: "pi1", ProcessDefinitionKey: "leave", BusinessKey: "order-1", Status: "RUNNING"}}, ConflictReturnExisting, "pi1", 0, nil},
+		{"suspended", []ProcessInstance{other, {ID: "pi2", ProcessDefinitionKey: "leave", BusinessKey: "order-1", Status: "SUSPENDED"}}, ConflictReturnExisting, "pi2", 0, nil},
+		{"completed", []ProcessInstance{{ID: "pi3", ProcessDefinitionKey: "leave", BusinessKey: "order-1", Status: "COMPLETED"}}, ConflictReturnExisting, "new", 1, nil},
+		{"error", []ProcessInstance{{ID: "pi1", ProcessDefinitionKey: "leave", BusinessKey: "order-1", Status: "RUNNING"}}, ConflictError, "", 0, ErrProcessInstanceExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := 0
+			srv := queryServer(t, tt.instances, &started)
+			defer srv.Close()
+
+			c, _ := NewClient("token", srv.URL+"/rb/v1")
+			pi, err := c.StartProcessInstanceOnce("leave", "order-1", nil, tt.policy)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if err == nil && pi.ProcessInstance.ID != tt.want {
+				t.Errorf("instance = %s, want %s", pi.ProcessInstance.ID, tt.want)
+			}
+			if started != tt.started {
+				t.Errorf("started %d instances, want %d", started, tt.started)
+			}
+		})
+	}
+}
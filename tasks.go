@@ -10,7 +10,7 @@ import (
 func (c *ActClient) GetTask(tid string) (*ActTask, error) {
 	tk := &ActTask{}
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/tasks/", tid), nil)
+	req, err := c.newRequest("GetTask", "GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/tasks/", tid), nil)
 	if err != nil {
 		return tk, err
 	}
@@ -27,7 +27,7 @@ func (c *ActClient) GetTask(tid string) (*ActTask, error) {
 func (c *ActClient) GetTasks() (*ActListTasks, error) {
 	tks := &ActListTasks{}
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s", c.BaseURL, "/tasks?page=0&size=1000"), nil)
+	req, err := c.newRequest("GetTasks", "GET", fmt.Sprintf("%s%s", c.BaseURL, "/tasks?page=0&size=1000"), nil)
 	if err != nil {
 		return tks, err
 	}
@@ -44,10 +44,13 @@ func (c *ActClient) TaskActionComplete(tid string) error {
 	if tid == "" {
 		return errors.New("Task id   are required for task action ")
 	}
+	if err := c.validateTaskVariables(tid, nil); err != nil {
+		return err
+	}
 
 	params := map[string]string{"payloadType": "CompleteTaskPayload"}
 
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/complete"), params)
+	req, err := c.newRequest("TaskActionComplete", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/complete"), params)
 	if err != nil {
 		return err
 	}
@@ -65,14 +68,49 @@ func (c *ActClient) TaskActionCompleteWithVariables(tid string, v map[string]str
 	if tid == "" {
 		return errors.New("Task id   are required for task action ")
 	}
+	if c.validators.hasTasks() {
+		vars := make(map[string]interface{}, len(v))
+		for key, v := range v {
+			vars[key] = v
+		}
+		if err := c.validateTaskVariables(tid, vars); err != nil {
+			return err
+		}
+	}
 
 	params := map[string]string{"payloadType": "CompleteTaskPayload"}
 	for key, v := range v {
 		params[key] = v
 	}
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/complete"), params)
+	req, err := c.newRequest("TaskActionCompleteWithVariables", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/complete"), params)
+	if err != nil {
+		return err
+	}
+
+	if err = c.SendWithBasicAuth(req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CompleteTask completes a task, variables keep their JSON types
+// Endpoint: POST runtime/tasks/{taskId}/complete
+func (c *ActClient) CompleteTask(tid string, variables map[string]interface{}) error {
+	if tid == "" {
+		return errors.New("Task id   are required for task action ")
+	}
+	if err := c.validateTaskVariables(tid, variables); err != nil {
+		return err
+	}
+
+	params := struct {
+		PayloadType string                 `json:"payloadType"`
+		Variables   map[string]interface{} `json:"variables,omitempty"`
+	}{PayloadType: "CompleteTaskPayload", Variables: variables}
+
+	req, err := c.newRequest("CompleteTask", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/complete"), params)
 	if err != nil {
-		fmt.Println(req)
 		return err
 	}
 
@@ -92,7 +130,7 @@ func (c *ActClient) TaskActionClaim(tid string, assignee string) error {
 
 	params := map[string]string{"action": string(TASK_ACTION_COMPLETE), "assignee": assignee}
 
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/claim"), params)
+	req, err := c.newRequest("TaskActionClaim", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/claim"), params)
 	if err != nil {
 		return err
 	}
@@ -113,7 +151,7 @@ func (c *ActClient) TaskActionAssign(tid string, assignee string) error {
 
 	params := map[string]string{"action": string(TASK_ACTION_COMPLETE), "assignee": assignee}
 
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/assign"), params)
+	req, err := c.newRequest("TaskActionAssign", "POST", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, "/assign"), params)
 	if err != nil {
 		return err
 	}
@@ -124,3 +162,75 @@ func (c *ActClient) TaskActionAssign(tid string, assignee string) error {
 
 	return nil
 }
+
+// UpdateTask changes the name, description, due date, priority, assignee or form key of a task
+// Endpoint: PUT runtime/tasks/{taskId}
+func (c *ActClient) UpdateTask(tid string, u ActUpdateTask) (*ActTask, error) {
+	if tid == "" {
+		return nil, errors.New("Task id is required ")
+	}
+	if u.PayloadType == "" {
+		u.PayloadType = "UpdateTaskPayload"
+	}
+	tk := &ActTask{}
+
+	req, err := c.newRequest("UpdateTask", "PUT", fmt.Sprintf("%s%s%s", c.BaseURL, "/tasks/", tid), u)
+	if err != nil {
+		return tk, err
+	}
+
+	if err = c.SendWithBasicAuth(req, tk); err != nil {
+		return tk, err
+	}
+
+	return tk, nil
+}
+
+// GetTaskCandidateUsers retrieves the users who can claim a task
+// Endpoint: GET runtime/tasks/{taskId}/candidate-users
+func (c *ActClient) GetTaskCandidateUsers(tid string) ([]string, error) {
+	cs, err := c.getTaskCandidates("GetTaskCandidateUsers", tid, "/candidate-users")
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]string, 0, len(cs.List.Candidates))
+	for _, e := range cs.List.Candidates {
+		users = append(users, e.Candidate.User)
+	}
+	return users, nil
+}
+
+// GetTaskCandidateGroups retrieves the groups whose members can claim a task
+// Endpoint: GET runtime/tasks/{taskId}/candidate-groups
+func (c *ActClient) GetTaskCandidateGroups(tid string) ([]string, error) {
+	cs, err := c.getTaskCandidates("GetTaskCandidateGroups", tid, "/candidate-groups")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, 0, len(cs.List.Candidates))
+	for _, e := range cs.List.Candidates {
+		groups = append(groups, e.Candidate.Group)
+	}
+	return groups, nil
+}
+
+// getTaskCandidates retrieves the candidate users or groups of a task
+func (c *ActClient) getTaskCandidates(op, tid, path string) (*ActListTaskCandidates, error) {
+	if tid == "" {
+		return nil, errors.New("Task id is required ")
+	}
+	cs := &ActListTaskCandidates{}
+
+	req, err := c.newRequest(op, "GET", fmt.Sprintf("%s%s%s%s", c.BaseURL, "/tasks/", tid, path), nil)
+	if err != nil {
+		return cs, err
+	}
+
+	if err = c.SendWithBasicAuth(req, cs); err != nil {
+		return cs, err
+	}
+
+	return cs, nil
+}
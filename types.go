@@ -1,9 +1,11 @@
 package activiti
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -23,10 +25,34 @@ type (
 
 	// Client represents a Activiti 6.x REST API Client
 	ActClient struct {
-		Client  *http.Client
-		Token   string
-		BaseURL string
-		Log     io.Writer // If user set log file name all requests will be logged there
+		Client     *http.Client
+		Token      string
+		BaseURL    string
+		QueryURL   string       // Query service url, derived from BaseURL when empty
+		Logger     *slog.Logger // If set all requests will be logged there
+		LogOptions LogOptions
+		Header     http.Header // Default headers added to every request which does not set them
+
+		// Deprecated: use SetLogger or SetLog. When Logger is nil, requests and
+		// responses, including headers and bodies, are logged to Log as text
+		Log io.Writer
+
+		ctx        context.Context // Context of the requests, see WithContext
+		middleware []Middleware
+		limiters   *limiters
+		breakers   *breakers
+
+		definitionCache *definitionCache
+		validators      *validators
+	}
+
+	// LogOptions controls what is logged for each request, in addition to
+	// request id, method, URL, status and latency
+	LogOptions struct {
+		LogHeaders  bool // Log request and response headers
+		LogBodies   bool // Log request and response bodies
+		MaxBodySize int  // Logged bodies are truncated to this size, 0 means DefaultMaxLogBodySize
+		RequestID   bool // Send a RequestIDHeader when no logger is set, it is always sent when logging
 	}
 
 	expirationTime int64
@@ -34,8 +60,8 @@ type (
 	// ErrorResponse https://www.activiti.org/userguide/#_error_response_body
 	ActErrorResponse struct {
 		Response     *http.Response `json:"-"`
-		statusCode   string         `json:"statusCode"`
-		errorMessage string         `json:"errorMessage"`
+		StatusCode   string         `json:"statusCode"`
+		ErrorMessage string         `json:"errorMessage"`
 	}
 
 	ActProcessDefinition struct {
@@ -62,6 +88,14 @@ type (
 	ActListProcessDefinitions struct {
 		List ActProcessDefinitions
 	}
+
+	// ProcessDefinitionFilter selects process definitions, empty fields match anything
+	ProcessDefinitionFilter struct {
+		Key        string
+		Name       string
+		AppName    string
+		AppVersion string
+	}
 	Pagination struct {
 		Count        int  `json:"count,omitempty"`
 		HasMoreItems bool `json:"hasMoreItems,omitempty"`
@@ -76,6 +110,8 @@ type (
 
 	ProcessInstance struct {
 		ID                       string `json:"id,omitempty"`
+		Name                     string `json:"name,omitempty"`
+		BusinessKey              string `json:"businessKey,omitempty"`
 		AppName                  string `json:"appName,omitempty"`
 		Initiator                string `json:"initiator,omitempty"`
 		ProcessDefinitionId      string `json:"processDefinitionId,omitempty"`
@@ -88,6 +124,7 @@ type (
 		ServiceVersion           string `json:"serviceVersion,omitempty"`
 		StartDate                string `json:"startDate,omitempty"`
 		Status                   string `json:"status,omitempty"`
+		LastModified             string `json:"lastModified,omitempty"`
 	}
 	ActProcessInstances struct {
 		ProcessInstances []ActProcessInstance `json:"entries,omitempty"`
@@ -126,6 +163,10 @@ type (
 		BusinessKey         string `json:"businessKey,omitempty"`
 		CompletedBy         string `json:"completedBy,omitempty"`
 		CompletedDate       string `json:"completedDate,omitempty"`
+		LastModified        string `json:"lastModified,omitempty"`
+		Description         string `json:"description,omitempty"`
+		DueDate             string `json:"dueDate,omitempty"`
+		ClaimedDate         string `json:"claimedDate,omitempty"`
 	}
 	ActTask struct {
 		Task Task `json:"entry,omitempty"`
@@ -139,6 +180,50 @@ type (
 		List ActTasks
 	}
 
+	// ActUpdateTask changes the fields of a task, empty fields are left unchanged
+	ActUpdateTask struct {
+		PayloadType string `json:"payloadType,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		DueDate     string `json:"dueDate,omitempty"`
+		Priority    int    `json:"priority,omitempty"`
+		Assignee    string `json:"assignee,omitempty"`
+		FormKey     string `json:"formKey,omitempty"`
+	}
+
+	TaskCandidate struct {
+		User  string `json:"user,omitempty"`
+		Group string `json:"group,omitempty"`
+	}
+	ActTaskCandidate struct {
+		Candidate TaskCandidate `json:"entry,omitempty"`
+	}
+	ActTaskCandidates struct {
+		Candidates []ActTaskCandidate `json:"entries,omitempty"`
+		Pagination Pagination         `json:"pagination,omitempty"`
+	}
+	ActListTaskCandidates struct {
+		List ActTaskCandidates
+	}
+
+	ProcessVariable struct {
+		Name              string      `json:"name,omitempty"`
+		Type              string      `json:"type,omitempty"`
+		Value             interface{} `json:"value,omitempty"`
+		ProcessInstanceId string      `json:"processInstanceId,omitempty"`
+		TaskId            string      `json:"taskId,omitempty"`
+	}
+	ActProcessVariable struct {
+		Variable ProcessVariable `json:"entry,omitempty"`
+	}
+	ActProcessVariables struct {
+		Variables  []ActProcessVariable `json:"entries,omitempty"`
+		Pagination Pagination           `json:"pagination,omitempty"`
+	}
+	ActListProcessVariables struct {
+		List ActProcessVariables
+	}
+
 	ActUser struct {
 		ID         string `json:"id,omitempty"`
 		FirstName  string `json:"firstName,omitempty"`
@@ -158,20 +243,66 @@ type (
 		Size  int       `json:"size,omitempty"`
 	}
 	ProcessDefinitionMeta struct {
-		ID          string   `json:"id,omitempty"`
-		Name        string   `json:"name,omitempty"`
-		Description string   `json:"description,omitempty"`
-		Version     int      `json:"version,omitempty"`
-		Groups      []string `json:"groups,omitempty"`
+		ID           string                         `json:"id,omitempty"`
+		Name         string                         `json:"name,omitempty"`
+		Description  string                         `json:"description,omitempty"`
+		Version      int                            `json:"version,omitempty"`
+		Users        []string                       `json:"users,omitempty"`
+		Groups       []string                       `json:"groups,omitempty"`
+		Variables    []ProcessDefinitionVariable    `json:"variables,omitempty"`
+		UserTasks    []ProcessDefinitionUserTask    `json:"userTasks,omitempty"`
+		ServiceTasks []ProcessDefinitionServiceTask `json:"serviceTasks,omitempty"`
+	}
+	// ProcessDefinitionVariable is a variable declared by a process definition
+	ProcessDefinitionVariable struct {
+		Name string `json:"name,omitempty"`
+		Type string `json:"type,omitempty"`
+	}
+	ProcessDefinitionUserTask struct {
+		TaskName        string `json:"taskName,omitempty"`
+		TaskDescription string `json:"taskDescription,omitempty"`
+	}
+	ProcessDefinitionServiceTask struct {
+		TaskName           string `json:"taskName,omitempty"`
+		TaskImplementation string `json:"taskImplementation,omitempty"`
 	}
 	ActProcessDefinitionMeta struct {
 		Entry ProcessDefinitionMeta `json:"entry,omitempty"`
 	}
 )
 
+// dateLayouts are the date formats used by Activiti Cloud services
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05.000Z",
+}
+
+// ParseDate parses a date returned by Activiti, such as Task.CreatedDate
+func ParseDate(s string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
 // Error method implementation for ErrorResponse struct
 func (r *ActErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %s", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.errorMessage)
+	return fmt.Sprintf("%v %v: %d %s", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.ErrorMessage)
+}
+
+// Map returns the variables by name
+func (l *ActListProcessVariables) Map() map[string]interface{} {
+	vars := make(map[string]interface{}, len(l.List.Variables))
+	for _, v := range l.List.Variables {
+		vars[v.Variable.Name] = v.Variable.Value
+	}
+	return vars
 }
 
 // MarshalJSON for JSONTime
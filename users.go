@@ -9,7 +9,7 @@ import (
 func (c *ActClient) GetUser(uid string) (*ActUser, error) {
 	user := &ActUser{}
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", uid), nil)
+	req, err := c.newRequest("GetUser", "GET", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", uid), nil)
 	if err != nil {
 		return user, err
 	}
@@ -26,7 +26,7 @@ func (c *ActClient) GetUser(uid string) (*ActUser, error) {
 func (c *ActClient) GetUsers() (*ActUsers, error) {
 	users := &ActUsers{}
 
-	req, err := c.NewRequest("GET", fmt.Sprintf("%s%s", c.BaseURL, "/identity/users"), nil)
+	req, err := c.newRequest("GetUsers", "GET", fmt.Sprintf("%s%s", c.BaseURL, "/identity/users"), nil)
 	if err != nil {
 		return users, err
 	}
@@ -43,7 +43,7 @@ func (c *ActClient) GetUsers() (*ActUsers, error) {
 func (c *ActClient) CreateUser(u ActUser) (*ActUser, error) {
 	user := &ActUser{}
 
-	req, err := c.NewRequest("POST", fmt.Sprintf("%s%s", c.BaseURL, "/identity/users"), u)
+	req, err := c.newRequest("CreateUser", "POST", fmt.Sprintf("%s%s", c.BaseURL, "/identity/users"), u)
 	if err != nil {
 		return user, err
 	}
@@ -60,7 +60,7 @@ func (c *ActClient) CreateUser(u ActUser) (*ActUser, error) {
 func (c *ActClient) UpdateUser(u ActUser) (*ActUser, error) {
 	user := &ActUser{}
 
-	req, err := c.NewRequest("PUT", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", u.ID), u)
+	req, err := c.newRequest("UpdateUser", "PUT", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", u.ID), u)
 	if err != nil {
 		return user, err
 	}
@@ -75,7 +75,7 @@ func (c *ActClient) UpdateUser(u ActUser) (*ActUser, error) {
 // DeleteUser deletes a user in activiti
 // Endpoint: DELETE identity/users/{userId}
 func (c *ActClient) DeleteUser(uid string) error {
-	req, err := c.NewRequest("DELETE", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", uid), nil)
+	req, err := c.newRequest("DeleteUser", "DELETE", fmt.Sprintf("%s%s%s", c.BaseURL, "/identity/users/", uid), nil)
 	if err != nil {
 		return err
 	}
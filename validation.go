@@ -0,0 +1,201 @@
+package activiti
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type (
+	// VariableValidator checks variables before they are sent to Activiti.
+	// With partial only the given variables are checked, missing required variables are not errors
+	VariableValidator interface {
+		ValidateVariables(variables map[string]interface{}, partial bool) []VariableError
+	}
+
+	// VariableValidatorFunc adapts a function to VariableValidator
+	VariableValidatorFunc func(variables map[string]interface{}, partial bool) []VariableError
+
+	// VariableError is an invalid variable. Field is the variable name, followed by
+	// the path of the invalid value within object variables, for example "address.zip"
+	VariableError struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError is returned, before any request, when variables are rejected by a validator
+	ValidationError struct {
+		ProcessDefinitionKey string          `json:"processDefinitionKey,omitempty"`
+		TaskDefinitionKey    string          `json:"taskDefinitionKey,omitempty"`
+		Errors               []VariableError `json:"errors"`
+	}
+
+	// validators holds the validators registered on a client
+	validators struct {
+		mu        sync.RWMutex
+		processes map[string]VariableValidator
+		tasks     map[string]VariableValidator
+	}
+)
+
+// ValidateVariables calls f
+func (f VariableValidatorFunc) ValidateVariables(variables map[string]interface{}, partial bool) []VariableError {
+	return f(variables, partial)
+}
+
+func (e VariableError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+	of := e.ProcessDefinitionKey
+	if e.TaskDefinitionKey != "" {
+		of = "task " + e.TaskDefinitionKey
+	}
+	return fmt.Sprintf("invalid variables of %s: %s", of, strings.Join(msgs, "; "))
+}
+
+// SetProcessValidator validates the variables of the process instances of a process definition key,
+// when starting them and in SetProcessVariables. A nil validator removes the registration.
+// Once a process validator is set, setting process variables first gets the process
+// instance to look up its definition key, one more request per call
+func (c *ActClient) SetProcessValidator(processDefinitionKey string, v VariableValidator) {
+	vs := c.variableValidators()
+	vs.set(vs.processes, processDefinitionKey, v)
+}
+
+// SetTaskValidator validates the variables completing the tasks of a task definition key.
+// A nil validator removes the registration. Once a task validator is set, completing a
+// task first gets the task to look up its definition key, one more request per call
+func (c *ActClient) SetTaskValidator(taskDefinitionKey string, v VariableValidator) {
+	vs := c.variableValidators()
+	vs.set(vs.tasks, taskDefinitionKey, v)
+}
+
+// newValidators returns an empty registry of validators
+func newValidators() *validators {
+	return &validators{processes: map[string]VariableValidator{}, tasks: map[string]VariableValidator{}}
+}
+
+// variableValidators returns the validators of the client. NewClient creates them, clients
+// built as struct literals get them on first use and only share them with later copies
+func (c *ActClient) variableValidators() *validators {
+	lazyInit.Lock()
+	defer lazyInit.Unlock()
+	if c.validators == nil {
+		c.validators = newValidators()
+	}
+	return c.validators
+}
+
+func (vs *validators) set(m map[string]VariableValidator, key string, v VariableValidator) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	if v == nil {
+		delete(m, key)
+		return
+	}
+	m[key] = v
+}
+
+// process returns the validator of a process definition key, nil when there is none
+func (vs *validators) process(key string) VariableValidator {
+	if vs == nil {
+		return nil
+	}
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return vs.processes[key]
+}
+
+// task returns the validator of a task definition key, nil when there is none
+func (vs *validators) task(key string) VariableValidator {
+	if vs == nil {
+		return nil
+	}
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return vs.tasks[key]
+}
+
+func (vs *validators) hasProcesses() bool {
+	if vs == nil {
+		return false
+	}
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return len(vs.processes) > 0
+}
+
+func (vs *validators) hasTasks() bool {
+	if vs == nil {
+		return false
+	}
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return len(vs.tasks) > 0
+}
+
+// validateStart checks the variables of a new process instance, by definition key or id
+func (c *ActClient) validateStart(key, definitionID string, variables map[string]interface{}) error {
+	if key == "" {
+		key = processDefinitionKey(definitionID)
+	}
+	if v := c.validators.process(key); v != nil {
+		if errs := v.ValidateVariables(variables, false); len(errs) > 0 {
+			return &ValidationError{ProcessDefinitionKey: key, Errors: errs}
+		}
+	}
+	return nil
+}
+
+// validateProcessVariables checks variables set on a process instance. While process validators
+// are set it gets the process instance with get, an extra request, to look up its definition key
+func (c *ActClient) validateProcessVariables(pid string, variables map[string]interface{}, get func(pid string) (*ActProcessInstance, error)) error {
+	if !c.validators.hasProcesses() {
+		return nil
+	}
+	pi, err := get(pid)
+	if err != nil {
+		return err
+	}
+	key := pi.ProcessInstance.ProcessDefinitionKey
+	if v := c.validators.process(key); v != nil {
+		if errs := v.ValidateVariables(variables, true); len(errs) > 0 {
+			return &ValidationError{ProcessDefinitionKey: key, Errors: errs}
+		}
+	}
+	return nil
+}
+
+// validateTaskVariables checks variables completing a task. While task validators are set
+// it gets the task, an extra request, to look up its definition key
+func (c *ActClient) validateTaskVariables(tid string, variables map[string]interface{}) error {
+	if !c.validators.hasTasks() {
+		return nil
+	}
+	tk, err := c.GetTask(tid)
+	if err != nil {
+		return err
+	}
+	key := tk.Task.TaskDefinitionKey
+	if v := c.validators.task(key); v != nil {
+		if errs := v.ValidateVariables(variables, false); len(errs) > 0 {
+			return &ValidationError{ProcessDefinitionKey: processDefinitionKey(tk.Task.ProcessDefinitionId), TaskDefinitionKey: key, Errors: errs}
+		}
+	}
+	return nil
+}
+
+// processDefinitionKey returns the key of a key:version:uuid process definition id
+func processDefinitionKey(id string) string {
+	key, _, _ := strings.Cut(id, ":")
+	return key
+}
@@ -0,0 +1,147 @@
+package activiti
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// required rejects the variables without name, unless partial
+func required(name string) VariableValidator {
+	return VariableValidatorFunc(func(variables map[string]interface{}, partial bool) []VariableError {
+		if _, ok := variables[name]; !ok && !partial {
+			return []VariableError{{Field: name, Message: "is required"}}
+		}
+		if v, ok := variables[name]; ok && v == nil {
+			return []VariableError{{Field: name, Message: "is null"}}
+		}
+		return nil
+	})
+}
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		process   bool // Register a validator of the leave process
+		task      bool // Register a validator of the approve task
+		call      func(c *ActClient) error
+		wantKey   string // ProcessDefinitionKey of the ValidationError, none when empty
+		wantCalls []string
+	}{
+		{
+			name:      "start rejected without request",
+			process:   true,
+			call:      func(c *ActClient) error { _, err := c.StartProcessInstanceWithVariables("leave", nil); return err },
+			wantKey:   "leave",
+			wantCalls: nil,
+		},
+		{
+			name:      "set variables without validators",
+			call:      func(c *ActClient) error { return c.SetProcessVariables("pi1", map[string]interface{}{"days": nil}) },
+			wantCalls: []string{"POST /rb/v1/process-instances/pi1/variables"},
+		},
+		{
+			name:      "set variables rejected after looking up the key",
+			process:   true,
+			call:      func(c *ActClient) error { return c.SetProcessVariables("pi1", map[string]interface{}{"days": nil}) },
+			wantKey:   "leave",
+			wantCalls: []string{"GET /rb/v1/process-instances/pi1"},
+		},
+		{
+			name:    "set variables partial",
+			process: true,
+			call: func(c *ActClient) error {
+				return c.SetProcessVariables("pi1", map[string]interface{}{"reason": "trip"})
+			},
+			wantCalls: []string{"GET /rb/v1/process-instances/pi1", "POST /rb/v1/process-instances/pi1/variables"},
+		},
+		{
+			name:    "admin set variables rejected after looking up the key as admin",
+			process: true,
+			call: func(c *ActClient) error {
+				return c.AdminSetProcessVariables("pi1", map[string]interface{}{"days": nil})
+			},
+			wantKey:   "leave",
+			wantCalls: []string{"GET /rb/admin/v1/process-instances/pi1"},
+		},
+		{
+			name:    "admin set variables partial",
+			process: true,
+			call: func(c *ActClient) error {
+				return c.AdminSetProcessVariables("pi1", map[string]interface{}{"reason": "trip"})
+			},
+			wantCalls: []string{"GET /rb/admin/v1/process-instances/pi1", "PUT /rb/admin/v1/process-instances/pi1/variables"},
+		},
+		{
+			name:      "complete task rejected",
+			task:      true,
+			call:      func(c *ActClient) error { return c.CompleteTask("t1", nil) },
+			wantKey:   "leave",
+			wantCalls: []string{"GET /rb/v1/tasks/t1"},
+		},
+		{
+			name:      "complete task without task validators",
+			process:   true,
+			call:      func(c *ActClient) error { return c.CompleteTask("t1", nil) },
+			wantCalls: []string{"POST /rb/v1/tasks/t1/complete"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				switch {
+				case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/rb/v1/process-instances/"),
+					r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/rb/admin/v1/process-instances/"):
+					w.Write([]byte(`{"entry":{"id":"pi1","processDefinitionKey":"leave"}}`))
+				case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/rb/v1/tasks/"):
+					w.Write([]byte(`{"entry":{"id":"t1","taskDefinitionKey":"approve","processDefinitionId":"leave:1:abc"}}`))
+				}
+			}))
+			defer srv.Close()
+
+			c, _ := NewClient("token", srv.URL+"/rb/v1")
+			if tt.process {
+				c.SetProcessValidator("leave", required("days"))
+			}
+			if tt.task {
+				c.SetTaskValidator("approve", required("approved"))
+			}
+
+			err := tt.call(c)
+			var ve *ValidationError
+			switch {
+			case tt.wantKey == "" && err != nil:
+				t.Fatal(err)
+			case tt.wantKey != "" && !errors.As(err, &ve):
+				t.Fatalf("err = %v, want a ValidationError", err)
+			case tt.wantKey != "" && ve.ProcessDefinitionKey != tt.wantKey:
+				t.Errorf("key = %s, want %s", ve.ProcessDefinitionKey, tt.wantKey)
+			}
+			if strings.Join(calls, ", ") != strings.Join(tt.wantCalls, ", ") {
+				t.Errorf("calls = %q, want %q", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSetValidatorConcurrently(t *testing.T) {
+	c := &ActClient{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.SetProcessValidator("leave", required("days"))
+			c.SetTaskValidator("approve", required("approved"))
+		}()
+	}
+	wg.Wait()
+	if c.validators.process("leave") == nil || c.validators.task("approve") == nil {
+		t.Error("validators lost")
+	}
+}
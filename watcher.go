@@ -0,0 +1,278 @@
+package activiti
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the poll interval when WatchOptions.Interval is 0
+const DefaultWatchInterval = 5 * time.Second
+
+// watchDateLayout formats the lastModifiedFrom cursor sent to the query service
+const watchDateLayout = "2006-01-02T15:04:05.000Z"
+
+// ChangeKind says how an entity changed since the previous poll
+type ChangeKind string
+
+const (
+	ChangeCreated   ChangeKind = "created"
+	ChangeUpdated   ChangeKind = "updated"
+	ChangeCompleted ChangeKind = "completed" // Completed or cancelled
+)
+
+// finishedStatuses are the statuses reported as ChangeCompleted
+var finishedStatuses = map[string]bool{"COMPLETED": true, "CANCELLED": true, "DELETED": true}
+
+type (
+	// Change is a task or process instance which changed, exactly one of Task and ProcessInstance is set
+	Change struct {
+		Kind            ChangeKind
+		Task            *Task
+		ProcessInstance *ProcessInstance
+	}
+
+	// WatchOptions configures a Watcher
+	WatchOptions struct {
+		Interval time.Duration // DefaultWatchInterval when 0
+
+		// Tasks and ProcessInstances select what is watched, both when neither is set
+		Tasks            bool
+		ProcessInstances bool
+
+		// TaskParams and ProcessInstanceParams are passed as query service filters,
+		// for example url.Values{"processDefinitionKey": {"leave"}}
+		TaskParams            url.Values
+		ProcessInstanceParams url.Values
+
+		// Checkpoint resumes a previous watch. Without one only changes made after
+		// the first poll are reported
+		Checkpoint *WatchCheckpoint
+		// OnCheckpoint is called after every poll whose changes were all received
+		OnCheckpoint func(WatchCheckpoint)
+		// OnError is called with poll errors, the poll is retried on the next interval
+		OnError func(error)
+	}
+
+	// WatchCheckpoint is the position of a Watcher, it encodes to JSON for storage
+	WatchCheckpoint struct {
+		Tasks            WatchCursor `json:"tasks"`
+		ProcessInstances WatchCursor `json:"processInstances"`
+	}
+
+	// WatchCursor is the last modification seen and the ids modified at that instant
+	WatchCursor struct {
+		LastModified time.Time `json:"lastModified"`
+		Seen         []string  `json:"seen,omitempty"`
+	}
+
+	// Watcher polls the query service for tasks and process instances modified since
+	// the previous poll. Changes are delivered at least once: a failed poll is repeated
+	// from the last checkpoint. Entities without lastModified are not reported
+	Watcher struct {
+		c       *ActClient
+		opts    WatchOptions
+		changes chan Change
+
+		start sync.Once
+		mu    sync.Mutex
+		cp    WatchCheckpoint
+	}
+
+	// watched is an entity returned by a poll
+	watched struct {
+		id, lastModified, created, status string
+		change                            Change
+	}
+)
+
+// NewWatcher returns a watcher of the query service, started by Run
+func (c *ActClient) NewWatcher(opts WatchOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if !opts.Tasks && !opts.ProcessInstances {
+		opts.Tasks, opts.ProcessInstances = true, true
+	}
+	w := &Watcher{c: c, opts: opts, changes: make(chan Change)}
+	if opts.Checkpoint != nil {
+		w.cp = *opts.Checkpoint
+	}
+	return w
+}
+
+// Changes returns the channel of changes, closed when Run returns
+func (w *Watcher) Changes() <-chan Change {
+	return w.changes
+}
+
+// Checkpoint returns the position after the last poll whose changes were all received
+func (w *Watcher) Checkpoint() WatchCheckpoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cp
+}
+
+// Run polls until ctx is done
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.changes)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Poll(ctx); err != nil && ctx.Err() == nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll queries the changes since the checkpoint once and sends them on the channel
+func (w *Watcher) Poll(ctx context.Context) error {
+	w.start.Do(func() {
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		w.mu.Lock()
+		if w.cp.Tasks.LastModified.IsZero() {
+			w.cp.Tasks.LastModified = now
+		}
+		if w.cp.ProcessInstances.LastModified.IsZero() {
+			w.cp.ProcessInstances.LastModified = now
+		}
+		w.mu.Unlock()
+	})
+
+	c := w.c.WithContext(ctx)
+	if w.opts.Tasks {
+		w.mu.Lock()
+		cur := w.cp.Tasks
+		w.mu.Unlock()
+		next, err := w.poll(ctx, cur, func(p url.Values, add func(watched)) (Pagination, int, error) {
+			tks, err := c.QueryTasks(p)
+			if err != nil {
+				return Pagination{}, 0, err
+			}
+			for i := range tks.List.Tasks {
+				t := tks.List.Tasks[i].Task
+				add(watched{id: t.ID, lastModified: t.LastModified, created: t.CreatedDate, status: t.Status, change: Change{Task: &t}})
+			}
+			return tks.List.Pagination, len(tks.List.Tasks), nil
+		}, w.opts.TaskParams)
+		if err != nil {
+			return err
+		}
+		w.setCheckpoint(func(cp *WatchCheckpoint) { cp.Tasks = next })
+	}
+
+	if w.opts.ProcessInstances {
+		w.mu.Lock()
+		cur := w.cp.ProcessInstances
+		w.mu.Unlock()
+		next, err := w.poll(ctx, cur, func(p url.Values, add func(watched)) (Pagination, int, error) {
+			pis, err := c.QueryProcessInstances(p)
+			if err != nil {
+				return Pagination{}, 0, err
+			}
+			for i := range pis.List.ProcessInstances {
+				pi := pis.List.ProcessInstances[i].ProcessInstance
+				add(watched{id: pi.ID, lastModified: pi.LastModified, created: pi.StartDate, status: pi.Status, change: Change{ProcessInstance: &pi}})
+			}
+			return pis.List.Pagination, len(pis.List.ProcessInstances), nil
+		}, w.opts.ProcessInstanceParams)
+		if err != nil {
+			return err
+		}
+		w.setCheckpoint(func(cp *WatchCheckpoint) { cp.ProcessInstances = next })
+	}
+	return nil
+}
+
+// poll pages through the entities modified since the cursor, sends their changes and returns the next cursor.
+// Each page is queried from the last change sent, as skipCount offsets shift when entities are modified
+// during the poll. Only a page without a later modification, such as more entities modified at one
+// instant than a page holds, is skipped by offset
+func (w *Watcher) poll(ctx context.Context, cur WatchCursor, fetch func(url.Values, func(watched)) (Pagination, int, error), filters url.Values) (WatchCursor, error) {
+	params := url.Values{}
+	for k, v := range filters {
+		params[k] = v
+	}
+	params.Set("sort", "lastModified,asc")
+	if params.Get("maxItems") == "" {
+		params.Set("maxItems", strconv.Itoa(queryPageSize))
+	}
+
+	next := cur
+	seen := make(map[string]bool, len(cur.Seen))
+	for _, id := range cur.Seen {
+		seen[id] = true
+	}
+	for skip := 0; ; {
+		params.Set("lastModifiedFrom", next.LastModified.UTC().Format(watchDateLayout))
+		params.Set("skipCount", strconv.Itoa(skip))
+		var found []watched
+		page, n, err := fetch(params, func(e watched) { found = append(found, e) })
+		if err != nil {
+			return cur, err
+		}
+
+		advanced := false
+		for _, e := range found {
+			lm, err := ParseDate(e.lastModified)
+			if err != nil || lm.Before(next.LastModified) || (lm.Equal(next.LastModified) && seen[e.id]) {
+				continue
+			}
+
+			e.change.Kind = changeKind(e, cur.LastModified)
+			select {
+			case w.changes <- e.change:
+			case <-ctx.Done():
+				return cur, ctx.Err()
+			}
+
+			if lm.After(next.LastModified) {
+				next = WatchCursor{LastModified: lm}
+				seen = map[string]bool{}
+				advanced = true
+			}
+			next.Seen = append(append([]string(nil), next.Seen...), e.id)
+			seen[e.id] = true
+		}
+
+		if !page.HasMoreItems || n == 0 {
+			return next, nil
+		}
+		if advanced {
+			skip = 0
+		} else {
+			skip += n
+		}
+	}
+}
+
+// changeKind classifies a change found by a poll from the instant since
+func changeKind(e watched, since time.Time) ChangeKind {
+	if finishedStatuses[e.status] {
+		return ChangeCompleted
+	}
+	if created, err := ParseDate(e.created); err == nil && !created.Before(since) {
+		return ChangeCreated
+	}
+	return ChangeUpdated
+}
+
+// setCheckpoint updates the checkpoint and reports it
+func (w *Watcher) setCheckpoint(update func(*WatchCheckpoint)) {
+	w.mu.Lock()
+	update(&w.cp)
+	cp := w.cp
+	w.mu.Unlock()
+
+	if w.opts.OnCheckpoint != nil {
+		w.opts.OnCheckpoint(cp)
+	}
+}
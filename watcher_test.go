@@ -0,0 +1,320 @@
+package activiti
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchServer is a query service sorting by lastModified and filtering by lastModifiedFrom
+type watchServer struct {
+	mu        sync.Mutex
+	tasks     []Task
+	instances []ProcessInstance
+	fail      bool
+	queries   []string // lastModifiedFrom and skipCount of every task query
+	// afterPage is called after every task page is served, n counts from 1
+	afterPage func(s *watchServer, n int)
+}
+
+func (s *watchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	q := r.URL.Query()
+	from, _ := ParseDate(q.Get("lastModifiedFrom"))
+	skip, _ := strconv.Atoi(q.Get("skipCount"))
+	max, _ := strconv.Atoi(q.Get("maxItems"))
+	if q.Get("sort") != "lastModified,asc" {
+		http.Error(w, "unexpected sort "+q.Get("sort"), http.StatusBadRequest)
+		return
+	}
+	// page returns the entries of the page and whether more follow
+	page := func(n int, lastModified func(i int) string, id func(i int) string) ([]int, bool) {
+		var matching []int
+		for i := 0; i < n; i++ {
+			if lm, err := ParseDate(lastModified(i)); err == nil && !lm.Before(from) {
+				matching = append(matching, i)
+			}
+		}
+		sort.SliceStable(matching, func(a, b int) bool {
+			la, lb := lastModified(matching[a]), lastModified(matching[b])
+			return la < lb || la == lb && id(matching[a]) < id(matching[b])
+		})
+		if skip > len(matching) {
+			skip = len(matching)
+		}
+		end := skip + max
+		if end > len(matching) {
+			end = len(matching)
+		}
+		return matching[skip:end], end < len(matching)
+	}
+
+	switch r.URL.Path {
+	case "/query/v1/tasks":
+		s.queries = append(s.queries, q.Get("lastModifiedFrom")+" "+q.Get("skipCount"))
+		idx, more := page(len(s.tasks), func(i int) string { return s.tasks[i].LastModified }, func(i int) string { return s.tasks[i].ID })
+		l := ActListTasks{}
+		for _, i := range idx {
+			l.List.Tasks = append(l.List.Tasks, ActTask{Task: s.tasks[i]})
+		}
+		l.List.Pagination.HasMoreItems = more
+		json.NewEncoder(w).Encode(l)
+		if s.afterPage != nil {
+			s.afterPage(s, len(s.queries))
+		}
+	case "/query/v1/process-instances":
+		idx, more := page(len(s.instances), func(i int) string { return s.instances[i].LastModified }, func(i int) string { return s.instances[i].ID })
+		l := ActListProcessInstances{}
+		for _, i := range idx {
+			l.List.ProcessInstances = append(l.List.ProcessInstances, ActProcessInstance{ProcessInstance: s.instances[i]})
+		}
+		l.List.Pagination.HasMoreItems = more
+		json.NewEncoder(w).Encode(l)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// at returns the query service date of second n of the test day
+func at(n int) string {
+	return watchTime(n).Format(watchDateLayout)
+}
+
+func watchTime(n int) time.Time {
+	return time.Date(2026, 1, 1, 10, 0, n, 0, time.UTC)
+}
+
+// pollChanges polls once and returns the changes sent
+func pollChanges(w *Watcher) ([]Change, error) {
+	done := make(chan error, 1)
+	go func() { done <- w.Poll(context.Background()) }()
+	var got []Change
+	for {
+		select {
+		case ch := <-w.Changes():
+			got = append(got, ch)
+		case err := <-done:
+			return got, err
+		}
+	}
+}
+
+func changeIDs(changes []Change) []string {
+	var ids []string
+	for _, ch := range changes {
+		if ch.Task != nil {
+			ids = append(ids, ch.Task.ID)
+		} else {
+			ids = append(ids, ch.ProcessInstance.ID)
+		}
+	}
+	return ids
+}
+
+func TestWatcherPollCursor(t *testing.T) {
+	task := func(id string, lm int) Task {
+		return Task{ID: id, LastModified: at(lm), CreatedDate: at(0), Status: "ASSIGNED"}
+	}
+	tests := []struct {
+		name      string
+		tasks     []Task
+		afterPage func(s *watchServer, n int)
+		cursor    WatchCursor
+		want      []string
+		queries   []string
+		next      WatchCursor
+	}{
+		{
+			name:   "pages from the last change",
+			tasks:  []Task{task("a", 1), task("b", 2), task("c", 3), task("d", 4), task("e", 5)},
+			cursor: WatchCursor{LastModified: watchTime(0)},
+			want:   []string{"a", "b", "c", "d", "e"},
+			// Each page starts with the last change of the previous one
+			queries: []string{at(0) + " 0", at(2) + " 0", at(3) + " 0", at(4) + " 0"},
+			next:    WatchCursor{LastModified: watchTime(5), Seen: []string{"e"}},
+		},
+		{
+			// With skipCount offsets c would be missed once a moves to the end
+			name:  "modified during the poll",
+			tasks: []Task{task("a", 1), task("b", 2), task("c", 3), task("d", 4), task("e", 5)},
+			afterPage: func(s *watchServer, n int) {
+				if n == 1 {
+					s.tasks[0].LastModified = at(9)
+				}
+			},
+			cursor:  WatchCursor{LastModified: watchTime(0)},
+			want:    []string{"a", "b", "c", "d", "e", "a"},
+			queries: []string{at(0) + " 0", at(2) + " 0", at(3) + " 0", at(4) + " 0", at(5) + " 0"},
+			next:    WatchCursor{LastModified: watchTime(9), Seen: []string{"a"}},
+		},
+		{
+			name:    "instant larger than a page",
+			tasks:   []Task{task("a", 1), task("b", 1), task("c", 1), task("d", 1), task("e", 1), task("f", 2)},
+			cursor:  WatchCursor{LastModified: watchTime(0)},
+			want:    []string{"a", "b", "c", "d", "e", "f"},
+			queries: []string{at(0) + " 0", at(1) + " 0", at(1) + " 2", at(1) + " 4"},
+			next:    WatchCursor{LastModified: watchTime(2), Seen: []string{"f"}},
+		},
+		{
+			name:    "seen ids are skipped",
+			tasks:   []Task{task("a", 1), task("b", 1), task("c", 2)},
+			cursor:  WatchCursor{LastModified: watchTime(1), Seen: []string{"a"}},
+			want:    []string{"b", "c"},
+			queries: []string{at(1) + " 0", at(1) + " 2"},
+			next:    WatchCursor{LastModified: watchTime(2), Seen: []string{"c"}},
+		},
+		{
+			name:    "seen ids accumulate at one instant",
+			tasks:   []Task{task("a", 1), task("b", 1), task("c", 1)},
+			cursor:  WatchCursor{LastModified: watchTime(1), Seen: []string{"a"}},
+			want:    []string{"b", "c"},
+			queries: []string{at(1) + " 0", at(1) + " 2"},
+			next:    WatchCursor{LastModified: watchTime(1), Seen: []string{"a", "b", "c"}},
+		},
+		{
+			name:    "nothing changed",
+			tasks:   []Task{task("a", 1), {ID: "no date"}},
+			cursor:  WatchCursor{LastModified: watchTime(1), Seen: []string{"a"}},
+			queries: []string{at(1) + " 0"},
+			next:    WatchCursor{LastModified: watchTime(1), Seen: []string{"a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &watchServer{tasks: tt.tasks, afterPage: tt.afterPage}
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+			c, _ := NewClient("token", srv.URL+"/rb/v1")
+
+			var reported []WatchCheckpoint
+			w := c.NewWatcher(WatchOptions{
+				Tasks:        true,
+				TaskParams:   url.Values{"maxItems": {"2"}},
+				Checkpoint:   &WatchCheckpoint{Tasks: tt.cursor},
+				OnCheckpoint: func(cp WatchCheckpoint) { reported = append(reported, cp) },
+			})
+			changes, err := pollChanges(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := changeIDs(changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(s.queries, tt.queries) {
+				t.Errorf("queries = %q, want %q", s.queries, tt.queries)
+			}
+			if got := w.Checkpoint().Tasks; !got.LastModified.Equal(tt.next.LastModified) || !reflect.DeepEqual(got.Seen, tt.next.Seen) {
+				t.Errorf("checkpoint = %+v, want %+v", got, tt.next)
+			}
+			if len(reported) != 1 || !reflect.DeepEqual(reported[0], w.Checkpoint()) {
+				t.Errorf("reported %+v", reported)
+			}
+		})
+	}
+}
+
+func TestWatcherChangeKind(t *testing.T) {
+	s := &watchServer{
+		tasks: []Task{
+			{ID: "created", LastModified: at(3), CreatedDate: at(2), Status: "CREATED"},
+			{ID: "created at the cursor", LastModified: at(3), CreatedDate: at(1), Status: "ASSIGNED"},
+			{ID: "updated", LastModified: at(3), CreatedDate: at(0), Status: "ASSIGNED"},
+			{ID: "no creation date", LastModified: at(3), Status: "ASSIGNED"},
+			{ID: "completed", LastModified: at(3), CreatedDate: at(2), Status: "COMPLETED"},
+			{ID: "cancelled", LastModified: at(3), CreatedDate: at(0), Status: "CANCELLED"},
+		},
+		instances: []ProcessInstance{
+			{ID: "pi started", LastModified: at(3), StartDate: at(2), Status: "RUNNING"},
+			{ID: "pi updated", LastModified: at(3), StartDate: at(0), Status: "SUSPENDED"},
+			{ID: "pi deleted", LastModified: at(3), StartDate: at(0), Status: "DELETED"},
+		},
+	}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	c, _ := NewClient("token", srv.URL+"/rb/v1")
+
+	w := c.NewWatcher(WatchOptions{Checkpoint: &WatchCheckpoint{
+		Tasks:            WatchCursor{LastModified: watchTime(1)},
+		ProcessInstances: WatchCursor{LastModified: watchTime(1)},
+	}})
+	changes, err := pollChanges(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]ChangeKind{}
+	for i, id := range changeIDs(changes) {
+		got[id] = changes[i].Kind
+	}
+	want := map[string]ChangeKind{
+		"created":               ChangeCreated,
+		"created at the cursor": ChangeCreated,
+		"updated":               ChangeUpdated,
+		"no creation date":      ChangeUpdated,
+		"completed":             ChangeCompleted,
+		"cancelled":             ChangeCompleted,
+		"pi started":            ChangeCreated,
+		"pi updated":            ChangeUpdated,
+		"pi deleted":            ChangeCompleted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kinds = %v, want %v", got, want)
+	}
+}
+
+func TestWatcherFailedPoll(t *testing.T) {
+	s := &watchServer{tasks: []Task{{ID: "a", LastModified: at(2), Status: "ASSIGNED"}}, fail: true}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	c, _ := NewClient("token", srv.URL+"/rb/v1")
+
+	cp := WatchCheckpoint{Tasks: WatchCursor{LastModified: watchTime(1)}}
+	w := c.NewWatcher(WatchOptions{Tasks: true, Checkpoint: &cp})
+	if _, err := pollChanges(w); err == nil {
+		t.Fatal("got no error")
+	}
+	if got := w.Checkpoint().Tasks; !reflect.DeepEqual(got, cp.Tasks) {
+		t.Errorf("checkpoint moved to %+v", got)
+	}
+
+	// The next poll repeats from the checkpoint
+	s.mu.Lock()
+	s.fail = false
+	s.mu.Unlock()
+	changes, err := pollChanges(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := changeIDs(changes); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("changes = %q", got)
+	}
+}
+
+func TestWatcherWithoutCheckpoint(t *testing.T) {
+	s := &watchServer{tasks: []Task{{ID: "old", LastModified: time.Now().UTC().Add(-time.Hour).Format(watchDateLayout)}}}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	c, _ := NewClient("token", srv.URL+"/rb/v1")
+
+	w := c.NewWatcher(WatchOptions{Tasks: true})
+	changes, err := pollChanges(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %q, want only those after the first poll", changeIDs(changes))
+	}
+}